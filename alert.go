@@ -3,7 +3,6 @@ package types
 import (
 	"crypto/sha256"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -21,8 +20,14 @@ var (
 	// IconRegex matches valid Slack icon emojis, on the format ':emoji:'.
 	IconRegex = regexp.MustCompile(fmt.Sprintf(`^:[^:]{1,%d}:$`, MaxIconEmojiLength))
 
-	// SlackMentionRegex matches valid Slack mentions, such as <!here>, <!channel> and <@U12345678>.
-	SlackMentionRegex = regexp.MustCompile(fmt.Sprintf(`^((<!here>)|(<!channel>)|(<@[^>\s]{1,%d}>))$`, MaxMentionLength))
+	// SlackMentionRegex matches Slack mentions that can be posted as they are, such as <!here>, <!channel>, <@U12345678>
+	// and <!subteam^S12345678>. Email mentions are not matched, since they must be resolved first (see Alert.ResolveMentions).
+	SlackMentionRegex = regexp.MustCompile(fmt.Sprintf(`^((<!here>)|(<!channel>)|(<@[^>\s]{1,%d}>)|(<!subteam\^[^>\s]{1,%d}>))$`, MaxMentionLength, MaxMentionLength))
+
+	// The exported regexes above are bound to the default length limits.
+	// These variants check the format only, with lengths checked separately against the active Limits.
+	slackChannelIDOrNameCharsRegex = regexp.MustCompile(`^[0-9a-zA-Z\-_]+$`)
+	iconCharsRegex                 = regexp.MustCompile(`^:[^:]+:$`)
 )
 
 const (
@@ -61,6 +66,8 @@ const (
 	MaxIconEmojiLength = 50
 	// MaxMentionLength is the maximum length of a Slack mention (excluding angle brackets).
 	MaxMentionLength = 20
+	// MaxMentionEmailLength is the maximum length of an email mention.
+	MaxMentionEmailLength = 254
 	// MaxCorrelationIDLength is the maximum length of the correlation ID.
 	MaxCorrelationIDLength = 500
 
@@ -126,6 +133,17 @@ const (
 	MinEscalationDelayDiffSeconds = 30
 	// MaxEscalationSlackMentionCount is the maximum number of Slack mentions per escalation.
 	MaxEscalationSlackMentionCount = 10
+	// MaxEscalationWindowCount is the maximum number of windows per escalation schedule.
+	MaxEscalationWindowCount = 20
+	// MaxEscalationHolidayCount is the maximum number of holidays per escalation schedule.
+	MaxEscalationHolidayCount = 100
+	// MaxEscalationRepeatCount is the maximum number of reminders per escalation.
+	MaxEscalationRepeatCount = 20
+	// MaxEscalationPolicyIDLength is the maximum length of an escalation policy ID.
+	MaxEscalationPolicyIDLength = 100
+
+	// MaxAlertBatchSize is the maximum number of alerts in an AlertBatch.
+	MaxAlertBatchSize = 100
 )
 
 // Alert represents a single alert that can be sent to the Slack Manager.
@@ -135,7 +153,7 @@ type Alert struct {
 	Timestamp time.Time `json:"timestamp"`
 
 	// CorrelationID is an optional field used to group related alerts together in issues.
-	// If unset, the correlation ID is constructed by hashing [Header, Text, Author, Host, SlackChannelID].
+	// If unset, the correlation ID is constructed by hashing [Header, Text, Author, Host, SlackChannelID] (see DefaultCorrelationID).
 	// It is strongly recommended to set this to an explicit value, which makes sense in your context, rather than relying on the default hash value.
 	// With a custom correlation ID, you can update both header and text without creating a new issue.
 	CorrelationID string `json:"correlationId"`
@@ -253,11 +271,22 @@ type Alert struct {
 	// Maximum of MaxEscalationCount escalations allowed.
 	Escalation []*Escalation `json:"escalation"`
 
+	// EscalationPolicy is the ID of a named EscalationPolicy, used instead of Escalation.
+	// The reference is replaced with the policy's escalation points by ResolveEscalationPolicy, when the alert is ingested.
+	// Cannot be combined with Escalation.
+	EscalationPolicy string `json:"escalationPolicy,omitempty"`
+
 	// IgnoreIfTextContains is a list of substrings that, if found in the alert text, will cause the alert to be ignored.
 	// This is useful for filtering out known noise or false positives.
 	// Maximum of MaxIgnoreIfTextContainsCount items, each up to MaxIgnoreIfTextContainsLength characters.
 	IgnoreIfTextContains []string `json:"ignoreIfTextContains"`
 
+	// EscapeText makes Clean escape the header, text, fallback text, author, host, footer and field titles and values
+	// for Slack (see EscapeSlackText), so that they cannot mention users, groups or channels. Links are kept.
+	// Only Escalation.SlackMentions can then notify anyone. Note that IgnoreIfTextContains is matched against the escaped text.
+	// Escaping can also be enabled for all alerts, with Limits.EscapeText.
+	EscapeText bool `json:"escapeText"`
+
 	// Webhooks defines interactive buttons that appear on the Slack post.
 	// Each webhook triggers an HTTP POST to the specified URL when clicked.
 	// Webhooks can include confirmation dialogs, input forms, and access level restrictions.
@@ -292,10 +321,25 @@ type Escalation struct {
 	DelaySeconds int `json:"delaySeconds"`
 
 	// SlackMentions is a list of Slack mentions that should be added to the Slack post when the escalation is triggered.
+	// Users, user groups, @here, @channel and email addresses are accepted (see ParseMention). Email addresses are replaced
+	// with user mentions by ResolveMentions.
 	SlackMentions []string `json:"slackMentions"`
 
 	// MoveToChannel is the ID or name of the Slack channel where the alert should be moved when the escalation is triggered.
 	MoveToChannel string `json:"moveToChannel"`
+
+	// RepeatSeconds repeats the Slack mentions every RepeatSeconds after the escalation has fired, as reminders,
+	// until the issue is resolved or acknowledged, or the next escalation point fires. Zero disables reminders.
+	// Must be at least MinEscalationDelayDiffSeconds. See Alert.EscalationReminders.
+	RepeatSeconds int `json:"repeatSeconds,omitempty"`
+
+	// MaxRepeats is the maximum number of reminders, required when RepeatSeconds is set.
+	// Maximum of MaxEscalationRepeatCount reminders allowed.
+	MaxRepeats int `json:"maxRepeats,omitempty"`
+
+	// Schedule optionally restricts when the escalation fires, such as to business hours.
+	// If nil, the escalation fires when DelaySeconds has passed. See EvaluateEscalation.
+	Schedule *EscalationSchedule `json:"schedule,omitempty"`
 }
 
 // Webhook represents an interactive button that appears on the Slack post.
@@ -456,603 +500,694 @@ func NewAlert(severity AlertSeverity) *Alert {
 // UniqueID returns a unique and deterministic ID for this alert, for database/storage purposes.
 // The ID is based on certain fields of the alert, and is base64 encoded to ensure it is safe for use in URLs and as a database key.
 func (a *Alert) UniqueID() string {
-	return hash("alert", a.SlackChannelID, a.RouteKey, a.CorrelationID, a.Timestamp.UTC().Format(time.RFC3339Nano), a.Header, a.Text)
+	return a.uniqueIDAt(a.Timestamp)
 }
 
-// Clean normalizes and sanitizes all alert fields.
+// uniqueIDAt returns the UniqueID the alert would have with the specified timestamp.
+func (a *Alert) uniqueIDAt(timestamp time.Time) string {
+	return hash("alert", a.SlackChannelID, a.RouteKey, a.CorrelationID, timestamp.UTC().Format(time.RFC3339Nano), a.Header, a.Text)
+}
+
+// Clean normalizes and sanitizes all alert fields, using the default limits.
 // It trims whitespace, normalizes case where appropriate, truncates fields that exceed maximum lengths,
 // and applies default values for empty or invalid fields (e.g., sets Severity to 'error' if empty).
 // This method should be called before validation to ensure consistent data.
 func (a *Alert) Clean() {
-	if time.Since(a.Timestamp) > MaxTimestampAge {
+	a.CleanWith(DefaultLimits())
+}
+
+// CleanWithReport cleans the alert exactly like Clean, and returns a list of all changes that were applied.
+// The list is empty if the alert was already clean.
+// This is useful for surfacing warnings to alert producers, e.g. when text was truncated or a stale timestamp was replaced.
+func (a *Alert) CleanWithReport() []*CleanChange {
+	return a.CleanWith(DefaultLimits())
+}
+
+// CleanWith cleans the alert like CleanWithReport, using the specified limits instead of the default limits.
+// If limits is nil, the default limits are used. The list of applied changes is returned.
+func (a *Alert) CleanWith(limits *Limits) []*CleanChange {
+	c := newCleaner(limits)
+	c.escaping = a.EscapeText || c.limits.EscapeText
+
+	if time.Since(a.Timestamp) > c.limits.MaxTimestampAge {
 		a.Timestamp = time.Now()
+		c.record("timestamp", CleanChangeReplaced, 0, 0)
 	}
 
-	a.Type = strings.ToLower(strings.TrimSpace(a.Type))
-	a.SlackChannelID = strings.ToUpper(strings.TrimSpace(a.SlackChannelID))
-	a.RouteKey = strings.ToLower(strings.TrimSpace(a.RouteKey))
-	a.Header = strings.ReplaceAll(strings.TrimSpace(a.Header), "\n", " ")
-	a.HeaderWhenResolved = strings.ReplaceAll(strings.TrimSpace(a.HeaderWhenResolved), "\n", " ")
-	a.Text = strings.TrimSpace(a.Text)
-	a.TextWhenResolved = strings.TrimSpace(a.TextWhenResolved)
-	a.FallbackText = strings.TrimSpace(strings.ReplaceAll(a.FallbackText, ":status:", ""))
-	a.FallbackText = strings.ReplaceAll(a.FallbackText, "\n", " ")
-	a.CorrelationID = strings.TrimSpace(a.CorrelationID)
-	a.Username = strings.TrimSpace(a.Username)
-	a.Author = strings.TrimSpace(a.Author)
-	a.Host = strings.TrimSpace(a.Host)
-	a.Link = strings.TrimSpace(a.Link)
-	a.Footer = strings.TrimSpace(a.Footer)
-	a.IconEmoji = strings.ToLower(strings.TrimSpace(a.IconEmoji))
-	a.Severity = AlertSeverity(strings.ToLower(strings.TrimSpace(string(a.Severity))))
-
-	if utf8.RuneCountInString(a.FallbackText) > MaxFallbackTextLength {
-		a.FallbackText = truncateString(a.FallbackText, MaxFallbackTextLength-3) + "..."
-	}
+	c.normalize("type", &a.Type, trimLower)
+	c.normalize("slackChannelId", &a.SlackChannelID, trimUpper)
+	c.normalize("routeKey", &a.RouteKey, trimLower)
+	c.normalize("header", &a.Header, trimSingleLine)
+	c.normalize("headerWhenResolved", &a.HeaderWhenResolved, trimSingleLine)
+	c.normalize("text", &a.Text, strings.TrimSpace)
+	c.normalize("textWhenResolved", &a.TextWhenResolved, strings.TrimSpace)
+	c.normalize("fallbackText", &a.FallbackText, func(s string) string {
+		return strings.ReplaceAll(strings.TrimSpace(strings.ReplaceAll(s, ":status:", "")), "\n", " ")
+	})
+	c.normalize("correlationId", &a.CorrelationID, strings.TrimSpace)
+	c.normalize("username", &a.Username, strings.TrimSpace)
+	c.normalize("author", &a.Author, strings.TrimSpace)
+	c.normalize("host", &a.Host, strings.TrimSpace)
+	c.normalize("link", &a.Link, strings.TrimSpace)
+	c.normalize("footer", &a.Footer, strings.TrimSpace)
+	c.normalize("iconEmoji", &a.IconEmoji, trimLower)
+
+	severity := string(a.Severity)
+	c.normalize("severity", &severity, trimLower)
+	a.Severity = AlertSeverity(severity)
+
+	// Escaping must happen before truncation, since it makes the values longer
+	c.escape("header", &a.Header)
+	c.escape("headerWhenResolved", &a.HeaderWhenResolved)
+	c.escape("text", &a.Text)
+	c.escape("textWhenResolved", &a.TextWhenResolved)
+	c.escape("fallbackText", &a.FallbackText)
+	c.escape("author", &a.Author)
+	c.escape("host", &a.Host)
+	c.escape("footer", &a.Footer)
+
+	c.apply("fallbackText", CleanChangeTruncated, &a.FallbackText, func(s string) string {
+		if utf8.RuneCountInString(s) > c.limits.MaxFallbackTextLength {
+			return c.shorten(s, c.limits.MaxFallbackTextLength, "...")
+		}
+		return s
+	})
 
-	if a.Severity == "" || a.Severity == "critical" {
+	switch a.Severity {
+	case "":
 		a.Severity = AlertError
+		c.record("severity", CleanChangeDefaulted, 0, len(a.Severity))
+	case "critical":
+		a.Severity = AlertError
+		c.record("severity", CleanChangeReplaced, len("critical"), len(a.Severity))
 	}
 
-	if a.ArchivingDelaySeconds < 0 {
-		a.ArchivingDelaySeconds = 0
-	}
-
-	if a.NotificationDelaySeconds < 0 {
-		a.NotificationDelaySeconds = 0
-	}
+	c.clampNegative("archivingDelaySeconds", &a.ArchivingDelaySeconds)
+	c.clampNegative("notificationDelaySeconds", &a.NotificationDelaySeconds)
 
 	// Max length in the Slack API is 150, see https://api.slack.com/reference/block-kit/blocks#header
 	// We also need to leave some space for the :status: emoji to be replaced with something a bit longer by the Slack Manager
-	if utf8.RuneCountInString(a.Header) > MaxHeaderLength {
-		a.Header = strings.TrimSpace(truncateString(a.Header, MaxHeaderLength-3)) + "..."
-	}
-
-	if utf8.RuneCountInString(a.HeaderWhenResolved) > MaxHeaderLength {
-		a.HeaderWhenResolved = strings.TrimSpace(truncateString(a.HeaderWhenResolved, MaxHeaderLength-3)) + "..."
-	}
-
-	a.Text = shortenAlertTextIfNeeded(a.Text)
-	a.TextWhenResolved = shortenAlertTextIfNeeded(a.TextWhenResolved)
+	c.truncate("header", &a.Header, c.limits.MaxHeaderLength)
+	c.truncate("headerWhenResolved", &a.HeaderWhenResolved, c.limits.MaxHeaderLength)
 
-	if utf8.RuneCountInString(a.Author) > MaxAuthorLength {
-		a.Author = strings.TrimSpace(truncateString(a.Author, MaxAuthorLength-3)) + "..."
-	}
-
-	if utf8.RuneCountInString(a.Host) > MaxHostLength {
-		a.Host = strings.TrimSpace(truncateString(a.Host, MaxHostLength-3)) + "..."
-	}
+	c.apply("text", CleanChangeTruncated, &a.Text, c.shortenText)
+	c.apply("textWhenResolved", CleanChangeTruncated, &a.TextWhenResolved, c.shortenText)
 
-	if utf8.RuneCountInString(a.Username) > MaxUsernameLength {
-		a.Username = strings.TrimSpace(truncateString(a.Username, MaxUsernameLength-3)) + "..."
-	}
-
-	if utf8.RuneCountInString(a.Footer) > MaxFooterLength {
-		a.Footer = strings.TrimSpace(truncateString(a.Footer, MaxFooterLength-3)) + "..."
-	}
+	c.truncate("author", &a.Author, c.limits.MaxAuthorLength)
+	c.truncate("host", &a.Host, c.limits.MaxHostLength)
+	c.truncate("username", &a.Username, c.limits.MaxUsernameLength)
+	c.truncate("footer", &a.Footer, c.limits.MaxFooterLength)
 
-	for _, field := range a.Fields {
+	for index, field := range a.Fields {
 		if field == nil {
 			continue
 		}
 
-		field.Title = strings.TrimSpace(field.Title)
-		field.Value = strings.TrimSpace(field.Value)
+		path := fmt.Sprintf("fields[%d]", index)
 
-		if utf8.RuneCountInString(field.Title) > MaxFieldTitleLength {
-			field.Title = strings.TrimSpace(truncateString(field.Title, MaxFieldTitleLength-3)) + "..."
-		}
-
-		if utf8.RuneCountInString(field.Value) > MaxFieldValueLength {
-			field.Value = strings.TrimSpace(truncateString(field.Value, MaxFieldValueLength-3)) + "..."
-		}
+		c.normalize(path+".title", &field.Title, strings.TrimSpace)
+		c.normalize(path+".value", &field.Value, strings.TrimSpace)
+		c.escape(path+".title", &field.Title)
+		c.escape(path+".value", &field.Value)
+		c.truncate(path+".title", &field.Title, c.limits.MaxFieldTitleLength)
+		c.truncate(path+".value", &field.Value, c.limits.MaxFieldValueLength)
 	}
 
-	for _, hook := range a.Webhooks {
+	for index, hook := range a.Webhooks {
 		if hook == nil {
 			continue
 		}
 
-		hook.ID = strings.TrimSpace(hook.ID)
-		hook.ButtonText = strings.TrimSpace(hook.ButtonText)
-		hook.URL = strings.TrimSpace(hook.URL)
-		hook.ConfirmationText = strings.TrimSpace(hook.ConfirmationText)
+		path := fmt.Sprintf("webhooks[%d]", index)
+
+		c.normalize(path+".id", &hook.ID, strings.TrimSpace)
+		c.normalize(path+".buttonText", &hook.ButtonText, strings.TrimSpace)
+		c.normalize(path+".url", &hook.URL, strings.TrimSpace)
+		c.normalize(path+".confirmationText", &hook.ConfirmationText, strings.TrimSpace)
 
 		if hook.ButtonStyle == "default" {
 			hook.ButtonStyle = ""
+			c.record(path+".buttonStyle", CleanChangeNormalized, len("default"), 0)
 		}
 
-		for _, input := range hook.PlainTextInput {
+		for inputIndex, input := range hook.PlainTextInput {
 			if input == nil {
 				continue
 			}
 
-			input.ID = strings.TrimSpace(input.ID)
-			input.Description = strings.TrimSpace(input.Description)
-			input.InitialValue = strings.TrimSpace(input.InitialValue)
+			inputPath := fmt.Sprintf("%s.plainTextInput[%d]", path, inputIndex)
+
+			c.normalize(inputPath+".id", &input.ID, strings.TrimSpace)
+			c.normalize(inputPath+".description", &input.Description, strings.TrimSpace)
+			c.normalize(inputPath+".initialValue", &input.InitialValue, strings.TrimSpace)
 		}
 
-		for _, input := range hook.CheckboxInput {
+		for inputIndex, input := range hook.CheckboxInput {
 			if input == nil {
 				continue
 			}
 
-			input.ID = strings.TrimSpace(input.ID)
-			input.Label = strings.TrimSpace(input.Label)
+			inputPath := fmt.Sprintf("%s.checkboxInput[%d]", path, inputIndex)
+
+			c.normalize(inputPath+".id", &input.ID, strings.TrimSpace)
+			c.normalize(inputPath+".label", &input.Label, strings.TrimSpace)
 		}
 	}
 
-	if len(a.Escalation) > 0 {
-		sort.Slice(a.Escalation, func(i, j int) bool {
-			if a.Escalation[i] == nil {
-				return true
-			}
-			if a.Escalation[j] == nil {
-				return false
-			}
-			return a.Escalation[i].DelaySeconds < a.Escalation[j].DelaySeconds
-		})
-
-		for _, e := range a.Escalation {
-			if e == nil {
-				continue
-			}
+	c.normalize("escalationPolicy", &a.EscalationPolicy, strings.TrimSpace)
 
-			e.Severity = AlertSeverity(strings.ToLower(strings.TrimSpace(string(e.Severity))))
-			e.MoveToChannel = strings.ToUpper(strings.TrimSpace(e.MoveToChannel))
+	cleanEscalation(c, "escalation", a.Escalation)
 
-			for i, mention := range e.SlackMentions {
-				e.SlackMentions[i] = strings.TrimSpace(mention)
-			}
-		}
-	}
+	return c.changes
 }
 
-// Validate returns an error if one or more of the required fields are empty or invalid
-func (a *Alert) Validate() error {
-	if a == nil {
-		return errors.New("alert is nil")
+// cleanEscalation sorts the escalation points by DelaySeconds (in place), and normalizes their values.
+// The path is the JSON path of the escalation points, such as 'escalation'.
+func cleanEscalation(c *cleaner, path string, escalation []*Escalation) {
+	if len(escalation) == 0 {
+		return
 	}
 
-	if err := a.ValidateSlackChannelIDAndRouteKey(); err != nil {
-		return err
-	}
+	sorted := sort.SliceIsSorted(escalation, func(i, j int) bool {
+		return escalationLess(escalation[i], escalation[j])
+	})
 
-	if err := a.ValidateHeaderAndText(); err != nil {
-		return err
+	if !sorted {
+		sort.Slice(escalation, func(i, j int) bool {
+			return escalationLess(escalation[i], escalation[j])
+		})
+		c.record(path, CleanChangeReordered, 0, 0)
 	}
 
-	if err := a.ValidateIcon(); err != nil {
-		return err
-	}
+	for index, e := range escalation {
+		if e == nil {
+			continue
+		}
 
-	if err := a.ValidateLink(); err != nil {
-		return err
-	}
+		pointPath := fmt.Sprintf("%s[%d]", path, index)
 
-	if err := a.ValidateSeverity(); err != nil {
-		return err
-	}
+		severity := string(e.Severity)
+		c.normalize(pointPath+".severity", &severity, trimLower)
+		e.Severity = AlertSeverity(severity)
 
-	if err := a.ValidateCorrelationID(); err != nil {
-		return err
-	}
+		c.normalize(pointPath+".moveToChannel", &e.MoveToChannel, trimUpper)
 
-	if err := a.ValidateAutoResolve(); err != nil {
-		return err
-	}
+		for i := range e.SlackMentions {
+			c.normalize(fmt.Sprintf("%s.slackMentions[%d]", pointPath, i), &e.SlackMentions[i], strings.TrimSpace)
+		}
 
-	if err := a.ValidateFields(); err != nil {
-		return err
+		if e.Schedule != nil {
+			e.Schedule.clean(c, pointPath+".schedule")
+		}
 	}
+}
 
-	if err := a.ValidateWebhooks(); err != nil {
-		return err
+// escalationLess orders escalation points by DelaySeconds, with nil escalation points first.
+func escalationLess(a, b *Escalation) bool {
+	if a == nil {
+		return b != nil
+	}
+	if b == nil {
+		return false
 	}
+	return a.DelaySeconds < b.DelaySeconds
+}
 
-	if err := a.ValidateEscalation(); err != nil {
-		return err
+// Validate returns an error if one or more of the required fields are empty or invalid, using the default limits.
+// Only the first validation failure is returned, as a *ValidationError. Use ValidateAll to get all failures.
+func (a *Alert) Validate() error {
+	return a.ValidateWith(DefaultLimits())
+}
+
+// ValidateWith validates the alert like Validate, using the specified limits instead of the default limits.
+// If limits is nil, the default limits are used.
+func (a *Alert) ValidateWith(limits *Limits) error {
+	v := newValidator(limits)
+	a.validate(v)
+	return v.first()
+}
+
+// ValidateAll validates the alert like Validate, but does not stop at the first failure.
+// All failures are returned as a ValidationErrors aggregate, or nil if the alert is valid.
+// Use errors.As to extract either the ValidationErrors or the individual *ValidationError values.
+func (a *Alert) ValidateAll() error {
+	return a.ValidateAllWith(DefaultLimits())
+}
+
+// ValidateAllWith validates the alert like ValidateAll, using the specified limits instead of the default limits.
+// If limits is nil, the default limits are used.
+func (a *Alert) ValidateAllWith(limits *Limits) error {
+	v := newValidator(limits)
+	a.validate(v)
+	return v.all()
+}
+
+func (a *Alert) validate(v *validator) {
+	if a == nil {
+		v.add("", ValidationErrorCodeRequired, 0, nil, "alert is nil")
+		return
 	}
 
-	return a.ValidateIgnoreIfTextContains()
+	a.validateSlackChannelIDAndRouteKey(v)
+	a.validateHeaderAndText(v)
+	a.validateIcon(v)
+	a.validateLink(v)
+	a.validateSeverity(v)
+	a.validateCorrelationID(v)
+	a.validateAutoResolve(v)
+	a.validateFields(v)
+	a.validateWebhooks(v)
+	a.validateEscalation(v)
+	a.validateEscalationPolicy(v)
+	a.validateIgnoreIfTextContains(v)
 }
 
 // ValidateSlackChannelIDAndRouteKey validates that SlackChannelID and RouteKey are valid, if set.
 // Both values are allowed to be empty (in which case a fallback mapping must exist in the API).
 func (a *Alert) ValidateSlackChannelIDAndRouteKey() error {
+	v := newValidator(nil)
+	a.validateSlackChannelIDAndRouteKey(v)
+	return v.first()
+}
+
+func (a *Alert) validateSlackChannelIDAndRouteKey(v *validator) {
 	if a.SlackChannelID != "" {
-		if !SlackChannelIDOrNameRegex.MatchString(a.SlackChannelID) {
-			return fmt.Errorf("slackChannelId '%s' is not valid", a.SlackChannelID)
+		if len(a.SlackChannelID) > v.limits.MaxSlackChannelIDLength {
+			v.add("slackChannelId", ValidationErrorCodeTooLong, v.limits.MaxSlackChannelIDLength, len(a.SlackChannelID), "slackChannelId '%s' is not valid", a.SlackChannelID)
+		} else if !slackChannelIDOrNameCharsRegex.MatchString(a.SlackChannelID) {
+			v.add("slackChannelId", ValidationErrorCodeInvalidFormat, 0, a.SlackChannelID, "slackChannelId '%s' is not valid", a.SlackChannelID)
 		}
 
-		return nil
+		return
 	}
 
-	if len(a.RouteKey) > MaxRouteKeyLength {
-		return fmt.Errorf("routeKey is too long, expected length <=%d", MaxRouteKeyLength)
+	if len(a.RouteKey) > v.limits.MaxRouteKeyLength {
+		v.add("routeKey", ValidationErrorCodeTooLong, v.limits.MaxRouteKeyLength, len(a.RouteKey), "routeKey is too long, expected length <=%d", v.limits.MaxRouteKeyLength)
 	}
-
-	return nil
 }
 
 // ValidateHeaderAndText validates that at least one of Header or Text is non-empty.
 // An alert must have either a header or text content to be meaningful.
 func (a *Alert) ValidateHeaderAndText() error {
+	v := newValidator(nil)
+	a.validateHeaderAndText(v)
+	return v.first()
+}
+
+func (a *Alert) validateHeaderAndText(v *validator) {
 	if a.Header == "" && a.Text == "" {
-		return errors.New("header and text cannot both be empty")
+		v.add("header", ValidationErrorCodeRequired, 0, nil, "header and text cannot both be empty")
 	}
-
-	return nil
 }
 
 // ValidateIcon validates that IconEmoji, if set, matches the expected Slack emoji format ':emoji:'.
 func (a *Alert) ValidateIcon() error {
+	v := newValidator(nil)
+	a.validateIcon(v)
+	return v.first()
+}
+
+func (a *Alert) validateIcon(v *validator) {
 	if a.IconEmoji == "" {
-		return nil
+		return
 	}
 
-	if !IconRegex.MatchString(a.IconEmoji) {
-		return fmt.Errorf("iconEmoji '%s' is not valid", a.IconEmoji)
+	if !iconCharsRegex.MatchString(a.IconEmoji) {
+		v.add("iconEmoji", ValidationErrorCodeInvalidFormat, 0, a.IconEmoji, "iconEmoji '%s' is not valid", a.IconEmoji)
+	} else if utf8.RuneCountInString(a.IconEmoji)-2 > v.limits.MaxIconEmojiLength {
+		v.add("iconEmoji", ValidationErrorCodeTooLong, v.limits.MaxIconEmojiLength, utf8.RuneCountInString(a.IconEmoji)-2, "iconEmoji '%s' is not valid", a.IconEmoji)
 	}
-
-	return nil
 }
 
 // ValidateLink validates that Link, if set, is a valid absolute URL with a scheme.
 func (a *Alert) ValidateLink() error {
+	v := newValidator(nil)
+	a.validateLink(v)
+	return v.first()
+}
+
+func (a *Alert) validateLink(v *validator) {
 	if a.Link == "" {
-		return nil
+		return
 	}
 
 	url, err := url.ParseRequestURI(a.Link)
-	if err != nil {
-		return errors.New("link is not a valid absolute URL")
+	if err != nil || url.Scheme == "" {
+		v.add("link", ValidationErrorCodeInvalidFormat, 0, a.Link, "link is not a valid absolute URL")
 	}
-
-	if url.Scheme == "" {
-		return errors.New("link is not a valid absolute URL")
-	}
-
-	return nil
 }
 
 // ValidateSeverity validates that Severity is one of the allowed AlertSeverity values.
 func (a *Alert) ValidateSeverity() error {
+	v := newValidator(nil)
+	a.validateSeverity(v)
+	return v.first()
+}
+
+func (a *Alert) validateSeverity(v *validator) {
 	if !SeverityIsValid(a.Severity) {
-		return fmt.Errorf("severity '%s' is not valid, expected one of [%s]", a.Severity, strings.Join(ValidSeverities(), ", "))
+		v.add("severity", ValidationErrorCodeInvalidValue, 0, string(a.Severity), "severity '%s' is not valid, expected one of [%s]", a.Severity, strings.Join(ValidSeverities(), ", "))
 	}
-
-	return nil
 }
 
 // ValidateCorrelationID validates that CorrelationID, if set, does not exceed MaxCorrelationIDLength.
 func (a *Alert) ValidateCorrelationID() error {
-	if a.CorrelationID == "" {
-		return nil
-	}
+	v := newValidator(nil)
+	a.validateCorrelationID(v)
+	return v.first()
+}
 
-	if len(a.CorrelationID) > MaxCorrelationIDLength {
-		return fmt.Errorf("correlationId is too long, expected length <=%d", MaxCorrelationIDLength)
+func (a *Alert) validateCorrelationID(v *validator) {
+	if len(a.CorrelationID) > v.limits.MaxCorrelationIDLength {
+		v.add("correlationId", ValidationErrorCodeTooLong, v.limits.MaxCorrelationIDLength, len(a.CorrelationID), "correlationId is too long, expected length <=%d", v.limits.MaxCorrelationIDLength)
 	}
-
-	return nil
 }
 
 // ValidateAutoResolve validates that AutoResolveSeconds is within the allowed range
 // when IssueFollowUpEnabled is true.
 func (a *Alert) ValidateAutoResolve() error {
-	if !a.IssueFollowUpEnabled {
-		return nil
-	}
+	v := newValidator(nil)
+	a.validateAutoResolve(v)
+	return v.first()
+}
 
-	if a.AutoResolveSeconds < MinAutoResolveSeconds {
-		return fmt.Errorf("autoResolveSeconds %d is too low, expected value >=%d", a.AutoResolveSeconds, MinAutoResolveSeconds)
+func (a *Alert) validateAutoResolve(v *validator) {
+	if !a.IssueFollowUpEnabled {
+		return
 	}
 
-	if a.AutoResolveSeconds > MaxAutoResolveSeconds {
-		return fmt.Errorf("autoResolveSeconds %d is too high, expected value <=%d", a.AutoResolveSeconds, MaxAutoResolveSeconds)
+	if a.AutoResolveSeconds < v.limits.MinAutoResolveSeconds {
+		v.add("autoResolveSeconds", ValidationErrorCodeTooLow, v.limits.MinAutoResolveSeconds, a.AutoResolveSeconds, "autoResolveSeconds %d is too low, expected value >=%d", a.AutoResolveSeconds, v.limits.MinAutoResolveSeconds)
+	} else if a.AutoResolveSeconds > v.limits.MaxAutoResolveSeconds {
+		v.add("autoResolveSeconds", ValidationErrorCodeTooHigh, v.limits.MaxAutoResolveSeconds, a.AutoResolveSeconds, "autoResolveSeconds %d is too high, expected value <=%d", a.AutoResolveSeconds, v.limits.MaxAutoResolveSeconds)
 	}
-
-	return nil
 }
 
 // ValidateIgnoreIfTextContains validates that the IgnoreIfTextContains slice
 // does not exceed the maximum count and that each item does not exceed the maximum length.
 func (a *Alert) ValidateIgnoreIfTextContains() error {
-	if len(a.IgnoreIfTextContains) == 0 {
-		return nil
-	}
+	v := newValidator(nil)
+	a.validateIgnoreIfTextContains(v)
+	return v.first()
+}
 
-	if len(a.IgnoreIfTextContains) > MaxIgnoreIfTextContainsCount {
-		return fmt.Errorf("too many ignoreIfTextContains items, expected <=%d", MaxIgnoreIfTextContainsCount)
+func (a *Alert) validateIgnoreIfTextContains(v *validator) {
+	if len(a.IgnoreIfTextContains) > v.limits.MaxIgnoreIfTextContainsCount {
+		v.add("ignoreIfTextContains", ValidationErrorCodeTooMany, v.limits.MaxIgnoreIfTextContainsCount, len(a.IgnoreIfTextContains), "too many ignoreIfTextContains items, expected <=%d", v.limits.MaxIgnoreIfTextContainsCount)
 	}
 
 	for index, s := range a.IgnoreIfTextContains {
-		if len(s) > MaxIgnoreIfTextContainsLength {
-			return fmt.Errorf("ignoreIfTextContains[%d] is too long, expected length <=%d", index, MaxIgnoreIfTextContainsLength)
+		if len(s) > v.limits.MaxIgnoreIfTextContainsLength {
+			v.add(fmt.Sprintf("ignoreIfTextContains[%d]", index), ValidationErrorCodeTooLong, v.limits.MaxIgnoreIfTextContainsLength, len(s), "ignoreIfTextContains[%d] is too long, expected length <=%d", index, v.limits.MaxIgnoreIfTextContainsLength)
 		}
 	}
-
-	return nil
 }
 
 // ValidateFields validates that the number of fields does not exceed MaxFieldCount.
 func (a *Alert) ValidateFields() error {
-	if len(a.Fields) > MaxFieldCount {
-		return fmt.Errorf("too many fields, expected <=%d", MaxFieldCount)
-	}
+	v := newValidator(nil)
+	a.validateFields(v)
+	return v.first()
+}
 
-	return nil
+func (a *Alert) validateFields(v *validator) {
+	if len(a.Fields) > v.limits.MaxFieldCount {
+		v.add("fields", ValidationErrorCodeTooMany, v.limits.MaxFieldCount, len(a.Fields), "too many fields, expected <=%d", v.limits.MaxFieldCount)
+	}
 }
 
 // ValidateWebhooks validates all webhooks in the alert.
 // It checks that the webhook count is within limits, all required fields are present,
 // URLs are valid, IDs are unique, and all nested inputs are properly configured.
 func (a *Alert) ValidateWebhooks() error {
-	if a.Webhooks == nil {
-		return nil
-	}
+	v := newValidator(nil)
+	a.validateWebhooks(v)
+	return v.first()
+}
 
-	if len(a.Webhooks) > MaxWebhookCount {
-		return fmt.Errorf("too many webhooks, expected <=%d", MaxWebhookCount)
+func (a *Alert) validateWebhooks(v *validator) {
+	if len(a.Webhooks) > v.limits.MaxWebhookCount {
+		v.add("webhooks", ValidationErrorCodeTooMany, v.limits.MaxWebhookCount, len(a.Webhooks), "too many webhooks, expected <=%d", v.limits.MaxWebhookCount)
 	}
 
 	webhookIDs := make(map[string]struct{})
 
 	for index, hook := range a.Webhooks {
+		path := fmt.Sprintf("webhooks[%d]", index)
+
 		if hook == nil {
-			return fmt.Errorf("webhook[%d] is nil", index)
+			v.add(path, ValidationErrorCodeRequired, 0, nil, "webhook[%d] is nil", index)
+			continue
 		}
 
 		if hook.ID == "" {
-			return fmt.Errorf("webhook[%d].id is required", index)
-		}
-
-		if len(hook.ID) > MaxWebhookIDLength {
-			return fmt.Errorf("webhook[%d].id is too long, expected length <=%d", index, MaxWebhookIDLength)
-		}
+			v.add(path+".id", ValidationErrorCodeRequired, 0, nil, "webhook[%d].id is required", index)
+		} else {
+			if len(hook.ID) > v.limits.MaxWebhookIDLength {
+				v.add(path+".id", ValidationErrorCodeTooLong, v.limits.MaxWebhookIDLength, len(hook.ID), "webhook[%d].id is too long, expected length <=%d", index, v.limits.MaxWebhookIDLength)
+			} else if _, ok := webhookIDs[hook.ID]; ok {
+				v.add(path+".id", ValidationErrorCodeNotUnique, 0, hook.ID, "webhook[%d].id must be unique", index)
+			}
 
-		if _, ok := webhookIDs[hook.ID]; ok {
-			return fmt.Errorf("webhook[%d].id must be unique", index)
+			webhookIDs[hook.ID] = struct{}{}
 		}
 
-		webhookIDs[hook.ID] = struct{}{}
-
 		if hook.URL == "" {
-			return fmt.Errorf("webhook[%d].url is required", index)
-		}
-
-		if len(hook.URL) > MaxWebhookURLLength {
-			return fmt.Errorf("webhook[%d].url is too long, expected length <=%d", index, MaxWebhookURLLength)
-		}
-
-		// For HTTP URLs, validate as absolute URL. For custom handler identifiers, validate as ASCII.
-		if strings.HasPrefix(strings.ToLower(hook.URL), "http") {
+			v.add(path+".url", ValidationErrorCodeRequired, 0, nil, "webhook[%d].url is required", index)
+		} else if len(hook.URL) > v.limits.MaxWebhookURLLength {
+			v.add(path+".url", ValidationErrorCodeTooLong, v.limits.MaxWebhookURLLength, len(hook.URL), "webhook[%d].url is too long, expected length <=%d", index, v.limits.MaxWebhookURLLength)
+		} else if strings.HasPrefix(strings.ToLower(hook.URL), "http") {
+			// For HTTP URLs, validate as absolute URL. For custom handler identifiers, validate as ASCII.
 			parsedURL, err := url.ParseRequestURI(hook.URL)
-			if err != nil {
-				return fmt.Errorf("webhook[%d].url is not a valid absolute URL", index)
-			}
-
-			if parsedURL.Scheme == "" || parsedURL.Host == "" {
-				return fmt.Errorf("webhook[%d].url is not a valid absolute URL", index)
+			if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+				v.add(path+".url", ValidationErrorCodeInvalidFormat, 0, hook.URL, "webhook[%d].url is not a valid absolute URL", index)
 			}
 		} else if !isValidASCII(hook.URL) {
-			return fmt.Errorf("webhook[%d].url contains invalid characters, expected printable ASCII", index)
+			v.add(path+".url", ValidationErrorCodeInvalidFormat, 0, hook.URL, "webhook[%d].url contains invalid characters, expected printable ASCII", index)
 		}
 
 		if hook.ButtonText == "" {
-			return fmt.Errorf("webhook[%d].buttonText is required", index)
+			v.add(path+".buttonText", ValidationErrorCodeRequired, 0, nil, "webhook[%d].buttonText is required", index)
+		} else if len(hook.ButtonText) > v.limits.MaxWebhookButtonTextLength {
+			v.add(path+".buttonText", ValidationErrorCodeTooLong, v.limits.MaxWebhookButtonTextLength, len(hook.ButtonText), "webhook[%d].buttonText is too long, expected length <=%d", index, v.limits.MaxWebhookButtonTextLength)
 		}
 
-		if len(hook.ButtonText) > MaxWebhookButtonTextLength {
-			return fmt.Errorf("webhook[%d].buttonText is too long, expected length <=%d", index, MaxWebhookButtonTextLength)
-		}
-
-		if len(hook.ConfirmationText) > MaxWebhookConfirmationTextLength {
-			return fmt.Errorf("webhook[%d].confirmationText is too long, expected length <=%d", index, MaxWebhookConfirmationTextLength)
+		if len(hook.ConfirmationText) > v.limits.MaxWebhookConfirmationTextLength {
+			v.add(path+".confirmationText", ValidationErrorCodeTooLong, v.limits.MaxWebhookConfirmationTextLength, len(hook.ConfirmationText), "webhook[%d].confirmationText is too long, expected length <=%d", index, v.limits.MaxWebhookConfirmationTextLength)
 		}
 
 		if hook.ButtonStyle != "" && !WebhookButtonStyleIsValid(hook.ButtonStyle) {
-			return fmt.Errorf("webhook[%d].buttonStyle '%s' is not valid, expected empty or one of [%s]", index, hook.ButtonStyle, strings.Join(ValidWebhookButtonStyles(), ", "))
+			v.add(path+".buttonStyle", ValidationErrorCodeInvalidValue, 0, string(hook.ButtonStyle), "webhook[%d].buttonStyle '%s' is not valid, expected empty or one of [%s]", index, hook.ButtonStyle, strings.Join(ValidWebhookButtonStyles(), ", "))
 		}
 
 		if hook.AccessLevel != "" && !WebhookAccessLevelIsValid(hook.AccessLevel) {
-			return fmt.Errorf("webhook[%d].accessLevel '%s' is not valid, expected empty or one of [%s]", index, hook.AccessLevel, strings.Join(ValidWebhookAccessLevels(), ", "))
+			v.add(path+".accessLevel", ValidationErrorCodeInvalidValue, 0, string(hook.AccessLevel), "webhook[%d].accessLevel '%s' is not valid, expected empty or one of [%s]", index, hook.AccessLevel, strings.Join(ValidWebhookAccessLevels(), ", "))
 		}
 
 		if hook.DisplayMode != "" && !WebhookDisplayModeIsValid(hook.DisplayMode) {
-			return fmt.Errorf("webhook[%d].displayMode '%s' is not valid, expected empty or one of [%s]", index, hook.DisplayMode, strings.Join(ValidWebhookDisplayModes(), ", "))
+			v.add(path+".displayMode", ValidationErrorCodeInvalidValue, 0, string(hook.DisplayMode), "webhook[%d].displayMode '%s' is not valid, expected empty or one of [%s]", index, hook.DisplayMode, strings.Join(ValidWebhookDisplayModes(), ", "))
 		}
 
-		if len(hook.Payload) > MaxWebhookPayloadCount {
-			return fmt.Errorf("webhook[%d].payload item count is too large, expected <=%d", index, MaxWebhookPayloadCount)
+		if len(hook.Payload) > v.limits.MaxWebhookPayloadCount {
+			v.add(path+".payload", ValidationErrorCodeTooMany, v.limits.MaxWebhookPayloadCount, len(hook.Payload), "webhook[%d].payload item count is too large, expected <=%d", index, v.limits.MaxWebhookPayloadCount)
 		}
 
-		if len(hook.PlainTextInput) > MaxWebhookPlainTextInputCount {
-			return fmt.Errorf("webhook[%d].plainTextInput item count is too large, expected <=%d", index, MaxWebhookPlainTextInputCount)
+		if len(hook.PlainTextInput) > v.limits.MaxWebhookPlainTextInputCount {
+			v.add(path+".plainTextInput", ValidationErrorCodeTooMany, v.limits.MaxWebhookPlainTextInputCount, len(hook.PlainTextInput), "webhook[%d].plainTextInput item count is too large, expected <=%d", index, v.limits.MaxWebhookPlainTextInputCount)
 		}
 
-		if len(hook.CheckboxInput) > MaxWebhookCheckboxInputCount {
-			return fmt.Errorf("webhook[%d].checkboxInput item count is too large, expected <=%d", index, MaxWebhookCheckboxInputCount)
+		if len(hook.CheckboxInput) > v.limits.MaxWebhookCheckboxInputCount {
+			v.add(path+".checkboxInput", ValidationErrorCodeTooMany, v.limits.MaxWebhookCheckboxInputCount, len(hook.CheckboxInput), "webhook[%d].checkboxInput item count is too large, expected <=%d", index, v.limits.MaxWebhookCheckboxInputCount)
 		}
 
 		inputIDs := make(map[string]struct{})
 
 		for inputIndex, input := range hook.PlainTextInput {
-			if input == nil {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d] is nil", index, inputIndex)
-			}
-
-			if input.ID == "" {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].id is required", index, inputIndex)
-			}
-
-			if _, ok := inputIDs[input.ID]; ok {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].id must be unique among all inputs", index, inputIndex)
-			}
+			validatePlainTextInput(v, input, index, inputIndex, inputIDs)
+		}
 
-			inputIDs[input.ID] = struct{}{}
+		for inputIndex, input := range hook.CheckboxInput {
+			validateCheckboxInput(v, input, index, inputIndex, inputIDs)
+		}
+	}
+}
 
-			if len(input.ID) > MaxWebhookInputIDLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].id is too long, expected <=%d", index, inputIndex, MaxWebhookInputIDLength)
-			}
+func validatePlainTextInput(v *validator, input *WebhookPlainTextInput, index, inputIndex int, inputIDs map[string]struct{}) {
+	path := fmt.Sprintf("webhooks[%d].plainTextInput[%d]", index, inputIndex)
 
-			if len(input.Description) > MaxWebhookInputDescriptionLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].description is too long, expected <=%d", index, inputIndex, MaxWebhookInputDescriptionLength)
-			}
+	if input == nil {
+		v.add(path, ValidationErrorCodeRequired, 0, nil, "webhook[%d].plainTextInput[%d] is nil", index, inputIndex)
+		return
+	}
 
-			if input.MinLength < 0 {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].minLength must be >=0", index, inputIndex)
-			}
+	if input.ID == "" {
+		v.add(path+".id", ValidationErrorCodeRequired, 0, nil, "webhook[%d].plainTextInput[%d].id is required", index, inputIndex)
+	} else {
+		if _, ok := inputIDs[input.ID]; ok {
+			v.add(path+".id", ValidationErrorCodeNotUnique, 0, input.ID, "webhook[%d].plainTextInput[%d].id must be unique among all inputs", index, inputIndex)
+		} else if len(input.ID) > v.limits.MaxWebhookInputIDLength {
+			v.add(path+".id", ValidationErrorCodeTooLong, v.limits.MaxWebhookInputIDLength, len(input.ID), "webhook[%d].plainTextInput[%d].id is too long, expected <=%d", index, inputIndex, v.limits.MaxWebhookInputIDLength)
+		}
 
-			if input.MinLength > MaxWebhookInputTextLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].minLength must be <=%d", index, inputIndex, MaxWebhookInputTextLength)
-			}
+		inputIDs[input.ID] = struct{}{}
+	}
 
-			if input.MaxLength < 0 {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].maxLength must be >=0", index, inputIndex)
-			}
+	if len(input.Description) > v.limits.MaxWebhookInputDescriptionLength {
+		v.add(path+".description", ValidationErrorCodeTooLong, v.limits.MaxWebhookInputDescriptionLength, len(input.Description), "webhook[%d].plainTextInput[%d].description is too long, expected <=%d", index, inputIndex, v.limits.MaxWebhookInputDescriptionLength)
+	}
 
-			if input.MaxLength > MaxWebhookInputTextLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].maxLength must be <=%d", index, inputIndex, MaxWebhookInputTextLength)
-			}
+	lengthsValid := true
 
-			if input.MaxLength < input.MinLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].maxLength cannot be smaller than minLength", index, inputIndex)
-			}
+	if input.MinLength < 0 {
+		v.add(path+".minLength", ValidationErrorCodeTooLow, 0, input.MinLength, "webhook[%d].plainTextInput[%d].minLength must be >=0", index, inputIndex)
+		lengthsValid = false
+	} else if input.MinLength > v.limits.MaxWebhookInputTextLength {
+		v.add(path+".minLength", ValidationErrorCodeTooHigh, v.limits.MaxWebhookInputTextLength, input.MinLength, "webhook[%d].plainTextInput[%d].minLength must be <=%d", index, inputIndex, v.limits.MaxWebhookInputTextLength)
+		lengthsValid = false
+	}
 
-			if len(input.InitialValue) > input.MaxLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].initialValue cannot be longer than maxLength", index, inputIndex)
-			}
+	if input.MaxLength < 0 {
+		v.add(path+".maxLength", ValidationErrorCodeTooLow, 0, input.MaxLength, "webhook[%d].plainTextInput[%d].maxLength must be >=0", index, inputIndex)
+		lengthsValid = false
+	} else if input.MaxLength > v.limits.MaxWebhookInputTextLength {
+		v.add(path+".maxLength", ValidationErrorCodeTooHigh, v.limits.MaxWebhookInputTextLength, input.MaxLength, "webhook[%d].plainTextInput[%d].maxLength must be <=%d", index, inputIndex, v.limits.MaxWebhookInputTextLength)
+		lengthsValid = false
+	}
 
-			if len(input.InitialValue) < input.MinLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].initialValue cannot be shorter than minLength", index, inputIndex)
-			}
-		}
+	// The cross-field checks are only meaningful when both lengths are individually valid.
+	if !lengthsValid {
+		return
+	}
 
-		for inputIndex, input := range hook.CheckboxInput {
-			if input == nil {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d] is nil", index, inputIndex)
-			}
+	if input.MaxLength < input.MinLength {
+		v.add(path+".maxLength", ValidationErrorCodeTooLow, input.MinLength, input.MaxLength, "webhook[%d].plainTextInput[%d].maxLength cannot be smaller than minLength", index, inputIndex)
+		return
+	}
 
-			if input.ID == "" {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].id is required", index, inputIndex)
-			}
+	if len(input.InitialValue) > input.MaxLength {
+		v.add(path+".initialValue", ValidationErrorCodeTooLong, input.MaxLength, len(input.InitialValue), "webhook[%d].plainTextInput[%d].initialValue cannot be longer than maxLength", index, inputIndex)
+	} else if len(input.InitialValue) < input.MinLength {
+		v.add(path+".initialValue", ValidationErrorCodeTooShort, input.MinLength, len(input.InitialValue), "webhook[%d].plainTextInput[%d].initialValue cannot be shorter than minLength", index, inputIndex)
+	}
+}
 
-			if _, ok := inputIDs[input.ID]; ok {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].id must be unique among all inputs", index, inputIndex)
-			}
+func validateCheckboxInput(v *validator, input *WebhookCheckboxInput, index, inputIndex int, inputIDs map[string]struct{}) {
+	path := fmt.Sprintf("webhooks[%d].checkboxInput[%d]", index, inputIndex)
 
-			inputIDs[input.ID] = struct{}{}
+	if input == nil {
+		v.add(path, ValidationErrorCodeRequired, 0, nil, "webhook[%d].checkboxInput[%d] is nil", index, inputIndex)
+		return
+	}
 
-			if len(input.ID) > MaxWebhookInputIDLength {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].id is too long, expected <=%d", index, inputIndex, MaxWebhookInputIDLength)
-			}
+	if input.ID == "" {
+		v.add(path+".id", ValidationErrorCodeRequired, 0, nil, "webhook[%d].checkboxInput[%d].id is required", index, inputIndex)
+	} else {
+		if _, ok := inputIDs[input.ID]; ok {
+			v.add(path+".id", ValidationErrorCodeNotUnique, 0, input.ID, "webhook[%d].checkboxInput[%d].id must be unique among all inputs", index, inputIndex)
+		} else if len(input.ID) > v.limits.MaxWebhookInputIDLength {
+			v.add(path+".id", ValidationErrorCodeTooLong, v.limits.MaxWebhookInputIDLength, len(input.ID), "webhook[%d].checkboxInput[%d].id is too long, expected <=%d", index, inputIndex, v.limits.MaxWebhookInputIDLength)
+		}
 
-			if len(input.Label) > MaxWebhookInputLabelLength {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].label is too long, expected <=%d", index, inputIndex, MaxWebhookInputLabelLength)
-			}
+		inputIDs[input.ID] = struct{}{}
+	}
 
-			if len(input.Options) > MaxWebhookCheckboxOptionCount {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].options item count is too large, expected <=%d", index, inputIndex, MaxWebhookCheckboxOptionCount)
-			}
+	if len(input.Label) > v.limits.MaxWebhookInputLabelLength {
+		v.add(path+".label", ValidationErrorCodeTooLong, v.limits.MaxWebhookInputLabelLength, len(input.Label), "webhook[%d].checkboxInput[%d].label is too long, expected <=%d", index, inputIndex, v.limits.MaxWebhookInputLabelLength)
+	}
 
-			values := make(map[string]struct{})
+	if len(input.Options) > v.limits.MaxWebhookCheckboxOptionCount {
+		v.add(path+".options", ValidationErrorCodeTooMany, v.limits.MaxWebhookCheckboxOptionCount, len(input.Options), "webhook[%d].checkboxInput[%d].options item count is too large, expected <=%d", index, inputIndex, v.limits.MaxWebhookCheckboxOptionCount)
+	}
 
-			for optionIndex, option := range input.Options {
-				if option == nil {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d] is nil", index, inputIndex, optionIndex)
-				}
+	values := make(map[string]struct{})
 
-				if option.Value == "" {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].value is required", index, inputIndex, optionIndex)
-				}
+	for optionIndex, option := range input.Options {
+		optionPath := fmt.Sprintf("%s.options[%d]", path, optionIndex)
 
-				if len(option.Value) > MaxCheckboxOptionValueLength {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].value is too long, expected <=%d", index, inputIndex, optionIndex, MaxCheckboxOptionValueLength)
-				}
+		if option == nil {
+			v.add(optionPath, ValidationErrorCodeRequired, 0, nil, "webhook[%d].checkboxInput[%d].options[%d] is nil", index, inputIndex, optionIndex)
+			continue
+		}
 
-				if _, ok := values[option.Value]; ok {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].value must be unique", index, inputIndex, optionIndex)
-				}
+		if option.Value == "" {
+			v.add(optionPath+".value", ValidationErrorCodeRequired, 0, nil, "webhook[%d].checkboxInput[%d].options[%d].value is required", index, inputIndex, optionIndex)
+		} else {
+			if len(option.Value) > v.limits.MaxCheckboxOptionValueLength {
+				v.add(optionPath+".value", ValidationErrorCodeTooLong, v.limits.MaxCheckboxOptionValueLength, len(option.Value), "webhook[%d].checkboxInput[%d].options[%d].value is too long, expected <=%d", index, inputIndex, optionIndex, v.limits.MaxCheckboxOptionValueLength)
+			} else if _, ok := values[option.Value]; ok {
+				v.add(optionPath+".value", ValidationErrorCodeNotUnique, 0, option.Value, "webhook[%d].checkboxInput[%d].options[%d].value must be unique", index, inputIndex, optionIndex)
+			}
 
-				values[option.Value] = struct{}{}
+			values[option.Value] = struct{}{}
+		}
 
-				if len(option.Text) > MaxWebhookCheckboxOptionTextLength {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].text is too long, expected <=%d", index, inputIndex, optionIndex, MaxWebhookCheckboxOptionTextLength)
-				}
-			}
+		if len(option.Text) > v.limits.MaxWebhookCheckboxOptionTextLength {
+			v.add(optionPath+".text", ValidationErrorCodeTooLong, v.limits.MaxWebhookCheckboxOptionTextLength, len(option.Text), "webhook[%d].checkboxInput[%d].options[%d].text is too long, expected <=%d", index, inputIndex, optionIndex, v.limits.MaxWebhookCheckboxOptionTextLength)
 		}
 	}
-
-	return nil
 }
 
 // ValidateEscalation validates all escalation points in the alert.
 // It checks that the escalation count is within limits, delays are properly spaced,
 // severities are valid for escalation, and Slack mentions and channels are valid.
 func (a *Alert) ValidateEscalation() error {
-	if a.Escalation == nil {
-		return nil
-	}
+	v := newValidator(nil)
+	a.validateEscalation(v)
+	return v.first()
+}
 
-	if len(a.Escalation) > MaxEscalationCount {
-		return fmt.Errorf("too many escalation points, expected <=%d", MaxEscalationCount)
+func (a *Alert) validateEscalation(v *validator) {
+	if len(a.Escalation) > v.limits.MaxEscalationCount {
+		v.add("escalation", ValidationErrorCodeTooMany, v.limits.MaxEscalationCount, len(a.Escalation), "too many escalation points, expected <=%d", v.limits.MaxEscalationCount)
 	}
 
 	previousDelay := 0
 
 	for index, e := range a.Escalation {
-		if e == nil {
-			return fmt.Errorf("escalation[%d] is nil", index)
-		}
+		path := fmt.Sprintf("escalation[%d]", index)
 
-		if e.DelaySeconds < MinEscalationDelaySeconds {
-			return fmt.Errorf("escalation[%d].delaySeconds '%d' is too low, expected value >=%d", index, e.DelaySeconds, MinEscalationDelaySeconds)
+		if e == nil {
+			v.add(path, ValidationErrorCodeRequired, 0, nil, "escalation[%d] is nil", index)
+			continue
 		}
 
-		if previousDelay > 0 && e.DelaySeconds-previousDelay < MinEscalationDelayDiffSeconds {
-			return fmt.Errorf("escalation[%d].delaySeconds '%d' is too small compared to previous escalation, expected diff >=%d", index, e.DelaySeconds, MinEscalationDelayDiffSeconds)
+		if e.DelaySeconds < v.limits.MinEscalationDelaySeconds {
+			v.add(path+".delaySeconds", ValidationErrorCodeTooLow, v.limits.MinEscalationDelaySeconds, e.DelaySeconds, "escalation[%d].delaySeconds '%d' is too low, expected value >=%d", index, e.DelaySeconds, v.limits.MinEscalationDelaySeconds)
+		} else if previousDelay > 0 && e.DelaySeconds-previousDelay < v.limits.MinEscalationDelayDiffSeconds {
+			v.add(path+".delaySeconds", ValidationErrorCodeTooLow, previousDelay+v.limits.MinEscalationDelayDiffSeconds, e.DelaySeconds, "escalation[%d].delaySeconds '%d' is too small compared to previous escalation, expected diff >=%d", index, e.DelaySeconds, v.limits.MinEscalationDelayDiffSeconds)
 		}
 
 		previousDelay = e.DelaySeconds
 
 		if e.Severity != AlertPanic && e.Severity != AlertError && e.Severity != AlertWarning {
-			return fmt.Errorf("escalation[%d].severity '%s' is not valid, expected one of [panic, error, warning]", index, e.Severity)
+			v.add(path+".severity", ValidationErrorCodeInvalidValue, 0, string(e.Severity), "escalation[%d].severity '%s' is not valid, expected one of [panic, error, warning]", index, e.Severity)
 		}
 
-		if len(e.SlackMentions) > MaxEscalationSlackMentionCount {
-			return fmt.Errorf("escalation[%d].slackMentions item count is too large, expected <=%d", index, MaxEscalationSlackMentionCount)
+		if len(e.SlackMentions) > v.limits.MaxEscalationSlackMentionCount {
+			v.add(path+".slackMentions", ValidationErrorCodeTooMany, v.limits.MaxEscalationSlackMentionCount, len(e.SlackMentions), "escalation[%d].slackMentions item count is too large, expected <=%d", index, v.limits.MaxEscalationSlackMentionCount)
 		}
 
 		for j, mention := range e.SlackMentions {
-			if !SlackMentionRegex.MatchString(mention) {
-				return fmt.Errorf("escalation[%d].slackMentions[%d] is not valid", index, j)
+			if !isValidSlackMention(mention, v.limits) {
+				v.add(fmt.Sprintf("%s.slackMentions[%d]", path, j), ValidationErrorCodeInvalidFormat, 0, mention, "escalation[%d].slackMentions[%d] is not valid", index, j)
 			}
 		}
 
-		if e.MoveToChannel != "" {
-			if !SlackChannelIDOrNameRegex.MatchString(e.MoveToChannel) {
-				return fmt.Errorf("escalation[%d].moveToChannel is not valid", index)
-			}
+		if e.MoveToChannel != "" && !isValidSlackChannelIDOrName(e.MoveToChannel, v.limits.MaxSlackChannelIDLength) {
+			v.add(path+".moveToChannel", ValidationErrorCodeInvalidFormat, 0, e.MoveToChannel, "escalation[%d].moveToChannel is not valid", index)
 		}
-	}
 
-	return nil
-}
-
-func shortenAlertTextIfNeeded(text string) string {
-	if utf8.RuneCountInString(text) <= MaxTextLength {
-		return text
-	}
+		e.validateRepeat(v, path)
 
-	endsWithCodeBlock := strings.HasSuffix(text, "```")
-
-	if endsWithCodeBlock {
-		return strings.TrimSpace(truncateString(text, MaxTextLength-6)) + "...```"
+		if e.Schedule != nil {
+			e.Schedule.validate(v, path+".schedule")
+		}
 	}
+}
 
-	return strings.TrimSpace(truncateString(text, MaxTextLength-3)) + "..."
+// isValidSlackChannelIDOrName returns true if s is a valid Slack channel ID or name, of at most maxLength characters.
+func isValidSlackChannelIDOrName(s string, maxLength int) bool {
+	return len(s) <= maxLength && slackChannelIDOrNameCharsRegex.MatchString(s)
 }
 
 // truncateString truncates a string to maxRunes runes, safely handling multi-byte UTF-8 characters.
@@ -1061,6 +1196,10 @@ func truncateString(s string, maxRunes int) string {
 		return s
 	}
 
+	if maxRunes <= 0 {
+		return ""
+	}
+
 	runes := []rune(s)
 	return string(runes[:maxRunes])
 }
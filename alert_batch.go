@@ -0,0 +1,333 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// AlertBatchStatus is the outcome for a single alert in an AlertBatch.
+type AlertBatchStatus string
+
+const (
+	// AlertBatchAccepted means that the alert was cleaned and validated successfully.
+	AlertBatchAccepted AlertBatchStatus = "accepted"
+
+	// AlertBatchRejected means that the alert could not be decoded, or failed validation.
+	AlertBatchRejected AlertBatchStatus = "rejected"
+
+	// AlertBatchDuplicate means that the alert is identical to an accepted alert earlier in the batch, see CleanAndValidateWith.
+	AlertBatchDuplicate AlertBatchStatus = "duplicate"
+)
+
+// AlertBatchStatusIsValid returns true if the provided AlertBatchStatus is valid.
+func AlertBatchStatusIsValid(s AlertBatchStatus) bool {
+	switch s {
+	case AlertBatchAccepted, AlertBatchRejected, AlertBatchDuplicate:
+		return true
+	}
+	return false
+}
+
+// ValidAlertBatchStatuses returns a slice of valid AlertBatchStatus values.
+func ValidAlertBatchStatuses() []string {
+	return []string{
+		string(AlertBatchAccepted),
+		string(AlertBatchRejected),
+		string(AlertBatchDuplicate),
+	}
+}
+
+// AlertBatch is a list of alerts sent together, encoded as a JSON array.
+// Decoding tolerates malformed entries: they are kept as nil alerts, and reported as rejected by CleanAndValidate,
+// so that the rest of the batch can still be accepted.
+type AlertBatch struct {
+	// Alerts holds the alerts in the batch. Entries that could not be decoded are nil.
+	Alerts []*Alert
+
+	// decodeErrors holds the decoding failure for each malformed entry, by index.
+	decodeErrors map[int]*ValidationError
+}
+
+// AlertBatchItemResult is the outcome for a single alert in an AlertBatch.
+type AlertBatchItemResult struct {
+	// Index is the position of the alert in the batch.
+	Index int `json:"index"`
+
+	// Status is the outcome for the alert.
+	Status AlertBatchStatus `json:"status"`
+
+	// UniqueID is the UniqueID of the cleaned alert. It is empty for rejected alerts. For duplicates, it is the UniqueID of the
+	// accepted alert (see DuplicateOf), since duplicates without a producer timestamp may have been given a different time by Clean.
+	UniqueID string `json:"uniqueId,omitempty"`
+
+	// DuplicateOf is the index of the accepted alert that this alert duplicates. It is nil unless Status is AlertBatchDuplicate.
+	DuplicateOf *int `json:"duplicateOf,omitempty"`
+
+	// Errors holds the decoding or validation failures of a rejected alert, with paths relative to the alert.
+	Errors ValidationErrors `json:"errors,omitempty"`
+
+	// Alert is the cleaned alert. It is nil if the entry could not be decoded.
+	Alert *Alert `json:"-"`
+}
+
+// AlertBatchResult is the outcome of AlertBatch.CleanAndValidate, with one result per alert, in batch order.
+type AlertBatchResult struct {
+	Results []*AlertBatchItemResult `json:"results"`
+}
+
+// NewAlertBatch returns a batch with the specified alerts.
+func NewAlertBatch(alerts ...*Alert) *AlertBatch {
+	return &AlertBatch{Alerts: alerts}
+}
+
+// Len returns the number of alerts in the batch, including entries that could not be decoded.
+func (b *AlertBatch) Len() int {
+	return len(b.Alerts)
+}
+
+// MarshalJSON encodes the batch as a JSON array of alerts.
+func (b *AlertBatch) MarshalJSON() ([]byte, error) {
+	if b.Alerts == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(b.Alerts)
+}
+
+// UnmarshalJSON decodes a JSON array of alerts, using the default limits. See DecodeAlertBatch.
+func (b *AlertBatch) UnmarshalJSON(data []byte) error {
+	batch, err := DecodeAlertBatch(data, nil)
+	if err != nil {
+		return err
+	}
+
+	*b = *batch
+
+	return nil
+}
+
+// DecodeAlertBatch decodes a JSON array of alerts, using the specified limits (or the default limits if nil).
+// An error is returned if the data is not a JSON array, and a *ValidationError if the array has more than
+// MaxAlertBatchSize entries; decoding stops at the first entry exceeding the limit.
+// Each entry is decoded with DecodeAlert, so entries in older schema versions are upgraded.
+// Entries that cannot be decoded as alerts are kept as nil, and reported by CleanAndValidate.
+func DecodeAlertBatch(data []byte, limits *Limits) (*AlertBatch, error) {
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	entries, err := decodeAlertBatchEntries(data, limits.MaxAlertBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &AlertBatch{Alerts: make([]*Alert, len(entries))}
+
+	for i, entry := range entries {
+		// Null entries are kept as nil alerts, and rejected by CleanAndValidate
+		if bytes.Equal(bytes.TrimSpace(entry), []byte("null")) {
+			continue
+		}
+
+		alert, err := DecodeAlert(entry)
+		if err != nil {
+			if b.decodeErrors == nil {
+				b.decodeErrors = make(map[int]*ValidationError)
+			}
+
+			b.decodeErrors[i] = decodeValidationError(err)
+
+			continue
+		}
+
+		b.Alerts[i] = alert
+	}
+
+	return b, nil
+}
+
+// decodeAlertBatchEntries splits a JSON array into its raw entries, failing as soon as there are more than maxEntries.
+func decodeAlertBatchEntries(data []byte, maxEntries int) ([]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode alert batch: %w", err)
+	}
+
+	if token == nil {
+		return nil, nil
+	}
+
+	if token != json.Delim('[') {
+		return nil, fmt.Errorf("failed to decode alert batch: expected a JSON array, got %v", token)
+	}
+
+	var entries []json.RawMessage
+
+	for dec.More() {
+		if len(entries) == maxEntries {
+			return nil, &ValidationError{
+				Code:    ValidationErrorCodeTooMany,
+				Limit:   maxEntries,
+				Actual:  len(entries) + 1,
+				Message: fmt.Sprintf("alert batch contains more than %d alerts", maxEntries),
+			}
+		}
+
+		var entry json.RawMessage
+
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("failed to decode alert batch: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// Consume the closing bracket, and make sure that nothing follows it
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode alert batch: %w", err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("failed to decode alert batch: unexpected data after the JSON array")
+	}
+
+	return entries, nil
+}
+
+// CleanAndValidate cleans and validates each alert in the batch, using the default limits.
+// See CleanAndValidateWith.
+func (b *AlertBatch) CleanAndValidate() (*AlertBatchResult, error) {
+	return b.CleanAndValidateWith(DefaultLimits())
+}
+
+// CleanAndValidateWith cleans and validates each alert in the batch, using the specified limits (or the default limits if nil).
+// Each alert is accepted, rejected (with all its validation errors), or marked as a duplicate of an earlier accepted alert
+// with the same UniqueID. Duplicates are detected with the timestamps set by the producer, so that identical alerts without
+// a timestamp (which are given the current time by Clean) are duplicates too.
+// A *ValidationError is returned, and no alerts are processed, if the batch exceeds MaxAlertBatchSize.
+func (b *AlertBatch) CleanAndValidateWith(limits *Limits) (*AlertBatchResult, error) {
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	if len(b.Alerts) > limits.MaxAlertBatchSize {
+		return nil, &ValidationError{
+			Code:    ValidationErrorCodeTooMany,
+			Limit:   limits.MaxAlertBatchSize,
+			Actual:  len(b.Alerts),
+			Message: fmt.Sprintf("alert batch contains %d alerts, the maximum is %d", len(b.Alerts), limits.MaxAlertBatchSize),
+		}
+	}
+
+	result := &AlertBatchResult{Results: make([]*AlertBatchItemResult, len(b.Alerts))}
+	seen := make(map[string]int)
+
+	for i, alert := range b.Alerts {
+		item := &AlertBatchItemResult{Index: i, Status: AlertBatchRejected, Alert: alert}
+		result.Results[i] = item
+
+		if decodeErr, ok := b.decodeErrors[i]; ok {
+			item.Errors = ValidationErrors{decodeErr}
+			continue
+		}
+
+		var timestamp time.Time
+
+		if alert != nil {
+			timestamp = alert.Timestamp
+			alert.CleanWith(limits)
+		}
+
+		if err := alert.ValidateAllWith(limits); err != nil {
+			var validationErrs ValidationErrors
+			if errors.As(err, &validationErrs) {
+				item.Errors = validationErrs
+			}
+
+			continue
+		}
+
+		key := alert.uniqueIDAt(timestamp)
+
+		if first, ok := seen[key]; ok {
+			item.Status = AlertBatchDuplicate
+			item.DuplicateOf = &first
+			item.UniqueID = result.Results[first].UniqueID
+
+			continue
+		}
+
+		seen[key] = i
+		item.Status = AlertBatchAccepted
+		item.UniqueID = alert.UniqueID()
+	}
+
+	return result, nil
+}
+
+// Accepted returns the accepted alerts, in batch order.
+func (r *AlertBatchResult) Accepted() []*Alert {
+	var alerts []*Alert
+
+	for _, item := range r.Results {
+		if item.Status == AlertBatchAccepted {
+			alerts = append(alerts, item.Alert)
+		}
+	}
+
+	return alerts
+}
+
+// Dropped returns the results for rejected and duplicate alerts, in batch order.
+func (r *AlertBatchResult) Dropped() []*AlertBatchItemResult {
+	var items []*AlertBatchItemResult
+
+	for _, item := range r.Results {
+		if item.Status != AlertBatchAccepted {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// Count returns the number of alerts with the specified status.
+func (r *AlertBatchResult) Count(status AlertBatchStatus) int {
+	var count int
+
+	for _, item := range r.Results {
+		if item.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// decodeValidationError converts a decoding error for a batch entry to a *ValidationError.
+// Type errors are addressed by the path of the offending value, and schema version errors are returned as-is.
+func decodeValidationError(err error) *ValidationError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+
+	validationErr = &ValidationError{
+		Code:    ValidationErrorCodeInvalidJSON,
+		Message: fmt.Sprintf("failed to decode alert: %s", err),
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		validationErr.Path = typeErr.Field
+		validationErr.Actual = typeErr.Value
+	}
+
+	return validationErr
+}
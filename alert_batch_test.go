@@ -0,0 +1,231 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertBatchUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("malformed entries should be tolerated", func(t *testing.T) {
+		t.Parallel()
+
+		var batch types.AlertBatch
+		err := json.Unmarshal([]byte(`[
+			{"header": "first", "slackChannelId": "C12345678"},
+			"not an alert",
+			{"header": "third", "autoResolveSeconds": "soon"},
+			null
+		]`), &batch)
+		require.NoError(t, err)
+		require.Equal(t, 4, batch.Len())
+
+		assert.Equal(t, "first", batch.Alerts[0].Header)
+		assert.Nil(t, batch.Alerts[1])
+		assert.Nil(t, batch.Alerts[2])
+		assert.Nil(t, batch.Alerts[3])
+	})
+
+	t.Run("non-array input should fail", func(t *testing.T) {
+		t.Parallel()
+
+		var batch types.AlertBatch
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"header": "h"}`), &batch), "failed to decode alert batch")
+	})
+
+	t.Run("oversized batch should fail while decoding", func(t *testing.T) {
+		t.Parallel()
+
+		entries := strings.Repeat(`{"header": "h"},`, types.MaxAlertBatchSize) + `{"header": "h"}`
+
+		var batch types.AlertBatch
+		err := json.Unmarshal([]byte("["+entries+"]"), &batch)
+
+		var validationErr *types.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, types.ValidationErrorCodeTooMany, validationErr.Code)
+		assert.Equal(t, types.MaxAlertBatchSize, validationErr.Limit)
+		assert.Equal(t, "alert batch contains more than 100 alerts", validationErr.Message)
+
+		// Entries after the limit are not decoded
+		_, err = types.DecodeAlertBatch([]byte("["+entries+`, "not decoded`), nil)
+		require.True(t, errors.As(err, &validationErr))
+
+		limits := types.DefaultLimits()
+		limits.MaxAlertBatchSize = 200
+
+		decoded, err := types.DecodeAlertBatch([]byte("["+strings.Repeat(`{"header": "h"},`, 150)+`null]`), limits)
+		require.NoError(t, err)
+		assert.Equal(t, 151, decoded.Len())
+	})
+
+	t.Run("trailing data should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.DecodeAlertBatch([]byte(`[{"header": "h"}] []`), nil)
+		require.ErrorContains(t, err, "unexpected data after the JSON array")
+	})
+
+	t.Run("batch should encode as an array", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(types.NewAlertBatch())
+		require.NoError(t, err)
+		assert.JSONEq(t, `[]`, string(data))
+
+		alert := types.NewErrorAlert()
+		alert.Header = "header"
+
+		data, err = json.Marshal(types.NewAlertBatch(alert))
+		require.NoError(t, err)
+
+		var batch types.AlertBatch
+		require.NoError(t, json.Unmarshal(data, &batch))
+		require.Equal(t, 1, batch.Len())
+		assert.Equal(t, "header", batch.Alerts[0].Header)
+	})
+}
+
+func TestAlertBatchCleanAndValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("each entry should have a result", func(t *testing.T) {
+		t.Parallel()
+
+		// The timestamp is part of UniqueID, and must be recent to not be replaced by Clean
+		ts := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+		var batch types.AlertBatch
+		require.NoError(t, json.Unmarshal(fmt.Appendf(nil, `[
+			{"timestamp": "%[1]s", "header": "first", "slackChannelId": "C12345678"},
+			{"header": "missing text and link", "link": "not a url", "iconEmoji": "bad"},
+			{"header": "third", "autoResolveSeconds": "soon"},
+			{"timestamp": "%[1]s", "header": "  first  ", "slackChannelId": "C12345678"},
+			null,
+			{"timestamp": "%[1]s", "header": "second", "slackChannelId": "C12345678"}
+		]`, ts), &batch))
+
+		result, err := batch.CleanAndValidate()
+		require.NoError(t, err)
+		require.Len(t, result.Results, 6)
+
+		statuses := make([]types.AlertBatchStatus, 0, len(result.Results))
+		for i, item := range result.Results {
+			assert.Equal(t, i, item.Index)
+			statuses = append(statuses, item.Status)
+		}
+
+		assert.Equal(t, []types.AlertBatchStatus{
+			types.AlertBatchAccepted,
+			types.AlertBatchRejected,
+			types.AlertBatchRejected,
+			types.AlertBatchDuplicate,
+			types.AlertBatchRejected,
+			types.AlertBatchAccepted,
+		}, statuses)
+
+		assert.NotEmpty(t, result.Results[0].UniqueID)
+		assert.Empty(t, result.Results[0].Errors)
+
+		// All validation failures are reported, not only the first
+		assert.Len(t, result.Results[1].Errors, 2)
+		assert.Equal(t, "iconEmoji", result.Results[1].Errors[0].Path)
+		assert.Equal(t, "link", result.Results[1].Errors[1].Path)
+
+		require.Len(t, result.Results[2].Errors, 1)
+		assert.Equal(t, types.ValidationErrorCodeInvalidJSON, result.Results[2].Errors[0].Code)
+		assert.Equal(t, "autoResolveSeconds", result.Results[2].Errors[0].Path)
+		assert.Nil(t, result.Results[2].Alert)
+
+		require.NotNil(t, result.Results[3].DuplicateOf)
+		assert.Equal(t, 0, *result.Results[3].DuplicateOf)
+		assert.Equal(t, result.Results[0].UniqueID, result.Results[3].UniqueID)
+
+		require.Len(t, result.Results[4].Errors, 1)
+		assert.Equal(t, types.ValidationErrorCodeRequired, result.Results[4].Errors[0].Code)
+
+		accepted := result.Accepted()
+		require.Len(t, accepted, 2)
+		assert.Equal(t, "first", accepted[0].Header)
+		assert.Equal(t, "second", accepted[1].Header)
+
+		assert.Len(t, result.Dropped(), 4)
+		assert.Equal(t, 2, result.Count(types.AlertBatchAccepted))
+		assert.Equal(t, 3, result.Count(types.AlertBatchRejected))
+		assert.Equal(t, 1, result.Count(types.AlertBatchDuplicate))
+	})
+
+	t.Run("identical alerts without timestamps should be duplicates", func(t *testing.T) {
+		t.Parallel()
+
+		batch, err := types.DecodeAlertBatch([]byte(`[
+			{"header": "disk full", "slackChannelId": "C12345678", "correlationId": "disk"},
+			{"header": "disk full", "slackChannelId": "C12345678", "correlationId": "disk"},
+			{"header": "disk full", "slackChannelId": "C12345678", "correlationId": "disk", "timestamp": "2026-01-01T10:00:00Z"}
+		]`), nil)
+		require.NoError(t, err)
+
+		result, err := batch.CleanAndValidate()
+		require.NoError(t, err)
+
+		assert.Equal(t, types.AlertBatchAccepted, result.Results[0].Status)
+		assert.Equal(t, types.AlertBatchDuplicate, result.Results[1].Status)
+		assert.Equal(t, 0, *result.Results[1].DuplicateOf)
+		assert.Equal(t, result.Results[0].UniqueID, result.Results[1].UniqueID)
+		assert.Equal(t, result.Results[0].Alert.UniqueID(), result.Results[1].UniqueID)
+		assert.Equal(t, types.AlertBatchAccepted, result.Results[2].Status)
+	})
+
+	t.Run("result should encode without alerts", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		batch := types.NewAlertBatch(alert)
+
+		result, err := batch.CleanAndValidate()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(result)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"results": [{"index": 0, "status": "rejected", "errors": [{"path": "header", "code": "required", "message": "header and text cannot both be empty"}]}]}`, string(data))
+	})
+
+	t.Run("oversized batch should fail", func(t *testing.T) {
+		t.Parallel()
+
+		batch := types.NewAlertBatch(make([]*types.Alert, types.MaxAlertBatchSize+1)...)
+
+		_, err := batch.CleanAndValidate()
+
+		var validationErr *types.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, types.ValidationErrorCodeTooMany, validationErr.Code)
+		assert.Equal(t, types.MaxAlertBatchSize, validationErr.Limit)
+
+		limits := types.DefaultLimits()
+		limits.MaxAlertBatchSize = 200
+
+		result, err := batch.CleanAndValidateWith(limits)
+		require.NoError(t, err)
+		assert.Equal(t, types.MaxAlertBatchSize+1, result.Count(types.AlertBatchRejected))
+	})
+}
+
+func TestAlertBatchStatusIsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range types.ValidAlertBatchStatuses() {
+		assert.True(t, types.AlertBatchStatusIsValid(types.AlertBatchStatus(s)))
+	}
+
+	assert.False(t, types.AlertBatchStatusIsValid(types.AlertBatchStatus(strings.ToUpper(string(types.AlertBatchAccepted)))))
+}
@@ -0,0 +1,428 @@
+package types
+
+import (
+	"maps"
+	"time"
+)
+
+// AlertBuilder builds alerts with chained method calls, as an alternative to assigning the Alert fields directly.
+// Create a builder with NewAlertBuilder, and call Build to clean, validate and return the alert.
+//
+// A builder should not be used after Build has been called.
+type AlertBuilder struct {
+	alert       *Alert
+	limits      *Limits
+	render      bool
+	correlation CorrelationStrategy
+}
+
+// NewAlertBuilder returns a builder for an alert with the specified severity.
+func NewAlertBuilder(severity AlertSeverity) *AlertBuilder {
+	return &AlertBuilder{
+		alert: NewAlert(severity),
+	}
+}
+
+// WithLimits sets the limits used by Build when cleaning and validating the alert.
+// If not set (or nil), the default limits are used.
+func (b *AlertBuilder) WithLimits(limits *Limits) *AlertBuilder {
+	b.limits = limits
+	return b
+}
+
+// WithTemplates makes Build render the alert templates (see Alert.Render) before cleaning and validating the alert.
+func (b *AlertBuilder) WithTemplates() *AlertBuilder {
+	b.render = true
+	return b
+}
+
+// WithTimestamp sets the alert timestamp. The default is the time the builder was created.
+func (b *AlertBuilder) WithTimestamp(timestamp time.Time) *AlertBuilder {
+	b.alert.Timestamp = timestamp
+	return b
+}
+
+// WithCorrelationID sets the correlation ID, used to group related alerts into issues.
+func (b *AlertBuilder) WithCorrelationID(correlationID string) *AlertBuilder {
+	b.alert.CorrelationID = correlationID
+	return b
+}
+
+// WithCorrelationStrategy makes Build set the correlation ID with the specified strategy, if no correlation ID is set.
+// The strategy is applied after cleaning the alert.
+func (b *AlertBuilder) WithCorrelationStrategy(strategy CorrelationStrategy) *AlertBuilder {
+	b.correlation = strategy
+	return b
+}
+
+// WithType sets the alert type.
+func (b *AlertBuilder) WithType(alertType string) *AlertBuilder {
+	b.alert.Type = alertType
+	return b
+}
+
+// WithHeader sets the alert header.
+func (b *AlertBuilder) WithHeader(header string) *AlertBuilder {
+	b.alert.Header = header
+	return b
+}
+
+// WithHeaderWhenResolved sets the header used when the issue is resolved.
+func (b *AlertBuilder) WithHeaderWhenResolved(header string) *AlertBuilder {
+	b.alert.HeaderWhenResolved = header
+	return b
+}
+
+// WithText sets the alert text.
+func (b *AlertBuilder) WithText(text string) *AlertBuilder {
+	b.alert.Text = text
+	return b
+}
+
+// WithTextWhenResolved sets the text used when the issue is resolved.
+func (b *AlertBuilder) WithTextWhenResolved(text string) *AlertBuilder {
+	b.alert.TextWhenResolved = text
+	return b
+}
+
+// WithFallbackText sets the fallback text, used in Slack notifications.
+func (b *AlertBuilder) WithFallbackText(text string) *AlertBuilder {
+	b.alert.FallbackText = text
+	return b
+}
+
+// WithAuthor sets the alert author.
+func (b *AlertBuilder) WithAuthor(author string) *AlertBuilder {
+	b.alert.Author = author
+	return b
+}
+
+// WithHost sets the alert host.
+func (b *AlertBuilder) WithHost(host string) *AlertBuilder {
+	b.alert.Host = host
+	return b
+}
+
+// WithFooter sets the alert footer.
+func (b *AlertBuilder) WithFooter(footer string) *AlertBuilder {
+	b.alert.Footer = footer
+	return b
+}
+
+// WithLink sets the link to more information about the alert.
+func (b *AlertBuilder) WithLink(link string) *AlertBuilder {
+	b.alert.Link = link
+	return b
+}
+
+// WithSlackChannelID sets the Slack channel ID or name the alert is sent to.
+func (b *AlertBuilder) WithSlackChannelID(channelID string) *AlertBuilder {
+	b.alert.SlackChannelID = channelID
+	return b
+}
+
+// WithRouteKey sets the route key, used to find the Slack channel when no channel ID is set.
+func (b *AlertBuilder) WithRouteKey(routeKey string) *AlertBuilder {
+	b.alert.RouteKey = routeKey
+	return b
+}
+
+// WithUsername sets the username shown on the Slack post.
+func (b *AlertBuilder) WithUsername(username string) *AlertBuilder {
+	b.alert.Username = username
+	return b
+}
+
+// WithIconEmoji sets the icon emoji shown on the Slack post, on the format ':emoji:'.
+func (b *AlertBuilder) WithIconEmoji(iconEmoji string) *AlertBuilder {
+	b.alert.IconEmoji = iconEmoji
+	return b
+}
+
+// WithField adds a field with the specified title and value.
+func (b *AlertBuilder) WithField(title, value string) *AlertBuilder {
+	b.alert.Fields = append(b.alert.Fields, &Field{Title: title, Value: value})
+	return b
+}
+
+// WithMetadata sets a metadata value.
+func (b *AlertBuilder) WithMetadata(key string, value any) *AlertBuilder {
+	if b.alert.Metadata == nil {
+		b.alert.Metadata = make(map[string]any)
+	}
+
+	b.alert.Metadata[key] = value
+
+	return b
+}
+
+// IgnoreIfTextContains adds one or more ignore patterns. The alert is ignored if its text contains any of them.
+func (b *AlertBuilder) IgnoreIfTextContains(texts ...string) *AlertBuilder {
+	b.alert.IgnoreIfTextContains = append(b.alert.IgnoreIfTextContains, texts...)
+	return b
+}
+
+// EscapeText makes Clean escape the alert text for Slack, so that it cannot mention anyone (see Alert.EscapeText).
+func (b *AlertBuilder) EscapeText() *AlertBuilder {
+	b.alert.EscapeText = true
+	return b
+}
+
+// NotificationDelay sets the delay before a Slack notification is sent. Sub-second precision is truncated.
+func (b *AlertBuilder) NotificationDelay(d time.Duration) *AlertBuilder {
+	b.alert.NotificationDelaySeconds = durationSeconds(d)
+	return b
+}
+
+// ArchivingDelay sets the delay before a resolved issue is archived. Sub-second precision is truncated.
+func (b *AlertBuilder) ArchivingDelay(d time.Duration) *AlertBuilder {
+	b.alert.ArchivingDelaySeconds = durationSeconds(d)
+	return b
+}
+
+// AutoResolveAfter enables issue follow-up, and auto-resolves the issue after the specified duration
+// without new alerts. Sub-second precision is truncated.
+func (b *AlertBuilder) AutoResolveAfter(d time.Duration) *AlertBuilder {
+	b.alert.IssueFollowUpEnabled = true
+	b.alert.AutoResolveSeconds = durationSeconds(d)
+	return b
+}
+
+// AutoResolveAsInconclusive marks auto-resolved issues as inconclusive, rather than resolved.
+func (b *AlertBuilder) AutoResolveAsInconclusive() *AlertBuilder {
+	b.alert.AutoResolveAsInconclusive = true
+	return b
+}
+
+// EscalateAfter adds an escalation point, changing the issue severity and adding the specified Slack mentions
+// when the issue has been open for the specified duration. Sub-second precision is truncated.
+func (b *AlertBuilder) EscalateAfter(d time.Duration, severity AlertSeverity, slackMentions ...string) *AlertBuilder {
+	return b.WithEscalation(&Escalation{
+		Severity:      severity,
+		DelaySeconds:  durationSeconds(d),
+		SlackMentions: slackMentions,
+	})
+}
+
+// WithEscalation adds an escalation point.
+func (b *AlertBuilder) WithEscalation(escalation *Escalation) *AlertBuilder {
+	b.alert.Escalation = append(b.alert.Escalation, escalation)
+	return b
+}
+
+// WithWebhook adds the webhook built by the specified webhook builder. A nil builder is ignored.
+func (b *AlertBuilder) WithWebhook(webhook *WebhookBuilder) *AlertBuilder {
+	if webhook != nil {
+		b.alert.Webhooks = append(b.alert.Webhooks, webhook.build())
+	}
+
+	return b
+}
+
+// Build cleans and validates the alert, and returns it. If validation fails, the first validation error is returned.
+// If WithTemplates was called, the alert templates are rendered before cleaning.
+func (b *AlertBuilder) Build() (*Alert, error) {
+	if b.render {
+		if err := b.alert.Render(); err != nil {
+			return nil, err
+		}
+	}
+
+	b.alert.CleanWith(b.limits)
+
+	if b.correlation != nil {
+		b.alert.CorrelationID = b.alert.EffectiveCorrelationID(b.correlation)
+	}
+
+	if err := b.alert.ValidateWith(b.limits); err != nil {
+		return nil, err
+	}
+
+	return b.alert, nil
+}
+
+// WebhookBuilder builds a webhook for use with AlertBuilder.WithWebhook.
+type WebhookBuilder struct {
+	webhook *Webhook
+}
+
+// NewWebhookBuilder returns a builder for a webhook with the specified ID, URL (or handler identifier) and button text.
+func NewWebhookBuilder(id, url, buttonText string) *WebhookBuilder {
+	return &WebhookBuilder{
+		webhook: &Webhook{
+			ID:         id,
+			URL:        url,
+			ButtonText: buttonText,
+		},
+	}
+}
+
+// WithConfirmationText sets the text of the confirmation dialog shown before the webhook is triggered.
+func (b *WebhookBuilder) WithConfirmationText(text string) *WebhookBuilder {
+	b.webhook.ConfirmationText = text
+	return b
+}
+
+// WithButtonStyle sets the button style.
+func (b *WebhookBuilder) WithButtonStyle(style WebhookButtonStyle) *WebhookBuilder {
+	b.webhook.ButtonStyle = style
+	return b
+}
+
+// WithAccessLevel sets who can click the webhook button.
+func (b *WebhookBuilder) WithAccessLevel(accessLevel WebhookAccessLevel) *WebhookBuilder {
+	b.webhook.AccessLevel = accessLevel
+	return b
+}
+
+// WithDisplayMode sets when the webhook button is visible.
+func (b *WebhookBuilder) WithDisplayMode(displayMode WebhookDisplayMode) *WebhookBuilder {
+	b.webhook.DisplayMode = displayMode
+	return b
+}
+
+// WithPayload sets a payload value, sent with the webhook request.
+func (b *WebhookBuilder) WithPayload(key string, value any) *WebhookBuilder {
+	if b.webhook.Payload == nil {
+		b.webhook.Payload = make(map[string]any)
+	}
+
+	b.webhook.Payload[key] = value
+
+	return b
+}
+
+// WithPlainTextInput adds the text input built by the specified builder. A nil builder is ignored.
+func (b *WebhookBuilder) WithPlainTextInput(input *PlainTextInputBuilder) *WebhookBuilder {
+	if input != nil {
+		b.webhook.PlainTextInput = append(b.webhook.PlainTextInput, input.build())
+	}
+
+	return b
+}
+
+// WithCheckboxInput adds the checkbox group built by the specified builder. A nil builder is ignored.
+func (b *WebhookBuilder) WithCheckboxInput(input *CheckboxInputBuilder) *WebhookBuilder {
+	if input != nil {
+		b.webhook.CheckboxInput = append(b.webhook.CheckboxInput, input.build())
+	}
+
+	return b
+}
+
+// build returns a copy of the webhook, so that the builder can be reused.
+func (b *WebhookBuilder) build() *Webhook {
+	webhook := *b.webhook
+	webhook.PlainTextInput = cloneSlice(b.webhook.PlainTextInput)
+	webhook.CheckboxInput = cloneSlice(b.webhook.CheckboxInput)
+	webhook.Payload = maps.Clone(b.webhook.Payload)
+
+	for _, input := range webhook.CheckboxInput {
+		if input != nil {
+			input.Options = cloneSlice(input.Options)
+		}
+	}
+
+	return &webhook
+}
+
+// PlainTextInputBuilder builds a webhook text input for use with WebhookBuilder.WithPlainTextInput.
+type PlainTextInputBuilder struct {
+	input *WebhookPlainTextInput
+}
+
+// NewPlainTextInputBuilder returns a builder for a text input with the specified ID.
+// The maximum length defaults to MaxWebhookInputTextLength.
+func NewPlainTextInputBuilder(id string) *PlainTextInputBuilder {
+	return &PlainTextInputBuilder{
+		input: &WebhookPlainTextInput{
+			ID:        id,
+			MaxLength: MaxWebhookInputTextLength,
+		},
+	}
+}
+
+// WithDescription sets the input description (placeholder).
+func (b *PlainTextInputBuilder) WithDescription(description string) *PlainTextInputBuilder {
+	b.input.Description = description
+	return b
+}
+
+// WithLength sets the minimum and maximum length of the input text.
+func (b *PlainTextInputBuilder) WithLength(minLength, maxLength int) *PlainTextInputBuilder {
+	b.input.MinLength = minLength
+	b.input.MaxLength = maxLength
+	return b
+}
+
+// Multiline makes the input a multi-line text area.
+func (b *PlainTextInputBuilder) Multiline() *PlainTextInputBuilder {
+	b.input.Multiline = true
+	return b
+}
+
+// WithInitialValue sets the initial value of the input.
+func (b *PlainTextInputBuilder) WithInitialValue(value string) *PlainTextInputBuilder {
+	b.input.InitialValue = value
+	return b
+}
+
+func (b *PlainTextInputBuilder) build() *WebhookPlainTextInput {
+	input := *b.input
+	return &input
+}
+
+// CheckboxInputBuilder builds a webhook checkbox group for use with WebhookBuilder.WithCheckboxInput.
+type CheckboxInputBuilder struct {
+	input *WebhookCheckboxInput
+}
+
+// NewCheckboxInputBuilder returns a builder for a checkbox group with the specified ID.
+func NewCheckboxInputBuilder(id string) *CheckboxInputBuilder {
+	return &CheckboxInputBuilder{
+		input: &WebhookCheckboxInput{
+			ID: id,
+		},
+	}
+}
+
+// WithLabel sets the checkbox group label.
+func (b *CheckboxInputBuilder) WithLabel(label string) *CheckboxInputBuilder {
+	b.input.Label = label
+	return b
+}
+
+// WithOption adds a checkbox option.
+func (b *CheckboxInputBuilder) WithOption(value, text string, selected bool) *CheckboxInputBuilder {
+	b.input.Options = append(b.input.Options, &WebhookCheckboxOption{Value: value, Text: text, Selected: selected})
+	return b
+}
+
+func (b *CheckboxInputBuilder) build() *WebhookCheckboxInput {
+	input := *b.input
+	input.Options = cloneSlice(b.input.Options)
+	return &input
+}
+
+// durationSeconds returns the number of whole seconds in d.
+func durationSeconds(d time.Duration) int {
+	return int(d / time.Second)
+}
+
+// cloneSlice returns a copy of s, where each element is a shallow copy of the original element.
+func cloneSlice[T any](s []*T) []*T {
+	if s == nil {
+		return nil
+	}
+
+	c := make([]*T, len(s))
+
+	for i, item := range s {
+		if item != nil {
+			clone := *item
+			c[i] = &clone
+		}
+	}
+
+	return c
+}
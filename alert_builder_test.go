@@ -0,0 +1,241 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("builder should produce the same alert as field assignments", func(t *testing.T) {
+		t.Parallel()
+
+		timestamp := time.Now().UTC()
+
+		alert, err := types.NewAlertBuilder(types.AlertError).
+			WithTimestamp(timestamp).
+			WithHeader("Database Connection Failed").
+			WithText("Unable to connect to production database").
+			WithSlackChannelID("C12345678").
+			AutoResolveAfter(5*time.Minute).
+			WithField("Host", "db-prod-01").
+			WithField("Port", "5432").
+			EscalateAfter(5*time.Minute, types.AlertPanic, "<!here>").
+			WithWebhook(types.NewWebhookBuilder("restart", "https://example.com/webhook/restart", "Restart DB").
+				WithButtonStyle(types.WebhookButtonStyleDanger).
+				WithAccessLevel(types.WebhookAccessLevelChannelAdmins)).
+			Build()
+		require.NoError(t, err)
+
+		expected := types.NewErrorAlert()
+		expected.Timestamp = timestamp
+		expected.Header = "Database Connection Failed"
+		expected.Text = "Unable to connect to production database"
+		expected.SlackChannelID = "C12345678"
+		expected.IssueFollowUpEnabled = true
+		expected.AutoResolveSeconds = 300
+		expected.Fields = []*types.Field{
+			{Title: "Host", Value: "db-prod-01"},
+			{Title: "Port", Value: "5432"},
+		}
+		expected.Escalation = []*types.Escalation{
+			{Severity: types.AlertPanic, DelaySeconds: 300, SlackMentions: []string{"<!here>"}},
+		}
+		expected.Webhooks = []*types.Webhook{
+			{
+				ID:          "restart",
+				URL:         "https://example.com/webhook/restart",
+				ButtonText:  "Restart DB",
+				ButtonStyle: types.WebhookButtonStyleDanger,
+				AccessLevel: types.WebhookAccessLevelChannelAdmins,
+			},
+		}
+		expected.Clean()
+
+		assert.Equal(t, expected, alert)
+	})
+
+	t.Run("all setters should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder(types.AlertWarning).
+			WithCorrelationID("correlation").
+			WithType("type").
+			WithHeader("header").
+			WithHeaderWhenResolved("resolved header").
+			WithText("text").
+			WithTextWhenResolved("resolved text").
+			WithFallbackText("fallback").
+			WithAuthor("author").
+			WithHost("host").
+			WithFooter("footer").
+			WithLink("https://example.com").
+			WithRouteKey("route").
+			WithUsername("username").
+			WithIconEmoji(":warning:").
+			WithMetadata("team", "platform").
+			IgnoreIfTextContains("foo", "bar").
+			NotificationDelay(time.Minute).
+			ArchivingDelay(time.Hour + 500*time.Millisecond).
+			AutoResolveAfter(time.Hour).
+			AutoResolveAsInconclusive().
+			WithEscalation(&types.Escalation{Severity: types.AlertError, DelaySeconds: 60, MoveToChannel: "C123"}).
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, types.AlertWarning, alert.Severity)
+		assert.Equal(t, "correlation", alert.CorrelationID)
+		assert.Equal(t, "type", alert.Type)
+		assert.Equal(t, "header", alert.Header)
+		assert.Equal(t, "resolved header", alert.HeaderWhenResolved)
+		assert.Equal(t, "text", alert.Text)
+		assert.Equal(t, "resolved text", alert.TextWhenResolved)
+		assert.Equal(t, "fallback", alert.FallbackText)
+		assert.Equal(t, "author", alert.Author)
+		assert.Equal(t, "host", alert.Host)
+		assert.Equal(t, "footer", alert.Footer)
+		assert.Equal(t, "https://example.com", alert.Link)
+		assert.Equal(t, "route", alert.RouteKey)
+		assert.Equal(t, "username", alert.Username)
+		assert.Equal(t, ":warning:", alert.IconEmoji)
+		assert.Equal(t, "platform", alert.Metadata["team"])
+		assert.Equal(t, []string{"foo", "bar"}, alert.IgnoreIfTextContains)
+		assert.Equal(t, 60, alert.NotificationDelaySeconds)
+		assert.Equal(t, 3600, alert.ArchivingDelaySeconds)
+		assert.True(t, alert.IssueFollowUpEnabled)
+		assert.Equal(t, 3600, alert.AutoResolveSeconds)
+		assert.True(t, alert.AutoResolveAsInconclusive)
+		require.Len(t, alert.Escalation, 1)
+		assert.Equal(t, "C123", alert.Escalation[0].MoveToChannel)
+	})
+
+	t.Run("webhook inputs should be built", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder(types.AlertError).
+			WithHeader("header").
+			WithWebhook(types.NewWebhookBuilder("ack", "ack-handler", "Acknowledge").
+				WithConfirmationText("Are you sure?").
+				WithDisplayMode(types.WebhookDisplayModeOpenIssue).
+				WithPayload("issue", 42).
+				WithPlainTextInput(types.NewPlainTextInputBuilder("reason").
+					WithDescription("Why?").
+					WithLength(5, 200).
+					Multiline().
+					WithInitialValue("because")).
+				WithCheckboxInput(types.NewCheckboxInputBuilder("notify").
+					WithLabel("Notify").
+					WithOption("team", "Team", true).
+					WithOption("oncall", "On-call", false))).
+			Build()
+		require.NoError(t, err)
+		require.Len(t, alert.Webhooks, 1)
+
+		hook := alert.Webhooks[0]
+		assert.Equal(t, "Are you sure?", hook.ConfirmationText)
+		assert.Equal(t, types.WebhookDisplayModeOpenIssue, hook.DisplayMode)
+		assert.Equal(t, map[string]any{"issue": 42}, hook.Payload)
+		assert.Equal(t, []*types.WebhookPlainTextInput{
+			{ID: "reason", Description: "Why?", MinLength: 5, MaxLength: 200, Multiline: true, InitialValue: "because"},
+		}, hook.PlainTextInput)
+		assert.Equal(t, []*types.WebhookCheckboxInput{
+			{ID: "notify", Label: "Notify", Options: []*types.WebhookCheckboxOption{
+				{Value: "team", Text: "Team", Selected: true},
+				{Value: "oncall", Text: "On-call"},
+			}},
+		}, hook.CheckboxInput)
+	})
+
+	t.Run("text input max length should default to the limit", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder(types.AlertError).
+			WithHeader("header").
+			WithWebhook(types.NewWebhookBuilder("ack", "ack-handler", "Acknowledge").
+				WithPlainTextInput(types.NewPlainTextInputBuilder("reason"))).
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, types.MaxWebhookInputTextLength, alert.Webhooks[0].PlainTextInput[0].MaxLength)
+	})
+
+	t.Run("webhook builder should be reusable", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := types.NewWebhookBuilder(" ack ", "ack-handler", "Acknowledge").
+			WithCheckboxInput(types.NewCheckboxInputBuilder("notify").WithOption(" team ", "Team", false))
+
+		first, err := types.NewAlertBuilder(types.AlertError).WithHeader("first").WithWebhook(webhook).Build()
+		require.NoError(t, err)
+
+		second, err := types.NewAlertBuilder(types.AlertError).WithHeader("second").WithWebhook(webhook).Build()
+		require.NoError(t, err)
+
+		assert.NotSame(t, first.Webhooks[0], second.Webhooks[0])
+		assert.NotSame(t, first.Webhooks[0].CheckboxInput[0].Options[0], second.Webhooks[0].CheckboxInput[0].Options[0])
+		assert.Equal(t, "ack", second.Webhooks[0].ID)
+	})
+
+	t.Run("nil builders should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := types.NewWebhookBuilder("ack", "ack-handler", "Acknowledge").WithPlainTextInput(nil).WithCheckboxInput(nil)
+
+		alert, err := types.NewAlertBuilder(types.AlertError).WithHeader("header").WithWebhook(nil).WithWebhook(webhook).Build()
+		require.NoError(t, err)
+		require.Len(t, alert.Webhooks, 1)
+		assert.Empty(t, alert.Webhooks[0].PlainTextInput)
+		assert.Empty(t, alert.Webhooks[0].CheckboxInput)
+	})
+
+	t.Run("build should clean the alert", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder("").
+			WithHeader("  header  ").
+			WithSlackChannelID(" c12345678 ").
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "header", alert.Header)
+		assert.Equal(t, "C12345678", alert.SlackChannelID)
+		assert.Equal(t, types.AlertError, alert.Severity)
+	})
+
+	t.Run("build should return the validation error", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder(types.AlertError).
+			WithHeader("header").
+			AutoResolveAfter(time.Second).
+			Build()
+		require.Error(t, err)
+		assert.Nil(t, alert)
+
+		var validationErr *types.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "autoResolveSeconds", validationErr.Path)
+
+		_, err = types.NewAlertBuilder(types.AlertError).Build()
+		require.ErrorContains(t, err, "header and text cannot both be empty")
+	})
+
+	t.Run("build should use custom limits", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxFieldCount = 1
+
+		_, err := types.NewAlertBuilder(types.AlertError).
+			WithHeader("header").
+			WithField("a", "1").
+			WithField("b", "2").
+			WithLimits(limits).
+			Build()
+		require.ErrorContains(t, err, "too many fields")
+	})
+}
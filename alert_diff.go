@@ -0,0 +1,220 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// visibleDiffFields are the top-level alert fields (JSON names) that affect the content of the Slack post.
+var visibleDiffFields = map[string]bool{
+	"header":             true,
+	"headerWhenResolved": true,
+	"text":               true,
+	"textWhenResolved":   true,
+	"fallbackText":       true,
+	"author":             true,
+	"host":               true,
+	"footer":             true,
+	"link":               true,
+	"severity":           true,
+	"username":           true,
+	"iconEmoji":          true,
+	"fields":             true,
+	"webhooks":           true,
+}
+
+// DiffOptions control which alert fields are compared by DiffWith.
+type DiffOptions struct {
+	// IgnoreTimestamp skips the Timestamp field, which usually differs between updates.
+	IgnoreTimestamp bool
+
+	// IgnoreMetadata skips the Metadata field.
+	IgnoreMetadata bool
+}
+
+// AlertChange describes a single difference between two alerts.
+type AlertChange struct {
+	// Path is the JSON path of the changed value, such as 'header', 'fields[3].value' or 'metadata.region'.
+	// Added or removed list items and map entries are reported with the path of the item, such as 'fields[3]'.
+	Path string `json:"path"`
+
+	// Old is the value in the old alert, or nil if the value was added.
+	Old any `json:"old"`
+
+	// New is the value in the new alert, or nil if the value was removed.
+	New any `json:"new"`
+
+	// Visible is true if the change affects the content of the Slack post (such as header, text, severity, fields or webhooks).
+	Visible bool `json:"visible"`
+}
+
+// AlertDiff is a list of differences between two alerts, as returned by Diff.
+type AlertDiff []*AlertChange
+
+// HasVisibleChanges returns true if at least one change affects the content of the Slack post.
+// Alerts without visible changes can be handled without updating the Slack post.
+func (d AlertDiff) HasVisibleChanges() bool {
+	return slices.ContainsFunc(d, func(c *AlertChange) bool { return c.Visible })
+}
+
+// Paths returns the paths of all changes, in order.
+func (d AlertDiff) Paths() []string {
+	paths := make([]string, len(d))
+
+	for i, c := range d {
+		paths[i] = c.Path
+	}
+
+	return paths
+}
+
+// Diff compares all fields of two alerts, and returns the differences ordered by field (in struct order),
+// list index and map key. A nil alert is treated as an empty alert.
+// Nil and empty lists and maps are considered equal.
+func Diff(oldAlert, newAlert *Alert) AlertDiff {
+	return DiffWith(oldAlert, newAlert, nil)
+}
+
+// DiffWith compares two alerts like Diff, using the specified options (or comparing all fields if nil).
+func DiffWith(oldAlert, newAlert *Alert, opts *DiffOptions) AlertDiff {
+	if opts == nil {
+		opts = &DiffOptions{}
+	}
+
+	if oldAlert == nil {
+		oldAlert = &Alert{}
+	}
+
+	if newAlert == nil {
+		newAlert = &Alert{}
+	}
+
+	d := &differ{opts: opts}
+	d.compare("", reflect.ValueOf(oldAlert).Elem(), reflect.ValueOf(newAlert).Elem())
+
+	return d.changes
+}
+
+// differ compares alert values and records the differences.
+type differ struct {
+	opts    *DiffOptions
+	changes AlertDiff
+}
+
+func (d *differ) record(path string, oldValue, newValue reflect.Value) {
+	top, _, _ := strings.Cut(path, ".")
+	top, _, _ = strings.Cut(top, "[")
+
+	d.changes = append(d.changes, &AlertChange{
+		Path:    path,
+		Old:     valueInterface(oldValue),
+		New:     valueInterface(newValue),
+		Visible: visibleDiffFields[top],
+	})
+}
+
+func (d *differ) compare(path string, oldValue, newValue reflect.Value) {
+	switch oldValue.Kind() {
+	case reflect.Pointer:
+		switch {
+		case oldValue.IsNil() && newValue.IsNil():
+		case oldValue.IsNil() || newValue.IsNil():
+			d.record(path, oldValue, newValue)
+		default:
+			d.compare(path, oldValue.Elem(), newValue.Elem())
+		}
+	case reflect.Struct:
+		if t, ok := oldValue.Interface().(time.Time); ok {
+			if !t.Equal(newValue.Interface().(time.Time)) {
+				d.record(path, oldValue, newValue)
+			}
+
+			return
+		}
+
+		d.compareStruct(path, oldValue, newValue)
+	case reflect.Slice:
+		for i := range max(oldValue.Len(), newValue.Len()) {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			switch {
+			case i >= oldValue.Len():
+				d.record(itemPath, reflect.Value{}, newValue.Index(i))
+			case i >= newValue.Len():
+				d.record(itemPath, oldValue.Index(i), reflect.Value{})
+			default:
+				d.compare(itemPath, oldValue.Index(i), newValue.Index(i))
+			}
+		}
+	case reflect.Map:
+		keys := make([]string, 0, oldValue.Len()+newValue.Len())
+
+		for _, m := range []reflect.Value{oldValue, newValue} {
+			for _, key := range m.MapKeys() {
+				if !slices.Contains(keys, key.String()) {
+					keys = append(keys, key.String())
+				}
+			}
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			k := reflect.ValueOf(key).Convert(oldValue.Type().Key())
+			d.compare(path+"."+key, oldValue.MapIndex(k), newValue.MapIndex(k))
+		}
+	default:
+		// Map entries that only exist on one side are invalid values
+		switch {
+		case !oldValue.IsValid() && !newValue.IsValid():
+		case !oldValue.IsValid() || !newValue.IsValid():
+			d.record(path, oldValue, newValue)
+		case !reflect.DeepEqual(oldValue.Interface(), newValue.Interface()):
+			d.record(path, oldValue, newValue)
+		}
+	}
+}
+
+func (d *differ) compareStruct(path string, oldValue, newValue reflect.Value) {
+	t := oldValue.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if path == "" && (d.opts.IgnoreTimestamp && name == "timestamp" || d.opts.IgnoreMetadata && name == "metadata") {
+			continue
+		}
+
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		d.compare(fieldPath, oldValue.Field(i), newValue.Field(i))
+	}
+}
+
+// valueInterface returns the value as an interface, or nil for invalid values and nil pointers.
+func valueInterface(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+
+	return v.Interface()
+}
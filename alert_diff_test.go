@@ -0,0 +1,136 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDiffTestAlert() *types.Alert {
+	return &types.Alert{
+		Timestamp:      time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		CorrelationID:  "correlation",
+		Header:         "header",
+		Text:           "text",
+		Severity:       types.AlertError,
+		SlackChannelID: "C12345678",
+		Fields:         []*types.Field{{Title: "a", Value: "1"}, {Title: "b", Value: "2"}},
+		Escalation:     []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"<!here>"}}},
+		Webhooks:       []*types.Webhook{{ID: "hook", URL: "https://example.com", ButtonText: "Click", Payload: map[string]any{"key": "value"}}},
+		Metadata:       map[string]any{"region": "eu-west-1"},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal alerts should have no changes", func(t *testing.T) {
+		t.Parallel()
+
+		diff := types.Diff(newDiffTestAlert(), newDiffTestAlert())
+		assert.Empty(t, diff)
+		assert.False(t, diff.HasVisibleChanges())
+	})
+
+	t.Run("nil and empty collections should be equal", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.NewErrorAlert()
+		b := &types.Alert{Timestamp: a.Timestamp, Severity: a.Severity, Fields: []*types.Field{}}
+
+		assert.Empty(t, types.Diff(a, b))
+	})
+
+	t.Run("visible changes should be reported by path", func(t *testing.T) {
+		t.Parallel()
+
+		oldAlert := newDiffTestAlert()
+		newAlert := newDiffTestAlert()
+		newAlert.Header = "new header"
+		newAlert.Severity = types.AlertWarning
+		newAlert.Fields[1].Value = "3"
+		newAlert.Fields = append(newAlert.Fields, &types.Field{Title: "c", Value: "4"})
+		newAlert.Webhooks[0].ButtonText = "Press"
+		newAlert.Webhooks[0].Payload["key"] = "other"
+
+		diff := types.Diff(oldAlert, newAlert)
+
+		assert.Equal(t, []string{
+			"header",
+			"severity",
+			"fields[1].value",
+			"fields[2]",
+			"webhooks[0].buttonText",
+			"webhooks[0].payload.key",
+		}, diff.Paths())
+		assert.True(t, diff.HasVisibleChanges())
+		assert.Equal(t, &types.AlertChange{Path: "header", Old: "header", New: "new header", Visible: true}, diff[0])
+		assert.Equal(t, &types.AlertChange{Path: "fields[2]", Old: nil, New: &types.Field{Title: "c", Value: "4"}, Visible: true}, diff[3])
+	})
+
+	t.Run("invisible changes should not count as visible", func(t *testing.T) {
+		t.Parallel()
+
+		oldAlert := newDiffTestAlert()
+		newAlert := newDiffTestAlert()
+		newAlert.Timestamp = newAlert.Timestamp.Add(time.Minute)
+		newAlert.AutoResolveSeconds = 300
+		newAlert.Escalation[0].SlackMentions = nil
+		newAlert.Metadata["region"] = "us-east-1"
+		newAlert.Metadata["zone"] = "a"
+
+		diff := types.Diff(oldAlert, newAlert)
+
+		assert.Equal(t, []string{
+			"timestamp",
+			"autoResolveSeconds",
+			"escalation[0].slackMentions[0]",
+			"metadata.region",
+			"metadata.zone",
+		}, diff.Paths())
+		assert.False(t, diff.HasVisibleChanges())
+		assert.Equal(t, &types.AlertChange{Path: "metadata.zone", Old: nil, New: "a"}, diff[4])
+	})
+
+	t.Run("removed items should be reported", func(t *testing.T) {
+		t.Parallel()
+
+		oldAlert := newDiffTestAlert()
+		newAlert := newDiffTestAlert()
+		newAlert.Webhooks = nil
+		newAlert.Fields[0] = nil
+
+		diff := types.Diff(oldAlert, newAlert)
+
+		require.Equal(t, []string{"fields[0]", "webhooks[0]"}, diff.Paths())
+		assert.Equal(t, oldAlert.Fields[0], diff[0].Old)
+		assert.Nil(t, diff[0].New)
+		assert.Nil(t, diff[1].New)
+	})
+
+	t.Run("options should ignore timestamp and metadata", func(t *testing.T) {
+		t.Parallel()
+
+		oldAlert := newDiffTestAlert()
+		newAlert := newDiffTestAlert()
+		newAlert.Timestamp = time.Now()
+		newAlert.Metadata = nil
+
+		assert.Equal(t, []string{"timestamp", "metadata.region"}, types.Diff(oldAlert, newAlert).Paths())
+		assert.Empty(t, types.DiffWith(oldAlert, newAlert, &types.DiffOptions{IgnoreTimestamp: true, IgnoreMetadata: true}))
+	})
+
+	t.Run("nil alert should be treated as empty", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "header"}
+
+		diff := types.Diff(nil, a)
+		assert.Equal(t, []string{"header"}, diff.Paths())
+		assert.True(t, diff.HasVisibleChanges())
+		assert.Empty(t, types.Diff(nil, nil))
+	})
+}
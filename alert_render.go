@@ -0,0 +1,271 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// maxRenderedLength is the maximum length (in bytes) of a single rendered template.
+// It guards against templates producing huge output. The rendered values are truncated by Clean in any case.
+const maxRenderedLength = 64 * 1024
+
+// maxRangeIterations is the maximum total number of range iterations of a single rendered template,
+// including nested ranges. It guards against templates looping without producing output (e.g. '{{ range 2000000000 }}{{ end }}').
+const maxRangeIterations = 10000
+
+// rangeLimitFunc is the name of the function appended to every range pipeline, counting the iterations.
+const rangeLimitFunc = "_rangeLimit"
+
+// errRenderedTooLong is returned when a template produces more than maxRenderedLength bytes.
+var errRenderedTooLong = fmt.Errorf("rendered output exceeds %d bytes", maxRenderedLength)
+
+// errTooManyIterations is returned when a template ranges more than maxRangeIterations times.
+var errTooManyIterations = fmt.Errorf("range iterations exceed %d", maxRangeIterations)
+
+// errCallNotAllowed is returned when a template uses the 'call' builtin.
+var errCallNotAllowed = errors.New("function 'call' is not allowed")
+
+// renderData is the data available to alert templates.
+// It is a snapshot of the alert properties, without any methods that templates could call.
+type renderData struct {
+	Timestamp      time.Time
+	CorrelationID  string
+	Type           string
+	Severity       AlertSeverity
+	Author         string
+	Host           string
+	SlackChannelID string
+	RouteKey       string
+	Username       string
+	Link           string
+	Metadata       map[string]any
+}
+
+// renderFuncs is the restricted set of functions available to alert templates, in addition to the
+// text/template builtins (except 'call', which is disabled).
+var renderFuncs = template.FuncMap{
+	"call": func(...any) (any, error) {
+		return nil, errCallNotAllowed
+	},
+	"since": func(t time.Time) string {
+		return time.Since(t).Round(time.Second).String()
+	},
+	"formatTime": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"replace": func(old, replacement, s string) (string, error) {
+		// Guard against growing the value exponentially by nesting replace calls
+		if n := strings.Count(s, old); len(s)+n*(len(replacement)-len(old)) > maxRenderedLength {
+			return "", errRenderedTooLong
+		}
+
+		return strings.ReplaceAll(s, old, replacement), nil
+	},
+	"truncate": func(maxRunes int, s string) string {
+		return truncateString(s, maxRunes)
+	},
+	"default": func(defaultValue, value any) any {
+		if value == nil {
+			return defaultValue
+		}
+
+		if v := reflect.ValueOf(value); v.IsZero() {
+			return defaultValue
+		}
+
+		return value
+	},
+}
+
+// Render renders Header, HeaderWhenResolved, Text, TextWhenResolved, Footer and the field values as Go text/template
+// templates, and replaces them with the result. Only values containing '{{' are treated as templates.
+// Render should be called before Clean.
+//
+// Templates can reference the alert properties .Timestamp, .CorrelationID, .Type, .Severity, .Author, .Host,
+// .SlackChannelID, .RouteKey, .Username, .Link and .Metadata, e.g. '{{ .Metadata.region }}' or '{{ .Host | upper }}'.
+// Referencing a missing metadata key is an error; use '{{ index .Metadata "key" | default "n/a" }}' for optional keys.
+//
+// In addition to the text/template builtins (except 'call', which is disabled), the functions since, formatTime,
+// upper, lower, trim, replace, truncate and default are available. Defining and invoking templates is not allowed, and
+// each template is limited to 10000 range iterations in total.
+//
+// If any template fails to parse or execute, an error describing the failing field is returned, and the alert is left unchanged.
+func (a *Alert) Render() error {
+	data := &renderData{
+		Timestamp:      a.Timestamp,
+		CorrelationID:  a.CorrelationID,
+		Type:           a.Type,
+		Severity:       a.Severity,
+		Author:         a.Author,
+		Host:           a.Host,
+		SlackChannelID: a.SlackChannelID,
+		RouteKey:       a.RouteKey,
+		Username:       a.Username,
+		Link:           a.Link,
+		Metadata:       a.Metadata,
+	}
+
+	targets := []renderTarget{
+		{"header", &a.Header},
+		{"headerWhenResolved", &a.HeaderWhenResolved},
+		{"text", &a.Text},
+		{"textWhenResolved", &a.TextWhenResolved},
+		{"footer", &a.Footer},
+	}
+
+	for index, field := range a.Fields {
+		if field != nil {
+			targets = append(targets, renderTarget{fmt.Sprintf("fields[%d].value", index), &field.Value})
+		}
+	}
+
+	// Render everything before assigning anything, so that the alert is unchanged on error
+	rendered := make([]string, len(targets))
+
+	for i, target := range targets {
+		rendered[i] = *target.value
+
+		if !strings.Contains(*target.value, "{{") {
+			continue
+		}
+
+		result, err := renderTemplate(target.path, *target.value, data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", target.path, err)
+		}
+
+		rendered[i] = result
+	}
+
+	for i, target := range targets {
+		*target.value = rendered[i]
+	}
+
+	return nil
+}
+
+// renderTarget is an alert value to be rendered, with its JSON path.
+type renderTarget struct {
+	path  string
+	value *string
+}
+
+func renderTemplate(name, text string, data *renderData) (string, error) {
+	iterations := 0
+
+	funcs := template.FuncMap{
+		rangeLimitFunc: func(value any) (any, error) {
+			return value, countRangeIterations(value, &iterations)
+		},
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(renderFuncs).Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	if len(tmpl.Templates()) > 1 {
+		return "", errors.New("defining templates is not allowed")
+	}
+
+	if err := limitRanges(tmpl.Tree, tmpl.Root); err != nil {
+		return "", err
+	}
+
+	w := &limitedWriter{limit: maxRenderedLength}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return "", err
+	}
+
+	return w.String(), nil
+}
+
+// limitRanges appends the range limit function to the pipeline of every range in the tree,
+// and rejects template invocations (which could recurse).
+func limitRanges(tree *parse.Tree, node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+
+		for _, child := range n.Nodes {
+			if err := limitRanges(tree, child); err != nil {
+				return err
+			}
+		}
+	case *parse.RangeNode:
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pipe.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(rangeLimitFunc).SetTree(tree).SetPos(n.Pipe.Pos)},
+		})
+
+		return limitBranch(tree, &n.BranchNode)
+	case *parse.IfNode:
+		return limitBranch(tree, &n.BranchNode)
+	case *parse.WithNode:
+		return limitBranch(tree, &n.BranchNode)
+	case *parse.TemplateNode:
+		return errors.New("invoking templates is not allowed")
+	}
+
+	return nil
+}
+
+func limitBranch(tree *parse.Tree, n *parse.BranchNode) error {
+	if err := limitRanges(tree, n.List); err != nil {
+		return err
+	}
+
+	return limitRanges(tree, n.ElseList)
+}
+
+// countRangeIterations adds the number of iterations of ranging over value to the count,
+// and fails if the count exceeds maxRangeIterations.
+func countRangeIterations(value any, count *int) error {
+	n := 0
+
+	switch v := reflect.ValueOf(value); v.Kind() { //nolint:exhaustive // other kinds cannot be ranged over
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = int(min(max(v.Int(), 0), maxRangeIterations+1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n = int(min(v.Uint(), maxRangeIterations+1))
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
+		n = v.Len()
+	case reflect.Func, reflect.Chan:
+		return fmt.Errorf("range over %s is not allowed", v.Kind())
+	}
+
+	*count += n
+
+	if *count > maxRangeIterations {
+		return errTooManyIterations
+	}
+
+	return nil
+}
+
+// limitedWriter is a strings.Builder that fails when more than limit bytes are written.
+type limitedWriter struct {
+	strings.Builder
+
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.Len()+len(p) > w.limit {
+		return 0, errRenderedTooLong
+	}
+
+	return w.Builder.Write(p)
+}
@@ -0,0 +1,186 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("templates should reference metadata and alert properties", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.NewErrorAlert()
+		a.Host = "db-prod-01"
+		a.Metadata = map[string]any{"region": "eu-west-1", "count": 3}
+		a.Header = "High latency in {{ .Metadata.region }}"
+		a.HeaderWhenResolved = "Latency OK in {{ .Metadata.region | upper }}"
+		a.Text = "{{ .Metadata.count }} errors on {{ .Host }} ({{ .Severity }})"
+		a.TextWhenResolved = "Resolved on {{ .Host }}"
+		a.Footer = "{{ index .Metadata \"team\" | default \"no team\" }}"
+		a.Fields = []*types.Field{
+			{Title: "Region", Value: "{{ .Metadata.region }}"},
+			nil,
+			{Title: "Static", Value: "static"},
+		}
+
+		require.NoError(t, a.Render())
+
+		assert.Equal(t, "High latency in eu-west-1", a.Header)
+		assert.Equal(t, "Latency OK in EU-WEST-1", a.HeaderWhenResolved)
+		assert.Equal(t, "3 errors on db-prod-01 (error)", a.Text)
+		assert.Equal(t, "Resolved on db-prod-01", a.TextWhenResolved)
+		assert.Equal(t, "no team", a.Footer)
+		assert.Equal(t, "eu-west-1", a.Fields[0].Value)
+		assert.Equal(t, "static", a.Fields[2].Value)
+	})
+
+	t.Run("values without templates should be left unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "Not a {template}", Text: "```{\"json\": true}```"}
+		require.NoError(t, a.Render())
+		assert.Equal(t, "Not a {template}", a.Header)
+		assert.Equal(t, "```{\"json\": true}```", a.Text)
+	})
+
+	t.Run("time functions should be available", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Timestamp: time.Now().Add(-90 * time.Second),
+			Header:    "Started {{ .Timestamp | since }} ago",
+			Text:      "{{ .Timestamp | formatTime \"2006\" }}",
+		}
+		require.NoError(t, a.Render())
+		assert.Equal(t, "Started 1m30s ago", a.Header)
+		assert.Equal(t, a.Timestamp.Format("2006"), a.Text)
+	})
+
+	t.Run("string functions should be available", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Metadata: map[string]any{"name": "  Service-A  "},
+			Header:   "{{ .Metadata.name | trim | lower | replace \"-\" \" \" }}",
+			Text:     "{{ truncate 5 \"abcdefgh\" }}",
+		}
+		require.NoError(t, a.Render())
+		assert.Equal(t, "service a", a.Header)
+		assert.Equal(t, "abcde", a.Text)
+	})
+
+	t.Run("missing metadata keys should fail with the field path", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Header:   "{{ .Metadata.region }}",
+			Metadata: map[string]any{},
+		}
+
+		err := a.Render()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to render header")
+		assert.Contains(t, err.Error(), `map has no entry for key "region"`)
+	})
+
+	t.Run("errors should leave the alert unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Host:   "host",
+			Header: "{{ .Host }}",
+			Fields: []*types.Field{{Value: "{{ .Unknown }}"}},
+		}
+
+		err := a.Render()
+		require.ErrorContains(t, err, "failed to render fields[0].value")
+		assert.Equal(t, "{{ .Host }}", a.Header)
+	})
+
+	t.Run("invalid templates should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Text: "{{ .Host "}
+		require.ErrorContains(t, a.Render(), "failed to render text")
+	})
+
+	t.Run("alert methods and call should not be available", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "{{ .Clean }}"}
+		require.Error(t, a.Render())
+
+		a = &types.Alert{Header: "{{ call .Metadata.fn }}", Metadata: map[string]any{"fn": func() string { return "x" }}}
+		require.ErrorContains(t, a.Render(), "function 'call' is not allowed")
+	})
+
+	t.Run("huge output should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Text: "{{ range 10000 }}" + strings.Repeat("x", 10) + "{{ end }}"}
+		require.ErrorContains(t, a.Render(), "rendered output exceeds")
+
+		a = &types.Alert{Text: `{{ replace "" .Host (replace "" .Host (replace "" .Host .Host)) }}`, Host: strings.Repeat("x", 100)}
+		require.ErrorContains(t, a.Render(), "rendered output exceeds")
+	})
+
+	t.Run("hostile templates should fail without running for long", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			template string
+			err      string
+		}{
+			{"{{ range 2000000000 }}{{ end }}x", "range iterations exceed 10000"},
+			{"{{ $n := 2000000000 }}{{ range $i := $n }}{{ end }}", "range iterations exceed 10000"},
+			{"{{ range 1000 }}{{ range 1000 }}{{ range 1000 }}{{ end }}{{ end }}{{ end }}", "range iterations exceed 10000"},
+			{"{{ if true }}{{ with .Host }}{{ else }}{{ range 20000 }}{{ end }}{{ end }}{{ end }}", "range iterations exceed 10000"},
+			{`{{ define "a" }}{{ template "a" . }}{{ template "a" . }}{{ end }}{{ template "a" . }}`, "defining templates is not allowed"},
+			{`{{ template "header" . }}{{ template "header" . }}`, "invoking templates is not allowed"},
+		}
+
+		for _, tt := range tests {
+			a := &types.Alert{Header: tt.template}
+
+			start := time.Now()
+			require.ErrorContains(t, a.Render(), tt.err, tt.template)
+			assert.Less(t, time.Since(start), time.Second, tt.template)
+		}
+	})
+
+	t.Run("ranges within the limit should be rendered", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Text:     "{{ range $i, $r := .Metadata.regions }}{{ if $i }}, {{ end }}{{ $r }}{{ end }} {{ range 3 }}x{{ end }}",
+			Metadata: map[string]any{"regions": []any{"eu-west-1", "us-east-1"}},
+		}
+		require.NoError(t, a.Render())
+		assert.Equal(t, "eu-west-1, us-east-1 xxx", a.Text)
+	})
+
+	t.Run("builder should render templates when enabled", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder(types.AlertError).
+			WithMetadata("region", "eu-west-1").
+			WithHeader("Outage in {{ .Metadata.region }}").
+			WithTemplates().
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, "Outage in eu-west-1", alert.Header)
+
+		_, err = types.NewAlertBuilder(types.AlertError).
+			WithHeader("Outage in {{ .Metadata.region }}").
+			WithTemplates().
+			Build()
+		require.ErrorContains(t, err, "failed to render header")
+	})
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Alert wire format (schema) versions.
+const (
+	// AlertSchemaVersion1 is the original alert format. Payloads without a schemaVersion property are decoded as version 1.
+	AlertSchemaVersion1 = 1
+
+	// CurrentAlertSchemaVersion is the alert format version written by EncodeAlert.
+	CurrentAlertSchemaVersion = AlertSchemaVersion1
+)
+
+// AlertSchemaVersionKey is the JSON property holding the schema version of an encoded alert.
+const AlertSchemaVersionKey = "schemaVersion"
+
+// alertMigration converts an alert JSON object between two adjacent schema versions.
+type alertMigration struct {
+	// from is the version accepted by up, and produced by down. The other version is from+1.
+	from int
+	up   func(doc map[string]any) error
+	down func(doc map[string]any) error
+}
+
+// alertMigrations holds the migrations between adjacent schema versions, ordered by version.
+// When the wire format changes in a way that older payloads cannot be decoded as-is (such as a renamed or restructured property),
+// add a migration here and increase CurrentAlertSchemaVersion. New optional properties do not require a new version.
+// Migrations must not lose data that both versions can represent. There are no migrations yet.
+var alertMigrations = []*alertMigration{}
+
+// EncodeAlert encodes the alert as JSON in the current schema version (see EncodeAlertVersion).
+func EncodeAlert(a *Alert) ([]byte, error) {
+	return EncodeAlertVersion(a, CurrentAlertSchemaVersion)
+}
+
+// EncodeAlertVersion encodes the alert as JSON in the specified schema version, for consumers that have not been
+// upgraded yet. Version 1 is written without the schemaVersion property, as before versioning was added.
+// An error is returned if the version is not supported.
+func EncodeAlertVersion(a *Alert, version int) ([]byte, error) {
+	if version < AlertSchemaVersion1 || version > CurrentAlertSchemaVersion {
+		return nil, fmt.Errorf("unsupported alert schema version %d, expected %d-%d", version, AlertSchemaVersion1, CurrentAlertSchemaVersion)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	doc, err := decodeAlertDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for v := CurrentAlertSchemaVersion; v > version; v-- {
+		migration := alertMigrations[v-1-AlertSchemaVersion1]
+
+		if err := migration.down(doc); err != nil {
+			return nil, fmt.Errorf("failed to convert alert from schema version %d to %d: %w", v, v-1, err)
+		}
+	}
+
+	if version > AlertSchemaVersion1 {
+		doc[AlertSchemaVersionKey] = version
+	}
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeAlert decodes an alert encoded in any supported schema version, upgrading older versions to the current format.
+// Payloads without a schemaVersion property (such as alerts encoded with json.Marshal) are decoded as version 1.
+// A *ValidationError is returned if the schema version is invalid or newer than CurrentAlertSchemaVersion.
+func DecodeAlert(data []byte) (*Alert, error) {
+	doc, err := decodeAlertDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := alertSchemaVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for v := version; v < CurrentAlertSchemaVersion; v++ {
+		migration := alertMigrations[v-AlertSchemaVersion1]
+
+		if err := migration.up(doc); err != nil {
+			return nil, fmt.Errorf("failed to convert alert from schema version %d to %d: %w", v, v+1, err)
+		}
+	}
+
+	delete(doc, AlertSchemaVersionKey)
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+
+	var alert Alert
+
+	if err := json.Unmarshal(data, &alert); err != nil {
+		return nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+
+	return &alert, nil
+}
+
+// decodeAlertDocument decodes an alert JSON object as a map, keeping numbers as json.Number to avoid loss of precision.
+func decodeAlertDocument(data []byte) (map[string]any, error) {
+	var doc map[string]any
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+
+	if doc == nil {
+		return nil, fmt.Errorf("failed to decode alert: alert is null")
+	}
+
+	return doc, nil
+}
+
+// alertSchemaVersion returns the schema version of an alert JSON object.
+func alertSchemaVersion(doc map[string]any) (int, error) {
+	value, ok := doc[AlertSchemaVersionKey]
+	if !ok {
+		return AlertSchemaVersion1, nil
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, &ValidationError{
+			Path:    AlertSchemaVersionKey,
+			Code:    ValidationErrorCodeInvalidFormat,
+			Actual:  value,
+			Message: "schemaVersion must be an integer",
+		}
+	}
+
+	version, err := number.Int64()
+	if err != nil || version < AlertSchemaVersion1 || version > CurrentAlertSchemaVersion {
+		return 0, &ValidationError{
+			Path:    AlertSchemaVersionKey,
+			Code:    ValidationErrorCodeInvalidValue,
+			Limit:   CurrentAlertSchemaVersion,
+			Actual:  number.String(),
+			Message: fmt.Sprintf("unsupported alert schema version %s, expected %d-%d", number, AlertSchemaVersion1, CurrentAlertSchemaVersion),
+		}
+	}
+
+	return int(version), nil
+}
@@ -0,0 +1,192 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func newSchemaVersionTestAlert() *types.Alert {
+	return &types.Alert{
+		Timestamp:            time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		CorrelationID:        "checkout-5xx-prod",
+		Type:                 "metrics",
+		Header:               ":status: Checkout 5xx rate above 5%",
+		HeaderWhenResolved:   ":status: Checkout 5xx rate back to normal",
+		Text:                 "The 5xx rate has been above 5% for 10 minutes.",
+		Footer:               "team-checkout",
+		Link:                 "https://runbooks.example.com/checkout-5xx",
+		IssueFollowUpEnabled: true,
+		AutoResolveSeconds:   3600,
+		Severity:             types.AlertError,
+		SlackChannelID:       "C0123456789",
+		Fields:               []*types.Field{{Title: "Region", Value: "eu-west-1"}},
+		Escalation:           []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 900, SlackMentions: []string{"<!here>"}}},
+		IgnoreIfTextContains: []string{"maintenance"},
+		Webhooks: []*types.Webhook{
+			{
+				ID:             "restart",
+				URL:            "https://ops.example.com/restart",
+				ButtonText:     "Restart pods",
+				ButtonStyle:    types.WebhookButtonStyleDanger,
+				Payload:        map[string]any{"service": "checkout"},
+				PlainTextInput: []*types.WebhookPlainTextInput{{ID: "reason", MaxLength: 200}},
+			},
+		},
+		Metadata: map[string]any{"region": "eu-west-1"},
+	}
+}
+
+func TestEncodeAlertGolden(t *testing.T) {
+	t.Parallel()
+
+	for version := types.AlertSchemaVersion1; version <= types.CurrentAlertSchemaVersion; version++ {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			t.Parallel()
+
+			alert := newSchemaVersionTestAlert()
+
+			actual, err := types.EncodeAlertVersion(alert, version)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", "alert_schema", fmt.Sprintf("v%d.json", version))
+
+			if *update {
+				var indented any
+				require.NoError(t, json.Unmarshal(actual, &indented))
+				data, err := json.MarshalIndent(indented, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o750))
+				require.NoError(t, os.WriteFile(golden, append(data, '\n'), 0o600))
+			}
+
+			// Encoding must match the golden file, and the golden file must decode to the original alert
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+
+			decoded, err := types.DecodeAlert(expected)
+			require.NoError(t, err)
+			assert.Equal(t, alert, decoded)
+		})
+	}
+}
+
+func TestDecodeAlert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unversioned JSON should decode as version 1", func(t *testing.T) {
+		t.Parallel()
+
+		alert := newSchemaVersionTestAlert()
+
+		data, err := json.Marshal(alert)
+		require.NoError(t, err)
+
+		decoded, err := types.DecodeAlert(data)
+		require.NoError(t, err)
+		assert.Equal(t, alert, decoded)
+	})
+
+	t.Run("deprecated properties should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := types.DecodeAlert([]byte(`{"header": "header", "failOnRateLimitError": true}`))
+		require.NoError(t, err)
+		assert.Equal(t, "header", decoded.Header)
+		assert.True(t, decoded.FailOnRateLimitError)
+
+		data, err := types.EncodeAlert(decoded)
+		require.NoError(t, err)
+
+		decoded, err = types.DecodeAlert(data)
+		require.NoError(t, err)
+		assert.True(t, decoded.FailOnRateLimitError)
+	})
+
+	t.Run("version 1 should be written without the schema version", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := types.EncodeAlertVersion(types.NewErrorAlert(), types.AlertSchemaVersion1)
+		require.NoError(t, err)
+
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(data, &doc))
+		assert.NotContains(t, doc, types.AlertSchemaVersionKey)
+		assert.Contains(t, doc, "failOnRateLimitError")
+	})
+
+	t.Run("invalid schema versions should fail", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			input string
+			code  types.ValidationErrorCode
+		}{
+			{input: `{"schemaVersion": 0}`, code: types.ValidationErrorCodeInvalidValue},
+			{input: `{"schemaVersion": 2}`, code: types.ValidationErrorCodeInvalidValue},
+			{input: `{"schemaVersion": 1.5}`, code: types.ValidationErrorCodeInvalidValue},
+			{input: `{"schemaVersion": "2"}`, code: types.ValidationErrorCodeInvalidFormat},
+		}
+
+		for _, tt := range tests {
+			_, err := types.DecodeAlert([]byte(tt.input))
+
+			var validationErr *types.ValidationError
+			require.True(t, errors.As(err, &validationErr), tt.input)
+			assert.Equal(t, types.AlertSchemaVersionKey, validationErr.Path, tt.input)
+			assert.Equal(t, tt.code, validationErr.Code, tt.input)
+		}
+	})
+
+	t.Run("invalid JSON should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.DecodeAlert([]byte(`{"header": `))
+		require.ErrorContains(t, err, "failed to decode alert")
+
+		_, err = types.DecodeAlert([]byte(`null`))
+		require.ErrorContains(t, err, "alert is null")
+
+		_, err = types.DecodeAlert([]byte(`{"autoResolveSeconds": "soon"}`))
+		require.ErrorContains(t, err, "failed to decode alert")
+	})
+
+	t.Run("unsupported encode version should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.EncodeAlertVersion(types.NewErrorAlert(), types.CurrentAlertSchemaVersion+1)
+		require.ErrorContains(t, err, "unsupported alert schema version")
+	})
+
+	t.Run("batch entries should be decoded by version", func(t *testing.T) {
+		t.Parallel()
+
+		var batch types.AlertBatch
+		require.NoError(t, json.Unmarshal([]byte(`[
+			{"header": "unversioned"},
+			{"schemaVersion": 1, "header": "v1"},
+			{"schemaVersion": 99, "header": "future"}
+		]`), &batch))
+
+		assert.Equal(t, "unversioned", batch.Alerts[0].Header)
+		assert.Equal(t, "v1", batch.Alerts[1].Header)
+		assert.Nil(t, batch.Alerts[2])
+
+		result, err := batch.CleanAndValidate()
+		require.NoError(t, err)
+		require.Len(t, result.Results[2].Errors, 1)
+		assert.Equal(t, types.AlertSchemaVersionKey, result.Results[2].Errors[0].Path)
+	})
+}
@@ -941,6 +941,11 @@ func TestAlertValidation(t *testing.T) {
 		a.Clean()
 		require.ErrorContains(t, a.Validate(), "escalation[0].slackMentions[0] is not valid")
 
+		// Escalation mentions may be user groups and email addresses
+		a = &types.Alert{Header: "a", RouteKey: "b", Escalation: []*types.Escalation{{DelaySeconds: types.MinEscalationDelaySeconds, Severity: types.AlertError, SlackMentions: []string{"<!subteam^S123>", "jane@example.com"}}}}
+		a.Clean()
+		require.NoError(t, a.Validate())
+
 		// Escalation moveToChannel must be a valid channel ID or channel name
 		a = &types.Alert{Header: "a", RouteKey: "b", Escalation: []*types.Escalation{{DelaySeconds: types.MinEscalationDelaySeconds, Severity: types.AlertError, MoveToChannel: "foo bar"}}}
 		a.Clean()
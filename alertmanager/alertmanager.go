@@ -0,0 +1,141 @@
+// Package alertmanager converts Prometheus Alertmanager webhook payloads (version 4) to Slack Manager alerts.
+//
+// Usage in an HTTP handler:
+//
+//	msg, err := alertmanager.Decode(r.Body)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//
+//	for _, alert := range alertmanager.Convert(msg, alertmanager.DefaultConfig()) {
+//	    alert.Clean()
+//	    if err := alert.Validate(); err != nil {
+//	        // handle error
+//	    }
+//	}
+package alertmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/mapping"
+)
+
+// SupportedVersion is the Alertmanager webhook payload version supported by Decode.
+const SupportedVersion = "4"
+
+// Status values used by Alertmanager, for both messages and individual alerts.
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Message is an Alertmanager webhook payload.
+// See https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+type Message struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []*Alert          `json:"alerts"`
+}
+
+// Alert is a single alert in an Alertmanager webhook payload.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Decode decodes an Alertmanager webhook payload. An error is returned if the JSON is invalid,
+// or if the payload version is not SupportedVersion.
+func Decode(r io.Reader) (*Message, error) {
+	var msg Message
+
+	if err := json.NewDecoder(r).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("failed to decode Alertmanager payload: %w", err)
+	}
+
+	if msg.Version != SupportedVersion {
+		return nil, fmt.Errorf("unsupported Alertmanager payload version '%s', expected '%s'", msg.Version, SupportedVersion)
+	}
+
+	return &msg, nil
+}
+
+// Config defines how Alertmanager alerts are mapped to Slack Manager alerts. Use DefaultConfig to get the default mapping.
+type Config struct {
+	// Config holds the mapping rules shared with the other converters.
+	mapping.Config
+
+	// Customize is called for each converted alert, and can modify the result further. Ignored if nil.
+	Customize func(alert *types.Alert, source *Alert, msg *Message)
+}
+
+// DefaultConfig returns a new Config instance with the default mapping, see mapping.DefaultConfig.
+func DefaultConfig() *Config {
+	return &Config{
+		Config: *mapping.DefaultConfig(),
+	}
+}
+
+// Convert converts each alert in the Alertmanager message to a Slack Manager alert, using the specified config
+// (or the default config if nil). The returned alerts are not cleaned or validated.
+func Convert(msg *Message, cfg *Config) []*types.Alert {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	alerts := make([]*types.Alert, 0, len(msg.Alerts))
+
+	for _, source := range msg.Alerts {
+		if source == nil {
+			continue
+		}
+
+		alerts = append(alerts, convertAlert(source, msg, cfg))
+	}
+
+	return alerts
+}
+
+func convertAlert(source *Alert, msg *Message, cfg *Config) *types.Alert {
+	alert := types.NewAlert(types.AlertError)
+
+	cfg.Apply(alert, &mapping.Source{
+		Labels:      source.Labels,
+		Annotations: source.Annotations,
+		Resolved:    source.Status == StatusResolved,
+		Receiver:    msg.Receiver,
+	})
+
+	alert.CorrelationID = source.Fingerprint
+	alert.Link = source.GeneratorURL
+
+	switch {
+	case source.Status == StatusResolved && !source.EndsAt.IsZero():
+		alert.Timestamp = source.EndsAt
+	case !source.StartsAt.IsZero():
+		alert.Timestamp = source.StartsAt
+	}
+
+	if cfg.Customize != nil {
+		cfg.Customize(alert, source, msg)
+	}
+
+	return alert
+}
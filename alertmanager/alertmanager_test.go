@@ -0,0 +1,161 @@
+package alertmanager_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/alertmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestConvertGolden(t *testing.T) {
+	t.Parallel()
+
+	payloads, err := filepath.Glob("testdata/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, payloads)
+
+	for _, payload := range payloads {
+		if strings.HasSuffix(payload, ".golden.json") {
+			continue
+		}
+
+		t.Run(filepath.Base(payload), func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(payload)
+			require.NoError(t, err)
+			defer f.Close()
+
+			msg, err := alertmanager.Decode(f)
+			require.NoError(t, err)
+
+			alerts := alertmanager.Convert(msg, alertmanager.DefaultConfig())
+
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			require.NoError(t, enc.Encode(alerts))
+			actual := buf.Bytes()
+
+			golden := strings.TrimSuffix(payload, ".json") + ".golden.json"
+
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o600))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+
+			for _, alert := range alerts {
+				alert.Timestamp = time.Now()
+				alert.Clean()
+				require.NoError(t, alert.Validate())
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported version should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := alertmanager.Decode(strings.NewReader(`{"version": "3", "alerts": []}`))
+		require.ErrorContains(t, err, "unsupported Alertmanager payload version '3'")
+	})
+
+	t.Run("invalid JSON should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := alertmanager.Decode(strings.NewReader(`{"version": `))
+		require.ErrorContains(t, err, "failed to decode Alertmanager payload")
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	msg := &alertmanager.Message{
+		Version:  "4",
+		Receiver: "team",
+		Alerts: []*alertmanager.Alert{
+			{
+				Status:      alertmanager.StatusFiring,
+				Labels:      map[string]string{"alertname": "Test", "level": "p1", "zone": "a", "env": "prod", "team": "platform"},
+				Annotations: map[string]string{"headline": "Headline"},
+				StartsAt:    time.Now(),
+				Fingerprint: "fingerprint",
+			},
+			nil,
+		},
+	}
+
+	t.Run("nil config should use the default config", func(t *testing.T) {
+		t.Parallel()
+
+		alerts := alertmanager.Convert(msg, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "Test", alerts[0].Header)
+		assert.Equal(t, types.AlertError, alerts[0].Severity)
+		assert.Equal(t, "team", alerts[0].RouteKey)
+		assert.Equal(t, "fingerprint", alerts[0].CorrelationID)
+		assert.True(t, alerts[0].IssueFollowUpEnabled)
+		assert.Equal(t, 86400, alerts[0].AutoResolveSeconds)
+	})
+
+	t.Run("custom mapping rules should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := alertmanager.DefaultConfig()
+		cfg.SeverityLabel = "level"
+		cfg.SeverityMapping = map[string]types.AlertSeverity{"p1": types.AlertPanic}
+		cfg.HeaderAnnotations = []string{"headline"}
+		cfg.FieldLabels = []string{"zone", "env", "missing"}
+		cfg.SlackChannelID = "C123"
+		cfg.RouteKey = "route"
+		cfg.DisableIssueFollowUp = true
+		cfg.Customize = func(alert *types.Alert, source *alertmanager.Alert, _ *alertmanager.Message) {
+			alert.Footer = "team " + source.Labels["team"]
+		}
+
+		alerts := alertmanager.Convert(msg, cfg)
+		require.Len(t, alerts, 1)
+
+		alert := alerts[0]
+		assert.Equal(t, types.AlertPanic, alert.Severity)
+		assert.Equal(t, "Headline", alert.Header)
+		assert.Equal(t, []*types.Field{{Title: "zone", Value: "a"}, {Title: "env", Value: "prod"}}, alert.Fields)
+		assert.Equal(t, "C123", alert.SlackChannelID)
+		assert.Equal(t, "route", alert.RouteKey)
+		assert.False(t, alert.IssueFollowUpEnabled)
+		assert.Zero(t, alert.AutoResolveSeconds)
+		assert.Equal(t, "team platform", alert.Footer)
+	})
+
+	t.Run("fields should be limited to the max field count", func(t *testing.T) {
+		t.Parallel()
+
+		labels := make(map[string]string)
+		for i := range types.MaxFieldCount + 5 {
+			labels[strings.Repeat("l", i+1)] = "value"
+		}
+
+		alerts := alertmanager.Convert(&alertmanager.Message{Alerts: []*alertmanager.Alert{{Labels: labels}}}, nil)
+		require.Len(t, alerts, 1)
+		assert.Len(t, alerts[0].Fields, types.MaxFieldCount)
+	})
+}
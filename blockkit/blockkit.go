@@ -0,0 +1,451 @@
+// Package blockkit renders alerts as Slack Block Kit messages, approximating what the Slack Manager posts for an issue.
+// It is intended for previews and for snapshot tests of alert designs, without sending alerts through the whole system.
+//
+// Usage:
+//
+//	msg, err := blockkit.Render(alert, blockkit.StateOpen)
+//	if err != nil {
+//	    // handle error
+//	}
+//
+//	body, _ := json.Marshal(msg) // paste the blocks into Slack's Block Kit Builder
+//
+// The alert is rendered as-is, so call Clean first to preview the cleaned content.
+package blockkit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// Slack Block Kit limits.
+// See https://api.slack.com/reference/block-kit/blocks
+const (
+	// MaxBlocks is the maximum number of blocks in a message.
+	MaxBlocks = 50
+	// MaxHeaderTextLength is the maximum length of the text in a header block.
+	MaxHeaderTextLength = 150
+	// MaxSectionTextLength is the maximum length of the text in a section block.
+	MaxSectionTextLength = 3000
+	// MaxSectionFieldCount is the maximum number of fields in a section block.
+	MaxSectionFieldCount = 10
+	// MaxSectionFieldTextLength is the maximum length of each field text in a section block.
+	MaxSectionFieldTextLength = 2000
+	// MaxContextElementCount is the maximum number of elements in a context block.
+	MaxContextElementCount = 10
+	// MaxActionElementCount is the maximum number of elements in an actions block.
+	MaxActionElementCount = 25
+)
+
+// Block, text object and element types.
+const (
+	BlockTypeHeader  = "header"
+	BlockTypeSection = "section"
+	BlockTypeContext = "context"
+	BlockTypeActions = "actions"
+
+	TextTypePlain    = "plain_text"
+	TextTypeMarkdown = "mrkdwn"
+
+	ElementTypeButton = "button"
+)
+
+// statusPlaceholder is replaced with the status emoji in the header and text.
+const statusPlaceholder = ":status:"
+
+// IssueState is the state of the issue that an alert is rendered for.
+type IssueState string
+
+const (
+	// StateOpen renders the alert as a new, open issue.
+	StateOpen IssueState = "open"
+
+	// StateEscalated renders the alert as an open issue where all escalation points have been triggered:
+	// the severity is that of the last escalation, and the escalation mentions are included.
+	StateEscalated IssueState = "escalated"
+
+	// StateResolved renders the alert as a resolved issue, using HeaderWhenResolved and TextWhenResolved when set.
+	StateResolved IssueState = "resolved"
+)
+
+// IssueStateIsValid returns true if the provided IssueState is valid.
+func IssueStateIsValid(s IssueState) bool {
+	switch s {
+	case StateOpen, StateEscalated, StateResolved:
+		return true
+	}
+	return false
+}
+
+// ValidIssueStates returns a slice of valid IssueState values.
+func ValidIssueStates() []string {
+	return []string{
+		string(StateOpen),
+		string(StateEscalated),
+		string(StateResolved),
+	}
+}
+
+// Message is a Slack message with Block Kit blocks, as accepted by chat.postMessage.
+type Message struct {
+	Text      string   `json:"text"`
+	Username  string   `json:"username,omitempty"`
+	IconEmoji string   `json:"icon_emoji,omitempty"`
+	Blocks    []*Block `json:"blocks"`
+}
+
+// Block is a Block Kit layout block. Only the properties used by the renderer are included.
+type Block struct {
+	Type string `json:"type"`
+	Text *Text  `json:"text,omitempty"`
+
+	// Fields holds the fields of a section block.
+	Fields []*Text `json:"fields,omitempty"`
+
+	// Elements holds the elements of a context block (*Text) or an actions block (*Button).
+	Elements []any `json:"elements,omitempty"`
+}
+
+// Text is a Block Kit text object.
+type Text struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// Button is a Block Kit button element.
+type Button struct {
+	Type     string   `json:"type"`
+	ActionID string   `json:"action_id"`
+	Text     *Text    `json:"text"`
+	Value    string   `json:"value,omitempty"`
+	Style    string   `json:"style,omitempty"`
+	Confirm  *Confirm `json:"confirm,omitempty"`
+}
+
+// Confirm is a Block Kit confirmation dialog object.
+type Confirm struct {
+	Title   *Text `json:"title"`
+	Text    *Text `json:"text"`
+	Confirm *Text `json:"confirm"`
+	Deny    *Text `json:"deny"`
+}
+
+// Options control how alerts are rendered. Use DefaultOptions to get the default options.
+type Options struct {
+	// StatusEmojis maps alert severities to the status emoji, which replaces :status: in the header and text.
+	StatusEmojis map[types.AlertSeverity]string
+
+	// InconclusiveEmoji is the status emoji for resolved issues, when the alert has AutoResolveAsInconclusive set.
+	InconclusiveEmoji string
+
+	// PrefixHeader prefixes the header with the status emoji, when neither header nor text contains :status:.
+	PrefixHeader bool
+}
+
+// DefaultOptions returns a new Options instance with the default status emojis.
+func DefaultOptions() *Options {
+	return &Options{
+		StatusEmojis: map[types.AlertSeverity]string{
+			types.AlertPanic:    ":rotating_light:",
+			types.AlertError:    ":red_circle:",
+			types.AlertWarning:  ":warning:",
+			types.AlertResolved: ":white_check_mark:",
+			types.AlertInfo:     ":information_source:",
+		},
+		InconclusiveEmoji: ":grey_question:",
+		PrefixHeader:      true,
+	}
+}
+
+// Render renders the alert as a Block Kit message for the specified issue state, using the default options.
+func Render(alert *types.Alert, state IssueState) (*Message, error) {
+	return RenderWith(alert, state, DefaultOptions())
+}
+
+// RenderWith renders the alert as a Block Kit message for the specified issue state, using the specified options
+// (or the default options if nil). The alert is not modified.
+//
+// The message has a header block, section blocks for the text and the fields, a section with the escalation mentions
+// (escalated state only), context blocks for author, host, link and footer, and an actions block with the
+// webhook buttons visible in the issue state. Texts are truncated to the Block Kit limits, long text is split
+// across several sections, and the message is capped at MaxBlocks blocks.
+func RenderWith(alert *types.Alert, state IssueState, opts *Options) (*Message, error) {
+	if alert == nil {
+		return nil, fmt.Errorf("alert is nil")
+	}
+
+	if !IssueStateIsValid(state) {
+		return nil, fmt.Errorf("invalid issue state '%s', expected one of %s", state, strings.Join(ValidIssueStates(), ", "))
+	}
+
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	r := &renderer{alert: alert, state: state, opts: opts}
+
+	return r.render(), nil
+}
+
+type renderer struct {
+	alert  *types.Alert
+	state  IssueState
+	opts   *Options
+	blocks []*Block
+}
+
+func (r *renderer) render() *Message {
+	header, text := r.content()
+
+	emoji := r.statusEmoji()
+	placeholder := strings.Contains(header, statusPlaceholder) || strings.Contains(text, statusPlaceholder)
+
+	header = strings.ReplaceAll(header, statusPlaceholder, emoji)
+	text = strings.ReplaceAll(text, statusPlaceholder, emoji)
+
+	if header != "" && !placeholder && r.opts.PrefixHeader && emoji != "" {
+		header = emoji + " " + header
+	}
+
+	if header != "" {
+		r.add(&Block{Type: BlockTypeHeader, Text: &Text{Type: TextTypePlain, Text: truncate(header, MaxHeaderTextLength), Emoji: true}})
+	}
+
+	for _, chunk := range split(text, MaxSectionTextLength) {
+		r.add(&Block{Type: BlockTypeSection, Text: &Text{Type: TextTypeMarkdown, Text: chunk}})
+	}
+
+	r.renderFields()
+	r.renderMentions()
+	r.renderContext()
+	r.renderWebhooks()
+
+	fallback := r.alert.FallbackText
+	if fallback == "" {
+		fallback = header
+	}
+
+	if fallback == "" {
+		fallback = truncate(text, MaxSectionTextLength)
+	}
+
+	if len(r.blocks) > MaxBlocks {
+		r.blocks = r.blocks[:MaxBlocks]
+	}
+
+	return &Message{
+		Text:      fallback,
+		Username:  r.alert.Username,
+		IconEmoji: r.alert.IconEmoji,
+		Blocks:    r.blocks,
+	}
+}
+
+func (r *renderer) add(block *Block) {
+	r.blocks = append(r.blocks, block)
+}
+
+// content returns the header and text for the issue state.
+func (r *renderer) content() (string, string) {
+	header, text := r.alert.Header, r.alert.Text
+
+	if r.state == StateResolved {
+		if r.alert.HeaderWhenResolved != "" {
+			header = r.alert.HeaderWhenResolved
+		}
+
+		if r.alert.TextWhenResolved != "" {
+			text = r.alert.TextWhenResolved
+		}
+	}
+
+	return header, text
+}
+
+// severity returns the issue severity for the issue state.
+func (r *renderer) severity() types.AlertSeverity {
+	switch r.state {
+	case StateResolved:
+		return types.AlertResolved
+	case StateEscalated:
+		if escalation := r.lastEscalation(); escalation != nil && escalation.Severity != "" {
+			return escalation.Severity
+		}
+	}
+
+	if r.alert.Severity == "" {
+		return types.AlertError
+	}
+
+	return r.alert.Severity
+}
+
+func (r *renderer) statusEmoji() string {
+	if r.state == StateResolved && r.alert.AutoResolveAsInconclusive && r.opts.InconclusiveEmoji != "" {
+		return r.opts.InconclusiveEmoji
+	}
+
+	return r.opts.StatusEmojis[r.severity()]
+}
+
+func (r *renderer) lastEscalation() *types.Escalation {
+	var last *types.Escalation
+
+	for _, escalation := range r.alert.Escalation {
+		if escalation != nil && (last == nil || escalation.DelaySeconds >= last.DelaySeconds) {
+			last = escalation
+		}
+	}
+
+	return last
+}
+
+func (r *renderer) renderFields() {
+	var fields []*Text
+
+	for _, field := range r.alert.Fields {
+		if field == nil {
+			continue
+		}
+
+		fields = append(fields, &Text{Type: TextTypeMarkdown, Text: truncate(fmt.Sprintf("*%s*\n%s", field.Title, field.Value), MaxSectionFieldTextLength)})
+	}
+
+	for chunk := range slices.Chunk(fields, MaxSectionFieldCount) {
+		r.add(&Block{Type: BlockTypeSection, Fields: chunk})
+	}
+}
+
+func (r *renderer) renderContext() {
+	var elements []any
+
+	if r.alert.Author != "" {
+		elements = append(elements, &Text{Type: TextTypeMarkdown, Text: "*Author:* " + r.alert.Author})
+	}
+
+	if r.alert.Host != "" {
+		elements = append(elements, &Text{Type: TextTypeMarkdown, Text: "*Host:* " + r.alert.Host})
+	}
+
+	if r.alert.Link != "" {
+		elements = append(elements, &Text{Type: TextTypeMarkdown, Text: fmt.Sprintf("<%s|More info>", r.alert.Link)})
+	}
+
+	if len(elements) > 0 {
+		r.add(&Block{Type: BlockTypeContext, Elements: elements})
+	}
+
+	if r.alert.Footer != "" {
+		r.add(&Block{Type: BlockTypeContext, Elements: []any{&Text{Type: TextTypeMarkdown, Text: r.alert.Footer}}})
+	}
+}
+
+func (r *renderer) renderMentions() {
+	if r.state != StateEscalated {
+		return
+	}
+
+	var mentions []string
+
+	for _, escalation := range r.alert.Escalation {
+		if escalation == nil {
+			continue
+		}
+
+		for _, mention := range escalation.SlackMentions {
+			if !slices.Contains(mentions, mention) {
+				mentions = append(mentions, mention)
+			}
+		}
+	}
+
+	if len(mentions) > 0 {
+		r.add(&Block{Type: BlockTypeSection, Text: &Text{Type: TextTypeMarkdown, Text: truncate(strings.Join(mentions, " "), MaxSectionTextLength)}})
+	}
+}
+
+func (r *renderer) renderWebhooks() {
+	var elements []any
+
+	for _, webhook := range r.alert.Webhooks {
+		if webhook == nil || !r.webhookVisible(webhook) || len(elements) == MaxActionElementCount {
+			continue
+		}
+
+		button := &Button{
+			Type:     ElementTypeButton,
+			ActionID: webhook.ID,
+			Text:     &Text{Type: TextTypePlain, Text: webhook.ButtonText},
+			Value:    webhook.ID,
+			Style:    string(webhook.ButtonStyle),
+		}
+
+		if webhook.ConfirmationText != "" {
+			button.Confirm = &Confirm{
+				Title:   &Text{Type: TextTypePlain, Text: "Are you sure?"},
+				Text:    &Text{Type: TextTypeMarkdown, Text: webhook.ConfirmationText},
+				Confirm: &Text{Type: TextTypePlain, Text: "Confirm"},
+				Deny:    &Text{Type: TextTypePlain, Text: "Cancel"},
+			}
+		}
+
+		elements = append(elements, button)
+	}
+
+	if len(elements) > 0 {
+		r.add(&Block{Type: BlockTypeActions, Elements: elements})
+	}
+}
+
+func (r *renderer) webhookVisible(webhook *types.Webhook) bool {
+	switch webhook.DisplayMode {
+	case types.WebhookDisplayModeOpenIssue:
+		return r.state != StateResolved
+	case types.WebhookDisplayModeResolvedIssue:
+		return r.state == StateResolved
+	default:
+		return true
+	}
+}
+
+// truncate truncates s to maxRunes characters, ending with '...' when truncated.
+func truncate(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+
+	return string(runes[:maxRunes-3]) + "..."
+}
+
+// split splits s into chunks of at most maxRunes characters, preferring to split at line breaks.
+func split(s string, maxRunes int) []string {
+	var chunks []string
+
+	runes := []rune(s)
+
+	for len(runes) > maxRunes {
+		end := maxRunes
+
+		// Split after the last line break, unless that gives a very short chunk
+		for i := maxRunes - 1; i > maxRunes/2; i-- {
+			if runes[i] == '\n' {
+				end = i + 1
+				break
+			}
+		}
+
+		chunks = append(chunks, string(runes[:end]))
+		runes = runes[end:]
+	}
+
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+
+	return chunks
+}
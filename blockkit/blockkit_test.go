@@ -0,0 +1,181 @@
+package blockkit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/blockkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRenderGolden(t *testing.T) {
+	t.Parallel()
+
+	inputs, err := filepath.Glob("testdata/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		if strings.HasSuffix(input, ".golden.json") {
+			continue
+		}
+
+		data, err := os.ReadFile(input)
+		require.NoError(t, err)
+
+		for _, state := range blockkit.ValidIssueStates() {
+			t.Run(filepath.Base(input)+"/"+state, func(t *testing.T) {
+				t.Parallel()
+
+				var alert types.Alert
+				require.NoError(t, json.Unmarshal(data, &alert))
+
+				msg, err := blockkit.Render(&alert, blockkit.IssueState(state))
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				enc := json.NewEncoder(&buf)
+				enc.SetEscapeHTML(false)
+				enc.SetIndent("", "  ")
+				require.NoError(t, enc.Encode(msg))
+				actual := buf.Bytes()
+
+				golden := strings.TrimSuffix(input, ".json") + "." + state + ".golden.json"
+
+				if *update {
+					require.NoError(t, os.WriteFile(golden, actual, 0o600))
+				}
+
+				expected, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(expected), string(actual))
+			})
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid input should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := blockkit.Render(nil, blockkit.StateOpen)
+		require.ErrorContains(t, err, "alert is nil")
+
+		_, err = blockkit.Render(types.NewErrorAlert(), "closed")
+		require.ErrorContains(t, err, "invalid issue state 'closed'")
+	})
+
+	t.Run("long text should be split into sections", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Text = strings.Repeat("a", 2000) + "\n" + strings.Repeat("b", 2000) + strings.Repeat("c", 4000)
+
+		msg, err := blockkit.Render(alert, blockkit.StateOpen)
+		require.NoError(t, err)
+		require.Len(t, msg.Blocks, 3)
+
+		var text string
+		for _, block := range msg.Blocks {
+			assert.LessOrEqual(t, len(block.Text.Text), blockkit.MaxSectionTextLength)
+			text += block.Text.Text
+		}
+
+		assert.Equal(t, alert.Text, text)
+		assert.Equal(t, strings.Repeat("a", 2000)+"\n", msg.Blocks[0].Text.Text)
+	})
+
+	t.Run("fields should be split into sections of ten", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = "Header"
+		for range 15 {
+			alert.Fields = append(alert.Fields, &types.Field{Title: "title", Value: "value"})
+		}
+
+		msg, err := blockkit.Render(alert, blockkit.StateOpen)
+		require.NoError(t, err)
+		require.Len(t, msg.Blocks, 3)
+		assert.Len(t, msg.Blocks[1].Fields, blockkit.MaxSectionFieldCount)
+		assert.Len(t, msg.Blocks[2].Fields, 5)
+		assert.Equal(t, "*title*\nvalue", msg.Blocks[1].Fields[0].Text)
+	})
+
+	t.Run("long header should be truncated", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = strings.Repeat("x", 200)
+
+		msg, err := blockkit.Render(alert, blockkit.StateOpen)
+		require.NoError(t, err)
+		assert.Len(t, []rune(msg.Blocks[0].Text.Text), blockkit.MaxHeaderTextLength)
+		assert.True(t, strings.HasSuffix(msg.Blocks[0].Text.Text, "..."))
+	})
+
+	t.Run("message should be capped at the max block count", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Text = strings.Repeat("x", blockkit.MaxSectionTextLength*(blockkit.MaxBlocks+5))
+
+		msg, err := blockkit.Render(alert, blockkit.StateOpen)
+		require.NoError(t, err)
+		assert.Len(t, msg.Blocks, blockkit.MaxBlocks)
+	})
+
+	t.Run("custom options should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewWarningAlert()
+		alert.Header = "Header"
+
+		opts := blockkit.DefaultOptions()
+		opts.StatusEmojis[types.AlertWarning] = ":eyes:"
+
+		msg, err := blockkit.RenderWith(alert, blockkit.StateOpen, opts)
+		require.NoError(t, err)
+		assert.Equal(t, ":eyes: Header", msg.Blocks[0].Text.Text)
+
+		opts.PrefixHeader = false
+
+		msg, err = blockkit.RenderWith(alert, blockkit.StateOpen, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "Header", msg.Blocks[0].Text.Text)
+		assert.Equal(t, "Header", msg.Text)
+	})
+
+	t.Run("alert should not be modified", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = ":status: Header"
+
+		_, err := blockkit.Render(alert, blockkit.StateResolved)
+		require.NoError(t, err)
+		assert.Equal(t, ":status: Header", alert.Header)
+	})
+}
+
+func TestIssueStateIsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, state := range blockkit.ValidIssueStates() {
+		assert.True(t, blockkit.IssueStateIsValid(blockkit.IssueState(state)))
+	}
+
+	assert.False(t, blockkit.IssueStateIsValid(""))
+	assert.False(t, blockkit.IssueStateIsValid("closed"))
+}
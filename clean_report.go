@@ -0,0 +1,165 @@
+package types
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// CleanChangeKind describes the kind of change applied to an alert field by Alert.Clean.
+type CleanChangeKind string
+
+const (
+	// CleanChangeNormalized means that whitespace was trimmed, case was normalized or line breaks were replaced.
+	CleanChangeNormalized CleanChangeKind = "normalized"
+
+	// CleanChangeTruncated means that the value exceeded its maximum length and was truncated (with a '...' suffix).
+	CleanChangeTruncated CleanChangeKind = "truncated"
+
+	// CleanChangeDefaulted means that an empty value was replaced with a default value.
+	CleanChangeDefaulted CleanChangeKind = "defaulted"
+
+	// CleanChangeReplaced means that a value was replaced with a different value,
+	// such as severity 'critical' being rewritten to 'error', or a stale timestamp being replaced with the current time.
+	CleanChangeReplaced CleanChangeKind = "replaced"
+
+	// CleanChangeClamped means that a numeric value was outside its allowed range and was clamped.
+	CleanChangeClamped CleanChangeKind = "clamped"
+
+	// CleanChangeReordered means that a list was re-sorted, such as escalation points being sorted by delay.
+	CleanChangeReordered CleanChangeKind = "reordered"
+
+	// CleanChangeEscaped means that Slack control characters or mentions were escaped (see Alert.EscapeText).
+	CleanChangeEscaped CleanChangeKind = "escaped"
+)
+
+// CleanChange describes a single change applied to an alert by Alert.CleanWithReport.
+type CleanChange struct {
+	// Path is the JSON path of the changed value, such as 'header' or 'fields[3].value'.
+	Path string `json:"path"`
+
+	// Kind is the kind of change.
+	Kind CleanChangeKind `json:"kind"`
+
+	// BeforeLength is the length (in runes) of a string value before the change. It is zero for non-string values.
+	BeforeLength int `json:"beforeLength"`
+
+	// AfterLength is the length (in runes) of a string value after the change. It is zero for non-string values.
+	AfterLength int `json:"afterLength"`
+}
+
+// cleaner applies changes to alert fields and records what was changed.
+type cleaner struct {
+	limits   *Limits
+	escaping bool
+	changes  []*CleanChange
+}
+
+// newCleaner returns a cleaner using the specified limits, or the default limits if nil.
+func newCleaner(limits *Limits) *cleaner {
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	return &cleaner{limits: limits}
+}
+
+// record adds a change to the report.
+func (c *cleaner) record(path string, kind CleanChangeKind, beforeLength, afterLength int) {
+	c.changes = append(c.changes, &CleanChange{
+		Path:         path,
+		Kind:         kind,
+		BeforeLength: beforeLength,
+		AfterLength:  afterLength,
+	})
+}
+
+// apply replaces *s with fn(*s), and records a change of the given kind if the value changed.
+func (c *cleaner) apply(path string, kind CleanChangeKind, s *string, fn func(string) string) {
+	before := *s
+	*s = fn(before)
+
+	if *s != before {
+		c.record(path, kind, utf8.RuneCountInString(before), utf8.RuneCountInString(*s))
+	}
+}
+
+// normalize applies fn to *s, recording a CleanChangeNormalized change if the value changed.
+func (c *cleaner) normalize(path string, s *string, fn func(string) string) {
+	c.apply(path, CleanChangeNormalized, s, fn)
+}
+
+// truncate shortens *s to maxLength runes (including a '...' suffix), recording a CleanChangeTruncated change if needed.
+func (c *cleaner) truncate(path string, s *string, maxLength int) {
+	c.apply(path, CleanChangeTruncated, s, func(s string) string {
+		if utf8.RuneCountInString(s) <= maxLength {
+			return s
+		}
+
+		return c.shorten(s, maxLength, "...")
+	})
+}
+
+// shortenText truncates alert text at MaxTextLength, keeping a trailing code block intact.
+func (c *cleaner) shortenText(text string) string {
+	if utf8.RuneCountInString(text) <= c.limits.MaxTextLength {
+		return text
+	}
+
+	if strings.HasSuffix(text, "```") && c.limits.MaxTextLength >= 6 {
+		return c.shorten(text, c.limits.MaxTextLength, "...```")
+	}
+
+	return c.shorten(text, c.limits.MaxTextLength, "...")
+}
+
+// shorten cuts s to maxLength runes including the suffix. If maxLength is too small to hold the suffix,
+// s is cut to maxLength runes without the suffix.
+func (c *cleaner) shorten(s string, maxLength int, suffix string) string {
+	if maxLength < len(suffix) {
+		return c.cut(s, maxLength)
+	}
+
+	return strings.TrimSpace(c.cut(s, maxLength-len(suffix))) + suffix
+}
+
+// cut truncates s to maxRunes runes (or to an empty string if maxRunes is negative). When escaping, a partial entity at the end (such as '&l' from '&lt;') is removed.
+func (c *cleaner) cut(s string, maxRunes int) string {
+	s = truncateString(s, maxRunes)
+
+	if !c.escaping {
+		return s
+	}
+
+	if i := strings.LastIndexByte(s, '&'); i >= 0 && !strings.Contains(s[i:], ";") {
+		return s[:i]
+	}
+
+	return s
+}
+
+// escape escapes *s for Slack if escaping is enabled, recording a CleanChangeEscaped change if the value changed.
+func (c *cleaner) escape(path string, s *string) {
+	if c.escaping {
+		c.apply(path, CleanChangeEscaped, s, EscapeSlackText)
+	}
+}
+
+// clampNegative sets *i to zero if it is negative, recording a CleanChangeClamped change if needed.
+func (c *cleaner) clampNegative(path string, i *int) {
+	if *i < 0 {
+		*i = 0
+		c.record(path, CleanChangeClamped, 0, 0)
+	}
+}
+
+func trimLower(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func trimUpper(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+func trimSingleLine(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", " ")
+}
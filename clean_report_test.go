@@ -0,0 +1,120 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanWithReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clean alert should produce an empty report", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.NewErrorAlert()
+		a.Header = "header"
+		a.Text = "text"
+		a.SlackChannelID = "C12345678"
+
+		assert.Empty(t, a.CleanWithReport())
+	})
+
+	t.Run("report should describe every change", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Timestamp:             time.Now().Add(-types.MaxTimestampAge - time.Hour),
+			Header:                "  " + strings.Repeat("h", types.MaxHeaderLength+10),
+			Text:                  "text",
+			Severity:              "CRITICAL",
+			ArchivingDelaySeconds: -1,
+			Fields: []*types.Field{
+				nil,
+				{Title: "title", Value: strings.Repeat("v", types.MaxFieldValueLength+1)},
+			},
+			Webhooks: []*types.Webhook{
+				{ID: " foo ", ButtonStyle: "default"},
+			},
+			Escalation: []*types.Escalation{
+				{DelaySeconds: 120, Severity: "panic"},
+				{DelaySeconds: 60, Severity: " WARNING "},
+			},
+		}
+
+		changes := a.CleanWithReport()
+
+		type change struct {
+			path string
+			kind types.CleanChangeKind
+		}
+
+		actual := make([]change, 0, len(changes))
+		for _, c := range changes {
+			actual = append(actual, change{c.Path, c.Kind})
+		}
+
+		assert.Equal(t, []change{
+			{"timestamp", types.CleanChangeReplaced},
+			{"header", types.CleanChangeNormalized},
+			{"severity", types.CleanChangeNormalized},
+			{"severity", types.CleanChangeReplaced},
+			{"archivingDelaySeconds", types.CleanChangeClamped},
+			{"header", types.CleanChangeTruncated},
+			{"fields[1].value", types.CleanChangeTruncated},
+			{"webhooks[0].id", types.CleanChangeNormalized},
+			{"webhooks[0].buttonStyle", types.CleanChangeNormalized},
+			{"escalation", types.CleanChangeReordered},
+			{"escalation[0].severity", types.CleanChangeNormalized},
+		}, actual)
+
+		require.Len(t, changes, 11)
+		assert.Equal(t, types.MaxHeaderLength+12, changes[1].BeforeLength)
+		assert.Equal(t, types.MaxHeaderLength+10, changes[1].AfterLength)
+		assert.Equal(t, types.MaxHeaderLength+10, changes[5].BeforeLength)
+		assert.Equal(t, types.MaxHeaderLength, changes[5].AfterLength)
+		assert.Equal(t, types.MaxFieldValueLength+1, changes[6].BeforeLength)
+		assert.Equal(t, types.MaxFieldValueLength, changes[6].AfterLength)
+	})
+
+	t.Run("empty severity should be reported as defaulted", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.NewAlert("")
+		a.Header = "header"
+
+		changes := a.CleanWithReport()
+		require.Len(t, changes, 1)
+		assert.Equal(t, "severity", changes[0].Path)
+		assert.Equal(t, types.CleanChangeDefaulted, changes[0].Kind)
+	})
+
+	t.Run("clean and clean with report should produce the same result", func(t *testing.T) {
+		t.Parallel()
+
+		timestamp := time.Now()
+
+		newAlert := func() *types.Alert {
+			return &types.Alert{
+				Timestamp:    timestamp,
+				Header:       " Foo\nbar ",
+				Text:         strings.Repeat("x", types.MaxTextLength) + "```",
+				FallbackText: " :status: fallback ",
+				IconEmoji:    " :FOO: ",
+				Fields:       []*types.Field{{Title: strings.Repeat("t", types.MaxFieldTitleLength+1)}},
+			}
+		}
+
+		a1 := newAlert()
+		a1.Clean()
+
+		a2 := newAlert()
+		a2.CleanWithReport()
+
+		assert.Equal(t, a1, a2)
+	})
+}
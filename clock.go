@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// Clock provides the current time, so that time-dependent logic (such as EvaluateEscalation) is deterministic in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns the result of calling f.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock returns a Clock reporting the current system time.
+func SystemClock() Clock {
+	return ClockFunc(time.Now)
+}
+
+// FixedClock returns a Clock that always reports t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
@@ -0,0 +1,33 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("system clock should report the current time", func(t *testing.T) {
+		t.Parallel()
+
+		before := time.Now()
+		now := types.SystemClock().Now()
+
+		assert.False(t, now.Before(before))
+		assert.False(t, now.After(time.Now()))
+	})
+
+	t.Run("fixed clock should report the same time", func(t *testing.T) {
+		t.Parallel()
+
+		fixed := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+		clock := types.FixedClock(fixed)
+
+		assert.Equal(t, fixed, clock.Now())
+		assert.Equal(t, fixed, clock.Now())
+	})
+}
@@ -0,0 +1,326 @@
+// Package cloudwatch converts AWS CloudWatch alarm notifications, delivered as SNS messages, to Slack Manager alerts.
+//
+// Usage in an HTTP handler subscribed to the SNS topic:
+//
+//	envelope, err := cloudwatch.Decode(r.Body)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//
+//	alarm, err := envelope.Alarm()
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//
+//	alerts, err := cloudwatch.Convert(alarm, cloudwatch.DefaultConfig())
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//
+//	for _, alert := range alerts {
+//	    alert.Clean()
+//	    if err := alert.Validate(); err != nil {
+//	        // handle error
+//	    }
+//	}
+//
+// Note that this package does not verify SNS message signatures, or confirm SNS subscriptions.
+package cloudwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/mapping"
+)
+
+// SNS message types.
+const (
+	SNSTypeNotification             = "Notification"
+	SNSTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// Alarm states.
+const (
+	StateAlarm            = "ALARM"
+	StateOK               = "OK"
+	StateInsufficientData = "INSUFFICIENT_DATA"
+)
+
+// stateChangeTimeLayout is the layout of Alarm.StateChangeTime, such as '2026-03-01T10:00:00.000+0000'.
+const stateChangeTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// ErrNotNotification is returned by Envelope.Alarm when the SNS message is not a notification,
+// such as a subscription confirmation.
+var ErrNotNotification = errors.New("SNS message is not a notification")
+
+// Envelope is an SNS message, as posted to HTTP(S) subscribers.
+// See https://docs.aws.amazon.com/sns/latest/dg/sns-message-and-json-formats.html
+type Envelope struct {
+	Type             string    `json:"Type"`
+	MessageID        string    `json:"MessageId"`
+	Token            string    `json:"Token,omitempty"`
+	TopicArn         string    `json:"TopicArn"`
+	Subject          string    `json:"Subject,omitempty"`
+	Message          string    `json:"Message"`
+	Timestamp        time.Time `json:"Timestamp"`
+	SignatureVersion string    `json:"SignatureVersion"`
+	Signature        string    `json:"Signature"`
+	SigningCertURL   string    `json:"SigningCertURL"`
+	SubscribeURL     string    `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL   string    `json:"UnsubscribeURL,omitempty"`
+}
+
+// Alarm is a CloudWatch alarm state change notification (the SNS message body).
+type Alarm struct {
+	AlarmName        string   `json:"AlarmName"`
+	AlarmDescription string   `json:"AlarmDescription"`
+	AWSAccountID     string   `json:"AWSAccountId"`
+	NewStateValue    string   `json:"NewStateValue"`
+	NewStateReason   string   `json:"NewStateReason"`
+	StateChangeTime  string   `json:"StateChangeTime"`
+	Region           string   `json:"Region"`
+	AlarmArn         string   `json:"AlarmArn"`
+	OldStateValue    string   `json:"OldStateValue"`
+	Trigger          *Trigger `json:"Trigger"`
+}
+
+// Trigger describes the metric and condition of a CloudWatch alarm.
+type Trigger struct {
+	MetricName         string       `json:"MetricName"`
+	Namespace          string       `json:"Namespace"`
+	StatisticType      string       `json:"StatisticType"`
+	Statistic          string       `json:"Statistic"`
+	Unit               string       `json:"Unit"`
+	Dimensions         []*Dimension `json:"Dimensions"`
+	Period             int          `json:"Period"`
+	EvaluationPeriods  int          `json:"EvaluationPeriods"`
+	ComparisonOperator string       `json:"ComparisonOperator"`
+	Threshold          float64      `json:"Threshold"`
+	TreatMissingData   string       `json:"TreatMissingData"`
+}
+
+// Dimension is a CloudWatch metric dimension.
+type Dimension struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Decode decodes an SNS message envelope.
+func Decode(r io.Reader) (*Envelope, error) {
+	var envelope Envelope
+
+	if err := json.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode SNS message: %w", err)
+	}
+
+	return &envelope, nil
+}
+
+// Alarm decodes the CloudWatch alarm in the SNS message body.
+// ErrNotNotification is returned if the SNS message is not a notification.
+func (e *Envelope) Alarm() (*Alarm, error) {
+	if e.Type != SNSTypeNotification {
+		return nil, fmt.Errorf("%w (type '%s')", ErrNotNotification, e.Type)
+	}
+
+	alarm, err := DecodeAlarm(strings.NewReader(e.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	return alarm, nil
+}
+
+// DecodeAlarm decodes a CloudWatch alarm notification, e.g. from an SNS message body (or a raw message delivery).
+func DecodeAlarm(r io.Reader) (*Alarm, error) {
+	var alarm Alarm
+
+	if err := json.NewDecoder(r).Decode(&alarm); err != nil {
+		return nil, fmt.Errorf("failed to decode CloudWatch alarm: %w", err)
+	}
+
+	if alarm.AlarmName == "" || alarm.NewStateValue == "" {
+		return nil, errors.New("failed to decode CloudWatch alarm: AlarmName and NewStateValue are required")
+	}
+
+	return &alarm, nil
+}
+
+// Time returns the parsed StateChangeTime, or the zero time if it cannot be parsed.
+func (a *Alarm) Time() time.Time {
+	t, err := time.Parse(stateChangeTimeLayout, a.StateChangeTime)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t.UTC()
+}
+
+// RegionCode returns the region code (such as 'eu-west-1') from the alarm ARN, or an empty string if not available.
+// The Region field holds the region display name (such as 'EU (Ireland)').
+func (a *Alarm) RegionCode() string {
+	// arn:aws:cloudwatch:<region>:<account>:alarm:<name>
+	parts := strings.SplitN(a.AlarmArn, ":", 7)
+	if len(parts) < 7 {
+		return ""
+	}
+
+	return parts[3]
+}
+
+// Config defines how CloudWatch alarms are mapped to Slack Manager alerts. Use DefaultConfig to get the default mapping.
+type Config struct {
+	// SlackChannelID is the Slack channel ID or name used for all alerts.
+	SlackChannelID string
+
+	// RouteKey is the route key used for all alerts. Ignored if SlackChannelID is set.
+	RouteKey string
+
+	// AlarmSeverity is the severity used for alarms in the ALARM state.
+	AlarmSeverity types.AlertSeverity
+
+	// AlarmSeverities overrides AlarmSeverity for specific alarm names, e.g. to map critical alarms to panic.
+	AlarmSeverities map[string]types.AlertSeverity
+
+	// InsufficientDataSeverity is the severity used for alarms in the INSUFFICIENT_DATA state.
+	// If empty, such alarms are ignored (Convert returns no alerts).
+	InsufficientDataSeverity types.AlertSeverity
+
+	// ConsoleLink sets the alert link to the alarm in the AWS console.
+	ConsoleLink bool
+
+	// AutoResolve is the duration after which an issue is auto-resolved, if no OK notification is received.
+	AutoResolve time.Duration
+
+	// DisableIssueFollowUp disables issue follow-up, so OK notifications are posted as separate messages.
+	DisableIssueFollowUp bool
+
+	// Customize is called for each converted alert, and can modify the result further. Ignored if nil.
+	Customize func(alert *types.Alert, alarm *Alarm)
+}
+
+// DefaultConfig returns a new Config instance with the default mapping: ALARM maps to error, OK to resolved,
+// INSUFFICIENT_DATA to warning, and the alert links to the alarm in the AWS console.
+func DefaultConfig() *Config {
+	return &Config{
+		AlarmSeverity:            types.AlertError,
+		InsufficientDataSeverity: types.AlertWarning,
+		ConsoleLink:              true,
+		AutoResolve:              24 * time.Hour,
+	}
+}
+
+// Convert converts the CloudWatch alarm to a Slack Manager alert, using the specified config (or the default config if nil).
+// The result has zero alerts if the alarm state is ignored (see Config.InsufficientDataSeverity), and one alert otherwise.
+// The returned alert is not cleaned or validated. An error is returned if the alarm state is not one of the known states.
+func Convert(alarm *Alarm, cfg *Config) ([]*types.Alert, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	severity, err := alarmSeverity(alarm, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if severity == "" {
+		return nil, nil
+	}
+
+	alert := types.NewAlert(severity)
+
+	alert.CorrelationID = alarm.AlarmArn
+	alert.Header = alarm.AlarmName
+	alert.Text = alarm.NewStateReason
+	alert.SlackChannelID = cfg.SlackChannelID
+	alert.RouteKey = cfg.RouteKey
+	alert.IssueFollowUpEnabled = !cfg.DisableIssueFollowUp
+
+	if alarm.AlarmDescription != "" {
+		alert.Text = alarm.AlarmDescription + "\n\n" + alarm.NewStateReason
+	}
+
+	if alert.IssueFollowUpEnabled {
+		alert.AutoResolveSeconds = int(cfg.AutoResolve / time.Second)
+	}
+
+	if t := alarm.Time(); !t.IsZero() {
+		alert.Timestamp = t
+	}
+
+	if region := alarm.RegionCode(); cfg.ConsoleLink && region != "" {
+		alert.Link = fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#alarmsV2:alarm/%s", region, region, url.PathEscape(alarm.AlarmName))
+	}
+
+	addFields(alert, alarm)
+
+	alert.Metadata["alarmArn"] = alarm.AlarmArn
+	alert.Metadata["newStateValue"] = alarm.NewStateValue
+	alert.Metadata["oldStateValue"] = alarm.OldStateValue
+
+	if cfg.Customize != nil {
+		cfg.Customize(alert, alarm)
+	}
+
+	return []*types.Alert{alert}, nil
+}
+
+func alarmSeverity(alarm *Alarm, cfg *Config) (types.AlertSeverity, error) {
+	switch alarm.NewStateValue {
+	case StateOK:
+		return types.AlertResolved, nil
+	case StateInsufficientData:
+		return cfg.InsufficientDataSeverity, nil
+	case StateAlarm:
+		if s, ok := cfg.AlarmSeverities[alarm.AlarmName]; ok {
+			return s, nil
+		}
+
+		return cfg.AlarmSeverity, nil
+	}
+
+	return "", fmt.Errorf("unsupported CloudWatch alarm state '%s', expected one of %s, %s or %s", alarm.NewStateValue, StateAlarm, StateOK, StateInsufficientData)
+}
+
+func addFields(alert *types.Alert, alarm *Alarm) {
+	if alarm.Region != "" {
+		mapping.AppendField(alert, "Region", alarm.Region)
+	}
+
+	if alarm.AWSAccountID != "" {
+		mapping.AppendField(alert, "Account", alarm.AWSAccountID)
+	}
+
+	mapping.AppendField(alert, "State", alarm.NewStateValue)
+
+	trigger := alarm.Trigger
+	if trigger == nil {
+		return
+	}
+
+	if trigger.MetricName != "" {
+		mapping.AppendField(alert, "Metric", strings.TrimPrefix(trigger.Namespace+"/"+trigger.MetricName, "/"))
+	}
+
+	if trigger.ComparisonOperator != "" {
+		mapping.AppendField(alert, "Condition", fmt.Sprintf("%s %s %s", trigger.Statistic, trigger.ComparisonOperator, strconv.FormatFloat(trigger.Threshold, 'g', -1, 64)))
+	}
+
+	for _, dimension := range trigger.Dimensions {
+		if dimension != nil {
+			mapping.AppendField(alert, dimension.Name, dimension.Value)
+		}
+	}
+}
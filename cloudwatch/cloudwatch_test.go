@@ -0,0 +1,199 @@
+package cloudwatch_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/cloudwatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestConvertGolden(t *testing.T) {
+	t.Parallel()
+
+	payloads, err := filepath.Glob("testdata/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, payloads)
+
+	for _, payload := range payloads {
+		if strings.HasSuffix(payload, ".golden.json") {
+			continue
+		}
+
+		t.Run(filepath.Base(payload), func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(payload)
+			require.NoError(t, err)
+			defer f.Close()
+
+			envelope, err := cloudwatch.Decode(f)
+			require.NoError(t, err)
+
+			alarm, err := envelope.Alarm()
+			require.NoError(t, err)
+
+			alerts, err := cloudwatch.Convert(alarm, cloudwatch.DefaultConfig())
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			require.NoError(t, enc.Encode(alerts))
+			actual := buf.Bytes()
+
+			golden := strings.TrimSuffix(payload, ".json") + ".golden.json"
+
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o600))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+
+			for _, alert := range alerts {
+				alert.Timestamp = time.Now()
+				alert.Clean()
+				require.NoError(t, alert.Validate())
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid JSON should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cloudwatch.Decode(strings.NewReader(`{"Type": `))
+		require.ErrorContains(t, err, "failed to decode SNS message")
+	})
+
+	t.Run("subscription confirmation should not be decoded as an alarm", func(t *testing.T) {
+		t.Parallel()
+
+		envelope, err := cloudwatch.Decode(strings.NewReader(`{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://example.com"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", envelope.SubscribeURL)
+
+		_, err = envelope.Alarm()
+		require.ErrorIs(t, err, cloudwatch.ErrNotNotification)
+	})
+
+	t.Run("invalid alarm JSON should fail", func(t *testing.T) {
+		t.Parallel()
+
+		envelope := &cloudwatch.Envelope{Type: cloudwatch.SNSTypeNotification, Message: "not json"}
+		_, err := envelope.Alarm()
+		require.ErrorContains(t, err, "failed to decode CloudWatch alarm")
+	})
+
+	t.Run("alarm without name or state should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cloudwatch.DecodeAlarm(strings.NewReader(`{"AlarmName": "name"}`))
+		require.ErrorContains(t, err, "AlarmName and NewStateValue are required")
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	alarm := &cloudwatch.Alarm{
+		AlarmName:     "Checkout errors",
+		NewStateValue: cloudwatch.StateAlarm,
+		AlarmArn:      "arn:aws:cloudwatch:us-east-1:123456789012:alarm:Checkout errors",
+	}
+
+	t.Run("nil config should use the default config", func(t *testing.T) {
+		t.Parallel()
+
+		alerts, err := cloudwatch.Convert(alarm, nil)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, types.AlertError, alerts[0].Severity)
+		assert.Equal(t, alarm.AlarmArn, alerts[0].CorrelationID)
+		assert.Equal(t, "https://us-east-1.console.aws.amazon.com/cloudwatch/home?region=us-east-1#alarmsV2:alarm/Checkout%20errors", alerts[0].Link)
+		assert.True(t, alerts[0].IssueFollowUpEnabled)
+		assert.Equal(t, 86400, alerts[0].AutoResolveSeconds)
+	})
+
+	t.Run("custom config should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := cloudwatch.DefaultConfig()
+		cfg.AlarmSeverities = map[string]types.AlertSeverity{"Checkout errors": types.AlertPanic}
+		cfg.SlackChannelID = "C123"
+		cfg.ConsoleLink = false
+		cfg.DisableIssueFollowUp = true
+		cfg.Customize = func(alert *types.Alert, alarm *cloudwatch.Alarm) {
+			alert.Footer = alarm.NewStateValue
+		}
+
+		alerts, err := cloudwatch.Convert(alarm, cfg)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+
+		alert := alerts[0]
+		assert.Equal(t, types.AlertPanic, alert.Severity)
+		assert.Equal(t, "C123", alert.SlackChannelID)
+		assert.Empty(t, alert.Link)
+		assert.False(t, alert.IssueFollowUpEnabled)
+		assert.Zero(t, alert.AutoResolveSeconds)
+		assert.Equal(t, "ALARM", alert.Footer)
+	})
+
+	t.Run("insufficient data should be ignored when configured", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := cloudwatch.DefaultConfig()
+		cfg.InsufficientDataSeverity = ""
+
+		alerts, err := cloudwatch.Convert(&cloudwatch.Alarm{AlarmName: "name", NewStateValue: cloudwatch.StateInsufficientData}, cfg)
+		require.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("OK should map to resolved", func(t *testing.T) {
+		t.Parallel()
+
+		alerts, err := cloudwatch.Convert(&cloudwatch.Alarm{AlarmName: "name", NewStateValue: cloudwatch.StateOK}, nil)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, types.AlertResolved, alerts[0].Severity)
+		assert.Empty(t, alerts[0].Link)
+	})
+
+	t.Run("unknown states should fail", func(t *testing.T) {
+		t.Parallel()
+
+		for _, state := range []string{"", "alarm", "UNKNOWN"} {
+			alerts, err := cloudwatch.Convert(&cloudwatch.Alarm{AlarmName: "name", NewStateValue: state}, nil)
+			require.ErrorContains(t, err, "unsupported CloudWatch alarm state '"+state+"'")
+			assert.Nil(t, alerts)
+		}
+	})
+}
+
+func TestAlarmTime(t *testing.T) {
+	t.Parallel()
+
+	alarm := &cloudwatch.Alarm{StateChangeTime: "2026-03-01T10:00:00.000+0100"}
+	assert.Equal(t, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), alarm.Time())
+
+	alarm.StateChangeTime = "invalid"
+	assert.True(t, alarm.Time().IsZero())
+}
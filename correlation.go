@@ -0,0 +1,169 @@
+package types
+
+import (
+	"fmt"
+)
+
+// CorrelationField is an alert field that can be included in a hashed correlation ID, see HashCorrelationStrategy.
+type CorrelationField string
+
+const (
+	// CorrelationFieldHeader represents the alert Header.
+	CorrelationFieldHeader CorrelationField = "header"
+
+	// CorrelationFieldText represents the alert Text.
+	CorrelationFieldText CorrelationField = "text"
+
+	// CorrelationFieldAuthor represents the alert Author.
+	CorrelationFieldAuthor CorrelationField = "author"
+
+	// CorrelationFieldHost represents the alert Host.
+	CorrelationFieldHost CorrelationField = "host"
+
+	// CorrelationFieldSlackChannelID represents the alert SlackChannelID.
+	CorrelationFieldSlackChannelID CorrelationField = "slackChannelId"
+
+	// CorrelationFieldRouteKey represents the alert RouteKey.
+	CorrelationFieldRouteKey CorrelationField = "routeKey"
+
+	// CorrelationFieldType represents the alert Type.
+	CorrelationFieldType CorrelationField = "type"
+)
+
+// CorrelationFieldIsValid returns true if the provided CorrelationField is valid.
+func CorrelationFieldIsValid(f CorrelationField) bool {
+	switch f {
+	case CorrelationFieldHeader, CorrelationFieldText, CorrelationFieldAuthor, CorrelationFieldHost,
+		CorrelationFieldSlackChannelID, CorrelationFieldRouteKey, CorrelationFieldType:
+		return true
+	}
+	return false
+}
+
+// ValidCorrelationFields returns a slice of valid CorrelationField values.
+func ValidCorrelationFields() []string {
+	return []string{
+		string(CorrelationFieldHeader),
+		string(CorrelationFieldText),
+		string(CorrelationFieldAuthor),
+		string(CorrelationFieldHost),
+		string(CorrelationFieldSlackChannelID),
+		string(CorrelationFieldRouteKey),
+		string(CorrelationFieldType),
+	}
+}
+
+// CorrelationStrategy computes the correlation ID for alerts without an explicit CorrelationID.
+// Producers, the Slack Manager API and DB plugins using the same strategy compute identical correlation IDs.
+type CorrelationStrategy interface {
+	// CorrelationID returns the correlation ID for the alert.
+	CorrelationID(a *Alert) string
+}
+
+// CorrelationStrategyFunc is a function implementing CorrelationStrategy.
+type CorrelationStrategyFunc func(a *Alert) string
+
+// CorrelationID returns f(a).
+func (f CorrelationStrategyFunc) CorrelationID(a *Alert) string {
+	return f(a)
+}
+
+// DefaultCorrelationStrategy returns the strategy used by the Slack Manager when an alert has no CorrelationID,
+// i.e. a hash of [Header, Text, Author, Host, SlackChannelID].
+func DefaultCorrelationStrategy() CorrelationStrategyFunc {
+	return HashCorrelationStrategy(CorrelationFieldHeader, CorrelationFieldText, CorrelationFieldAuthor, CorrelationFieldHost, CorrelationFieldSlackChannelID)
+}
+
+// HashCorrelationStrategy returns a strategy computing the correlation ID as a hash of the specified fields, in the specified order.
+// It panics if a field is not valid (see ValidCorrelationFields).
+func HashCorrelationStrategy(fields ...CorrelationField) CorrelationStrategyFunc {
+	for _, field := range fields {
+		if !CorrelationFieldIsValid(field) {
+			panic(fmt.Sprintf("invalid correlation field '%s'", field))
+		}
+	}
+
+	return func(a *Alert) string {
+		values := make([]string, len(fields))
+
+		for i, field := range fields {
+			values[i] = correlationFieldValue(a, field)
+		}
+
+		return hash(values...)
+	}
+}
+
+// TypeAndHostCorrelationStrategy returns a strategy computing the correlation ID as a hash of [Type, Host],
+// grouping all alerts of the same type from the same host in one issue.
+func TypeAndHostCorrelationStrategy() CorrelationStrategyFunc {
+	return HashCorrelationStrategy(CorrelationFieldType, CorrelationFieldHost)
+}
+
+// MetadataCorrelationStrategy returns a strategy using the Metadata value with the specified key as the correlation ID.
+// Values longer than MaxCorrelationIDLength are hashed. If the key is missing (or the value is empty),
+// the fallback strategy is used, or the default strategy if fallback is nil.
+func MetadataCorrelationStrategy(key string, fallback CorrelationStrategy) CorrelationStrategyFunc {
+	if fallback == nil {
+		fallback = DefaultCorrelationStrategy()
+	}
+
+	return func(a *Alert) string {
+		value, ok := a.Metadata[key]
+		if !ok || value == nil {
+			return fallback.CorrelationID(a)
+		}
+
+		s := fmt.Sprint(value)
+
+		switch {
+		case s == "":
+			return fallback.CorrelationID(a)
+		case len(s) > MaxCorrelationIDLength:
+			return hash(s)
+		default:
+			return s
+		}
+	}
+}
+
+// DefaultCorrelationID returns the correlation ID computed by the Slack Manager when the alert has no CorrelationID,
+// i.e. a hash of [Header, Text, Author, Host, SlackChannelID]. Call Clean first to get the same result as the Slack Manager.
+func (a *Alert) DefaultCorrelationID() string {
+	return DefaultCorrelationStrategy().CorrelationID(a)
+}
+
+// EffectiveCorrelationID returns the alert CorrelationID if set, and otherwise the ID computed by the specified strategy
+// (or the default strategy if nil). This is the ID used to group the alert into an issue.
+func (a *Alert) EffectiveCorrelationID(strategy CorrelationStrategy) string {
+	if a.CorrelationID != "" {
+		return a.CorrelationID
+	}
+
+	if strategy == nil {
+		strategy = DefaultCorrelationStrategy()
+	}
+
+	return strategy.CorrelationID(a)
+}
+
+func correlationFieldValue(a *Alert, field CorrelationField) string {
+	switch field {
+	case CorrelationFieldHeader:
+		return a.Header
+	case CorrelationFieldText:
+		return a.Text
+	case CorrelationFieldAuthor:
+		return a.Author
+	case CorrelationFieldHost:
+		return a.Host
+	case CorrelationFieldSlackChannelID:
+		return a.SlackChannelID
+	case CorrelationFieldRouteKey:
+		return a.RouteKey
+	case CorrelationFieldType:
+		return a.Type
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,148 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelationFieldValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range types.ValidCorrelationFields() {
+		assert.True(t, types.CorrelationFieldIsValid(types.CorrelationField(f)))
+	}
+
+	assert.False(t, types.CorrelationFieldIsValid("footer"))
+	assert.False(t, types.CorrelationFieldIsValid(""))
+}
+
+func TestDefaultCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{
+		Header:         "header",
+		Text:           "text",
+		Author:         "author",
+		Host:           "host",
+		SlackChannelID: "C12345678",
+		Footer:         "footer",
+	}
+
+	expected := hash("header", "text", "author", "host", "C12345678")
+	assert.Equal(t, expected, a.DefaultCorrelationID())
+	assert.Equal(t, expected, types.DefaultCorrelationStrategy().CorrelationID(a))
+	assert.LessOrEqual(t, len(expected), types.MaxCorrelationIDLength)
+
+	// Fields outside the hash should not affect the ID
+	a.Footer = "other footer"
+	a.CorrelationID = "explicit"
+	assert.Equal(t, expected, a.DefaultCorrelationID())
+
+	// Fields in the hash should
+	a.Host = "other host"
+	assert.NotEqual(t, expected, a.DefaultCorrelationID())
+}
+
+func TestCorrelationStrategies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hash strategy should hash the selected fields in order", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Type: "compliance", Host: "host", RouteKey: "route", Header: "header"}
+
+		strategy := types.HashCorrelationStrategy(types.CorrelationFieldRouteKey, types.CorrelationFieldType)
+		assert.Equal(t, hash("route", "compliance"), strategy.CorrelationID(a))
+
+		assert.Equal(t, hash("compliance", "host"), types.TypeAndHostCorrelationStrategy().CorrelationID(a))
+
+		a.Header = "other header"
+		assert.Equal(t, hash("compliance", "host"), types.TypeAndHostCorrelationStrategy().CorrelationID(a))
+	})
+
+	t.Run("hash strategy should panic on invalid fields", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			types.HashCorrelationStrategy(types.CorrelationFieldHost, "footer")
+		})
+	})
+
+	t.Run("metadata strategy should use the metadata value", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := types.MetadataCorrelationStrategy("incident", nil)
+
+		a := &types.Alert{Header: "header", Metadata: map[string]any{"incident": "INC-123"}}
+		assert.Equal(t, "INC-123", strategy.CorrelationID(a))
+
+		a.Metadata["incident"] = 42
+		assert.Equal(t, "42", strategy.CorrelationID(a))
+
+		long := strings.Repeat("x", types.MaxCorrelationIDLength+1)
+		a.Metadata["incident"] = long
+		assert.Equal(t, hash(long), strategy.CorrelationID(a))
+	})
+
+	t.Run("metadata strategy should use the fallback when the key is missing", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "header", Type: "type", Host: "host", Metadata: map[string]any{"incident": ""}}
+
+		assert.Equal(t, a.DefaultCorrelationID(), types.MetadataCorrelationStrategy("incident", nil).CorrelationID(a))
+		assert.Equal(t, a.DefaultCorrelationID(), types.MetadataCorrelationStrategy("missing", nil).CorrelationID(a))
+		assert.Equal(t, hash("type", "host"), types.MetadataCorrelationStrategy("missing", types.TypeAndHostCorrelationStrategy()).CorrelationID(a))
+
+		a.Metadata = nil
+		assert.Equal(t, a.DefaultCorrelationID(), types.MetadataCorrelationStrategy("incident", nil).CorrelationID(a))
+	})
+
+	t.Run("custom strategies should be supported", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := types.CorrelationStrategyFunc(func(a *types.Alert) string {
+			return "custom-" + a.Host
+		})
+
+		a := &types.Alert{Host: "host"}
+		assert.Equal(t, "custom-host", a.EffectiveCorrelationID(strategy))
+	})
+}
+
+func TestEffectiveCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{Header: "header", Type: "type", Host: "host"}
+	assert.Equal(t, a.DefaultCorrelationID(), a.EffectiveCorrelationID(nil))
+	assert.Equal(t, hash("type", "host"), a.EffectiveCorrelationID(types.TypeAndHostCorrelationStrategy()))
+
+	a.CorrelationID = "explicit"
+	assert.Equal(t, "explicit", a.EffectiveCorrelationID(nil))
+	assert.Equal(t, "explicit", a.EffectiveCorrelationID(types.TypeAndHostCorrelationStrategy()))
+}
+
+func TestAlertBuilderCorrelationStrategy(t *testing.T) {
+	t.Parallel()
+
+	alert, err := types.NewAlertBuilder(types.AlertError).
+		WithHeader("  header  ").
+		WithHost("host").
+		WithCorrelationStrategy(types.DefaultCorrelationStrategy()).
+		Build()
+	require.NoError(t, err)
+
+	// The strategy is applied to the cleaned values
+	assert.Equal(t, hash("header", "", "", "host", ""), alert.CorrelationID)
+
+	alert, err = types.NewAlertBuilder(types.AlertError).
+		WithHeader("header").
+		WithCorrelationID("explicit").
+		WithCorrelationStrategy(types.DefaultCorrelationStrategy()).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", alert.CorrelationID)
+}
@@ -40,15 +40,80 @@
 //   - WebhookButtonStyle - Visual style (primary, danger)
 //   - WebhookDisplayMode - When to show buttons (always, open_issue, resolved_issue)
 //
+// Callbacks for webhooks with a custom handler identifier as URL can be routed with a WebhookMux, which dispatches
+// them to WebhookHandlers by identifier or prefix pattern, through middleware such as WebhookLogging, WebhookMetrics
+// and WebhookAccess.
+//
+// Callbacks for webhooks with an http or https URL can be delivered with a WebhookDispatcher, which POSTs them as
+// JSON signed with SignWebhookPayload, and retries network errors and 5xx responses with exponential backoff.
+// Receivers verify the signature and timestamp with VerifyWebhookCallback, or the VerifyWebhookSignature HTTP middleware,
+// or with a WebhookVerifier configured with a Clock and timestamp tolerance.
+//
 // # Validation and Cleaning
 //
 // Alert provides extensive validation and cleaning methods:
 //
+//   - Render() - Renders Go templates in the header, text, footer and field values (call before Clean)
 //   - Clean() - Normalizes and truncates all fields to valid values
+//   - CleanWithReport() - Same as Clean, but returns a CleanChange for every value that was modified
 //   - Validate() - Returns error if any field is invalid
+//   - ValidateAll() - Returns all validation failures as ValidationErrors, each with a JSON path and code
 //   - Individual validation methods for specific fields (ValidateSlackChannelIDAndRouteKey, etc.)
 //
+// Alerts without a CorrelationID are grouped by DefaultCorrelationID(), a hash of the header, text, author, host and
+// channel. Other grouping rules can be expressed as a CorrelationStrategy, see EffectiveCorrelationID.
+//
 // The package defines comprehensive constants for maximum lengths and limits (e.g., MaxHeaderLength = 130).
+// To apply different limits (e.g. per tenant), adjust the Limits returned by DefaultLimits() and use
+// CleanWith(limits), ValidateWith(limits) or ValidateAllWith(limits).
+//
+// An escalation point may have a Schedule (business hours in a time zone, with holidays), which defers the escalation
+// to the next window or replaces its mentions outside the windows. EvaluateEscalation decides what should happen to an
+// escalation point at the time reported by a Clock.
+// With RepeatSeconds and MaxRepeats, an escalation point repeats its mentions until a later escalation point fires;
+// Alert.EscalationReminders lists the future reminders.
+//
+// Instead of its own escalation points, an alert may reference a named EscalationPolicy with EscalationPolicy.
+// Policies are held by an EscalationPolicyRegistry, and ResolveEscalationPolicy replaces the reference with the
+// policy's escalation points when the alert is ingested.
+//
+// Escalation mentions may be users, user groups, @here, @channel or email addresses (see ParseMention).
+// ResolveMentions replaces email addresses with user mentions, using a MentionResolver.
+//
+// Set EscapeText on the alert (or in the Limits) to make Clean escape free text for Slack, so that only
+// Escalation.SlackMentions can notify anyone.
+//
+// EncodeAlert writes an alert in the current wire format, and DecodeAlert upgrades payloads in any supported version
+// (payloads without a schemaVersion property are version 1). EncodeAlertVersion writes older versions.
+//
+// AlertBatch decodes a JSON array of alerts, tolerating malformed entries, and CleanAndValidate returns a result
+// per index (accepted, rejected with validation errors, or duplicate), so that partial batches can be accepted.
+//
+// Diff(old, new) returns the changes between two alerts by field path, and AlertDiff.HasVisibleChanges reports
+// whether any of them affect the Slack post.
+//
+// A Redactor replaces secrets (such as AWS keys, tokens and passwords in URLs) in the free text of an alert,
+// including metadata and webhook payloads. Use DefaultRedactor().Redact(alert) before Clean.
+//
+// AlertJSONSchema() and WebhookCallbackJSONSchema() return JSON Schema documents describing the same rules,
+// for producers written in other languages.
+//
+// # Converters
+//
+// The alertmanager and grafana subpackages convert Prometheus Alertmanager and Grafana webhook payloads to alerts.
+// The cloudwatch subpackage converts AWS CloudWatch alarm notifications delivered by SNS.
+// The pagerduty subpackage converts between PagerDuty Events API v2 events and alerts, in both directions.
+// The mapping subpackage holds the label and annotation mapping rules shared by the label-based converters.
+//
+// # Block Kit Preview
+//
+// The blockkit subpackage renders an alert as the Slack Block Kit message posted for an open, escalated or resolved issue,
+// for previews and snapshot tests of alert designs.
+//
+// # Protocol Buffers
+//
+// The typespb subpackage holds Protocol Buffers definitions for Alert, WebhookCallback and ChannelProcessingState,
+// with conversion functions to and from the types in this package, for compact binary encoding on queues and gRPC ingest.
 //
 // # Testing Utilities
 //
@@ -99,4 +164,19 @@
 //	if err := alert.Validate(); err != nil {
 //	    panic(err)
 //	}
+//
+// The same alert can be built with AlertBuilder, where Build cleans and validates the alert:
+//
+//	alert, err := types.NewAlertBuilder(types.AlertError).
+//	    WithHeader("Database Connection Failed").
+//	    WithText("Unable to connect to production database").
+//	    WithSlackChannelID("C12345678").
+//	    AutoResolveAfter(5 * time.Minute).
+//	    WithField("Host", "db-prod-01").
+//	    WithField("Port", "5432").
+//	    EscalateAfter(5*time.Minute, types.AlertPanic, "<!here>").
+//	    WithWebhook(types.NewWebhookBuilder("restart", "https://example.com/webhook/restart", "Restart DB").
+//	        WithButtonStyle(types.WebhookButtonStyleDanger).
+//	        WithAccessLevel(types.WebhookAccessLevelChannelAdmins)).
+//	    Build()
 package types
@@ -0,0 +1,323 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// escalationPolicyIDRegex matches the characters allowed in an escalation policy ID.
+var escalationPolicyIDRegex = regexp.MustCompile(`^[0-9a-zA-Z\-_.]+$`)
+
+// EscalationPolicy is a named, reusable list of escalation points. Alerts reference a policy by ID with
+// Alert.EscalationPolicy, so that mentions can be changed in one place instead of in every alert producer.
+type EscalationPolicy struct {
+	// ID is the unique name of the policy, referenced by Alert.EscalationPolicy.
+	// Letters, digits, '-', '_' and '.' are allowed, up to MaxEscalationPolicyIDLength characters.
+	ID string `json:"id"`
+
+	// Steps are the escalation points of the policy, sorted by DelaySeconds.
+	// The same limits apply as for Alert.Escalation (see ValidateEscalation).
+	Steps []*Escalation `json:"steps"`
+
+	// RepeatSeconds and MaxRepeats are the repeat rules for steps without their own RepeatSeconds.
+	// See Escalation.RepeatSeconds.
+	RepeatSeconds int `json:"repeatSeconds"`
+	MaxRepeats    int `json:"maxRepeats"`
+}
+
+// Escalation returns a copy of the policy steps, with the policy repeat rules applied to steps without their own
+// RepeatSeconds. Nil steps are kept, so that they are reported by ValidateEscalation.
+func (p *EscalationPolicy) Escalation() []*Escalation {
+	steps := make([]*Escalation, len(p.Steps))
+
+	for i, step := range p.Steps {
+		if step == nil {
+			continue
+		}
+
+		e := step.clone()
+
+		if e.RepeatSeconds == 0 && e.MaxRepeats == 0 {
+			e.RepeatSeconds = p.RepeatSeconds
+			e.MaxRepeats = p.MaxRepeats
+		}
+
+		steps[i] = e
+	}
+
+	return steps
+}
+
+// Clean trims the policy ID, and sorts and normalizes the steps like the escalation points of an alert (see Alert.Clean).
+// The registry cleans policies before validating them, in Register and Load.
+func (p *EscalationPolicy) Clean() {
+	c := newCleaner(nil)
+
+	c.normalize("id", &p.ID, strings.TrimSpace)
+	cleanEscalation(c, "steps", p.Steps)
+}
+
+// Validate returns an error if the policy ID is invalid, if the policy has no steps, or if the steps or repeat rules
+// are invalid, using the default limits. Only the first validation failure is returned, as a *ValidationError.
+// Paths are relative to the policy, such as 'steps[1].delaySeconds'.
+func (p *EscalationPolicy) Validate() error {
+	return p.ValidateWith(DefaultLimits())
+}
+
+// ValidateWith validates the policy like Validate, using the specified limits instead of the default limits.
+// If limits is nil, the default limits are used.
+func (p *EscalationPolicy) ValidateWith(limits *Limits) error {
+	v := newValidator(limits)
+	p.validate(v)
+	return v.first()
+}
+
+func (p *EscalationPolicy) validate(v *validator) {
+	if p == nil {
+		v.add("", ValidationErrorCodeRequired, 0, nil, "escalation policy is nil")
+		return
+	}
+
+	switch {
+	case p.ID == "":
+		v.add("id", ValidationErrorCodeRequired, 0, nil, "id is required")
+	case len(p.ID) > v.limits.MaxEscalationPolicyIDLength:
+		v.add("id", ValidationErrorCodeTooLong, v.limits.MaxEscalationPolicyIDLength, len(p.ID), "id is too long, expected length <=%d", v.limits.MaxEscalationPolicyIDLength)
+	case !escalationPolicyIDRegex.MatchString(p.ID):
+		v.add("id", ValidationErrorCodeInvalidFormat, 0, p.ID, "id '%s' is not valid, expected letters, digits, '-', '_' or '.'", p.ID)
+	}
+
+	if len(p.Steps) == 0 {
+		v.add("steps", ValidationErrorCodeRequired, 0, nil, "steps must contain at least one escalation point")
+	}
+
+	// The steps are validated as alert escalation points, with the paths moved from 'escalation' to 'steps'
+	steps := newValidator(v.limits)
+	(&Alert{Escalation: p.Steps}).validateEscalation(steps)
+
+	for _, err := range steps.errs {
+		err.Path = "steps" + strings.TrimPrefix(err.Path, "escalation")
+
+		if rest, ok := strings.CutPrefix(err.Message, "escalation["); ok {
+			err.Message = "steps[" + rest
+		}
+
+		v.errs = append(v.errs, err)
+	}
+
+	(&Escalation{RepeatSeconds: p.RepeatSeconds, MaxRepeats: p.MaxRepeats}).validateRepeat(v, "")
+}
+
+// EscalationPolicyRegistry holds the escalation policies referenced by alerts, keyed by ID.
+// It is safe for concurrent use, so policies can be reloaded while alerts are resolved.
+type EscalationPolicyRegistry struct {
+	mu       sync.RWMutex
+	limits   *Limits
+	policies map[string]*EscalationPolicy
+}
+
+// NewEscalationPolicyRegistry creates an empty EscalationPolicyRegistry. Policies are validated with the specified limits,
+// and alerts resolved by ResolveEscalationPolicy are validated with the same limits. If limits is nil, the default limits are used.
+func NewEscalationPolicyRegistry(limits *Limits) *EscalationPolicyRegistry {
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	return &EscalationPolicyRegistry{
+		limits:   limits,
+		policies: make(map[string]*EscalationPolicy),
+	}
+}
+
+// Register cleans and validates a copy of the policy, and adds it to the registry, replacing any policy with the same ID.
+// Since the registry keeps a copy of the policy, p is not changed, and later changes to p have no effect.
+func (r *EscalationPolicyRegistry) Register(p *EscalationPolicy) error {
+	if p == nil {
+		return errors.New("escalation policy is nil")
+	}
+
+	p = p.clone()
+	p.Clean()
+
+	if err := p.ValidateWith(r.limits); err != nil {
+		return fmt.Errorf("invalid escalation policy '%s': %w", p.ID, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.policies[p.ID] = p
+
+	return nil
+}
+
+// Load reads a JSON array of policies from reader, cleans and validates them, and replaces all policies in the registry.
+// If a policy is invalid or an ID is used more than once, an error is returned and the registry is not changed.
+func (r *EscalationPolicyRegistry) Load(reader io.Reader) error {
+	var loaded []*EscalationPolicy
+
+	if err := json.NewDecoder(reader).Decode(&loaded); err != nil {
+		return fmt.Errorf("failed to decode escalation policies: %w", err)
+	}
+
+	policies := make(map[string]*EscalationPolicy, len(loaded))
+
+	for index, p := range loaded {
+		if p != nil {
+			p.Clean()
+		}
+
+		if err := p.ValidateWith(r.limits); err != nil {
+			return fmt.Errorf("invalid escalation policy at index %d: %w", index, err)
+		}
+
+		if _, ok := policies[p.ID]; ok {
+			return fmt.Errorf("escalation policy '%s' is defined more than once", p.ID)
+		}
+
+		policies[p.ID] = p
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.policies = policies
+
+	return nil
+}
+
+// Get returns a copy of the policy with the specified ID, and false if it does not exist.
+func (r *EscalationPolicyRegistry) Get(id string) (*EscalationPolicy, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.policies[id]
+	if !ok {
+		return nil, false
+	}
+
+	return p.clone(), true
+}
+
+// IDs returns the IDs of all policies in the registry, sorted alphabetically.
+func (r *EscalationPolicyRegistry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.policies))
+
+	for id := range r.policies {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// ResolveEscalationPolicy replaces the EscalationPolicy reference with the escalation points of the referenced policy
+// (see EscalationPolicy.Escalation), and clears the reference, so that the alert is self-contained. It does nothing
+// if EscalationPolicy is empty. Call it when the alert is ingested, after Clean.
+//
+// A *ValidationError is returned, and the alert is not changed, if the policy does not exist in the registry (or the registry
+// is nil), if the alert also has escalation points, or if the resolved escalation points are invalid (see ValidateEscalation).
+func (a *Alert) ResolveEscalationPolicy(registry *EscalationPolicyRegistry) error {
+	if a.EscalationPolicy == "" {
+		return nil
+	}
+
+	var limits *Limits
+	if registry != nil {
+		limits = registry.limits
+	}
+
+	v := newValidator(limits)
+
+	if len(a.Escalation) > 0 {
+		a.validateEscalationPolicy(v)
+		return v.first()
+	}
+
+	var (
+		p  *EscalationPolicy
+		ok bool
+	)
+
+	if registry != nil {
+		registry.mu.RLock()
+		p, ok = registry.policies[a.EscalationPolicy]
+		registry.mu.RUnlock()
+	}
+
+	if !ok {
+		v.add("escalationPolicy", ValidationErrorCodeInvalidValue, 0, a.EscalationPolicy, "escalationPolicy '%s' does not exist", a.EscalationPolicy)
+		return v.first()
+	}
+
+	resolved := &Alert{Escalation: p.Escalation()}
+
+	resolved.validateEscalation(v)
+	if err := v.first(); err != nil {
+		return err
+	}
+
+	a.Escalation = resolved.Escalation
+	a.EscalationPolicy = ""
+
+	return nil
+}
+
+// ValidateEscalationPolicy validates that EscalationPolicy, if set, is a valid policy ID, and that it is not combined
+// with Escalation. Whether the policy exists is checked by ResolveEscalationPolicy.
+func (a *Alert) ValidateEscalationPolicy() error {
+	v := newValidator(nil)
+	a.validateEscalationPolicy(v)
+	return v.first()
+}
+
+func (a *Alert) validateEscalationPolicy(v *validator) {
+	if a.EscalationPolicy == "" {
+		return
+	}
+
+	switch {
+	case len(a.EscalationPolicy) > v.limits.MaxEscalationPolicyIDLength:
+		v.add("escalationPolicy", ValidationErrorCodeTooLong, v.limits.MaxEscalationPolicyIDLength, len(a.EscalationPolicy), "escalationPolicy is too long, expected length <=%d", v.limits.MaxEscalationPolicyIDLength)
+	case !escalationPolicyIDRegex.MatchString(a.EscalationPolicy):
+		v.add("escalationPolicy", ValidationErrorCodeInvalidFormat, 0, a.EscalationPolicy, "escalationPolicy '%s' is not valid", a.EscalationPolicy)
+	case len(a.Escalation) > 0:
+		v.add("escalationPolicy", ValidationErrorCodeInvalidValue, 0, a.EscalationPolicy, "escalationPolicy cannot be combined with escalation")
+	}
+}
+
+// clone returns a deep copy of the policy.
+func (p *EscalationPolicy) clone() *EscalationPolicy {
+	c := *p
+	c.Steps = make([]*Escalation, len(p.Steps))
+
+	for i, step := range p.Steps {
+		if step != nil {
+			c.Steps[i] = step.clone()
+		}
+	}
+
+	return &c
+}
+
+// clone returns a deep copy of the escalation point.
+func (e *Escalation) clone() *Escalation {
+	c := *e
+	c.SlackMentions = slices.Clone(e.SlackMentions)
+
+	if e.Schedule != nil {
+		c.Schedule = e.Schedule.clone()
+	}
+
+	return &c
+}
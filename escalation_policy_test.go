@@ -0,0 +1,423 @@
+package types_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEscalationPolicy(id string) *types.EscalationPolicy {
+	return &types.EscalationPolicy{
+		ID: id,
+		Steps: []*types.Escalation{
+			{Severity: types.AlertWarning, DelaySeconds: 300, SlackMentions: []string{"<@U1>"}},
+			{
+				Severity:      types.AlertPanic,
+				DelaySeconds:  900,
+				SlackMentions: []string{"<!here>"},
+				RepeatSeconds: 600,
+				MaxRepeats:    1,
+				Schedule:      &types.EscalationSchedule{Windows: []*types.EscalationWindow{{Weekdays: []string{"monday"}, Start: "08:00", End: "16:00"}}},
+			},
+		},
+		RepeatSeconds: 120,
+		MaxRepeats:    5,
+	}
+}
+
+func requireValidationError(t *testing.T, err error, path string, code types.ValidationErrorCode) *types.ValidationError {
+	t.Helper()
+
+	var validationErr *types.ValidationError
+	require.True(t, errors.As(err, &validationErr), "expected a *ValidationError, got %v", err)
+	assert.Equal(t, path, validationErr.Path)
+	assert.Equal(t, code, validationErr.Code)
+
+	return validationErr
+}
+
+func TestEscalationPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("escalation should apply the policy repeat rules to steps without their own", func(t *testing.T) {
+		t.Parallel()
+
+		p := newTestEscalationPolicy("team-checkout")
+		escalation := p.Escalation()
+
+		require.Len(t, escalation, 2)
+		assert.Equal(t, 120, escalation[0].RepeatSeconds)
+		assert.Equal(t, 5, escalation[0].MaxRepeats)
+		assert.Equal(t, 600, escalation[1].RepeatSeconds)
+		assert.Equal(t, 1, escalation[1].MaxRepeats)
+		assert.Equal(t, 0, p.Steps[0].RepeatSeconds)
+	})
+
+	t.Run("escalation should return copies of the steps", func(t *testing.T) {
+		t.Parallel()
+
+		p := newTestEscalationPolicy("team-checkout")
+		escalation := p.Escalation()
+
+		escalation[0].SlackMentions[0] = "<!channel>"
+		escalation[1].Schedule.Windows[0].Weekdays[0] = "sunday"
+
+		assert.Equal(t, "<@U1>", p.Steps[0].SlackMentions[0])
+		assert.Equal(t, "monday", p.Steps[1].Schedule.Windows[0].Weekdays[0])
+	})
+
+	t.Run("valid policy should pass validation", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, newTestEscalationPolicy("team-checkout.v2").Validate())
+	})
+
+	t.Run("invalid policy should fail validation", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name   string
+			modify func(p *types.EscalationPolicy)
+			path   string
+			code   types.ValidationErrorCode
+		}{
+			{"missing id", func(p *types.EscalationPolicy) { p.ID = "" }, "id", types.ValidationErrorCodeRequired},
+			{"id too long", func(p *types.EscalationPolicy) { p.ID = strings.Repeat("a", types.MaxEscalationPolicyIDLength+1) }, "id", types.ValidationErrorCodeTooLong},
+			{"invalid id", func(p *types.EscalationPolicy) { p.ID = "team checkout" }, "id", types.ValidationErrorCodeInvalidFormat},
+			{"no steps", func(p *types.EscalationPolicy) { p.Steps = nil }, "steps", types.ValidationErrorCodeRequired},
+			{"nil step", func(p *types.EscalationPolicy) { p.Steps[1] = nil }, "steps[1]", types.ValidationErrorCodeRequired},
+			{"too many steps", func(p *types.EscalationPolicy) {
+				p.Steps = append(p.Steps, &types.Escalation{Severity: types.AlertPanic, DelaySeconds: 1800}, &types.Escalation{Severity: types.AlertPanic, DelaySeconds: 3600})
+			}, "steps", types.ValidationErrorCodeTooMany},
+			{"invalid step schedule", func(p *types.EscalationPolicy) { p.Steps[1].Schedule.Windows[0].Start = "8:00" }, "steps[1].schedule.windows[0].start", types.ValidationErrorCodeInvalidFormat},
+			{"invalid repeat rules", func(p *types.EscalationPolicy) { p.RepeatSeconds = 10 }, "repeatSeconds", types.ValidationErrorCodeTooLow},
+			{"repeat rules without max repeats", func(p *types.EscalationPolicy) { p.MaxRepeats = 0 }, "maxRepeats", types.ValidationErrorCodeRequired},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				p := newTestEscalationPolicy("team-checkout")
+				tt.modify(p)
+
+				requireValidationError(t, p.Validate(), tt.path, tt.code)
+			})
+		}
+	})
+
+	t.Run("step errors should be reported relative to the policy", func(t *testing.T) {
+		t.Parallel()
+
+		p := newTestEscalationPolicy("team-checkout")
+		p.Steps[1].DelaySeconds = 310
+
+		err := requireValidationError(t, p.Validate(), "steps[1].delaySeconds", types.ValidationErrorCodeTooLow)
+		assert.True(t, strings.HasPrefix(err.Message, "steps[1].delaySeconds"), err.Message)
+	})
+
+	t.Run("nil policy should fail validation", func(t *testing.T) {
+		t.Parallel()
+
+		var p *types.EscalationPolicy
+		requireValidationError(t, p.Validate(), "", types.ValidationErrorCodeRequired)
+	})
+
+	t.Run("custom limits should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxEscalationCount = 1
+
+		requireValidationError(t, newTestEscalationPolicy("team-checkout").ValidateWith(limits), "steps", types.ValidationErrorCodeTooMany)
+	})
+}
+
+func TestEscalationPolicyRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("registered policies should be returned by id", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+		require.NoError(t, registry.Register(newTestEscalationPolicy("team-payments")))
+		require.NoError(t, registry.Register(newTestEscalationPolicy("team-checkout")))
+
+		assert.Equal(t, []string{"team-checkout", "team-payments"}, registry.IDs())
+
+		p, ok := registry.Get("team-checkout")
+		require.True(t, ok)
+		assert.Equal(t, newTestEscalationPolicy("team-checkout"), p)
+
+		_, ok = registry.Get("team-search")
+		assert.False(t, ok)
+	})
+
+	t.Run("registry should keep its own copy of the policies", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+		p := newTestEscalationPolicy("team-checkout")
+		require.NoError(t, registry.Register(p))
+
+		p.Steps[0].SlackMentions[0] = "<!channel>"
+
+		registered, _ := registry.Get("team-checkout")
+		registered.Steps[0].DelaySeconds = 60
+
+		registered, _ = registry.Get("team-checkout")
+		assert.Equal(t, newTestEscalationPolicy("team-checkout"), registered)
+	})
+
+	t.Run("registering a policy should replace a policy with the same id", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+		require.NoError(t, registry.Register(newTestEscalationPolicy("team-checkout")))
+
+		replacement := newTestEscalationPolicy("team-checkout")
+		replacement.Steps = replacement.Steps[:1]
+		require.NoError(t, registry.Register(replacement))
+
+		p, _ := registry.Get("team-checkout")
+		assert.Len(t, p.Steps, 1)
+	})
+
+	t.Run("invalid policies should not be registered", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+
+		err := registry.Register(&types.EscalationPolicy{ID: "team-checkout"})
+		require.ErrorContains(t, err, "invalid escalation policy 'team-checkout'")
+		requireValidationError(t, err, "steps", types.ValidationErrorCodeRequired)
+
+		require.ErrorContains(t, registry.Register(nil), "escalation policy is nil")
+		assert.Empty(t, registry.IDs())
+	})
+
+	t.Run("registry limits should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxEscalationCount = 1
+
+		registry := types.NewEscalationPolicyRegistry(limits)
+		require.Error(t, registry.Register(newTestEscalationPolicy("team-checkout")))
+	})
+
+	t.Run("load should replace all policies", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+		require.NoError(t, registry.Register(newTestEscalationPolicy("team-payments")))
+
+		err := registry.Load(strings.NewReader(`[
+			{"id": "team-checkout", "steps": [{"severity": "warning", "delaySeconds": 300, "slackMentions": ["<@U1>"]}], "repeatSeconds": 300, "maxRepeats": 2},
+			{"id": "team-search", "steps": [{"severity": "panic", "delaySeconds": 60}]}
+		]`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"team-checkout", "team-search"}, registry.IDs())
+
+		p, ok := registry.Get("team-checkout")
+		require.True(t, ok)
+		assert.Equal(t, &types.EscalationPolicy{
+			ID:            "team-checkout",
+			Steps:         []*types.Escalation{{Severity: types.AlertWarning, DelaySeconds: 300, SlackMentions: []string{"<@U1>"}}},
+			RepeatSeconds: 300,
+			MaxRepeats:    2,
+		}, p)
+	})
+
+	t.Run("policies should be cleaned before validation", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+
+		err := registry.Load(strings.NewReader(`[
+			{"id": " team-checkout ", "steps": [
+				{"severity": " PANIC ", "delaySeconds": 900, "slackMentions": [" <!here> "]},
+				{"severity": "warning", "delaySeconds": 300, "moveToChannel": " c12345678 "}
+			]}
+		]`))
+		require.NoError(t, err)
+
+		p, ok := registry.Get("team-checkout")
+		require.True(t, ok)
+		assert.Equal(t, []*types.Escalation{
+			{Severity: types.AlertWarning, DelaySeconds: 300, MoveToChannel: "C12345678"},
+			{Severity: types.AlertPanic, DelaySeconds: 900, SlackMentions: []string{"<!here>"}},
+		}, p.Steps)
+
+		unsorted := newTestEscalationPolicy("team-search")
+		slices.Reverse(unsorted.Steps)
+
+		require.NoError(t, registry.Register(unsorted))
+		assert.Equal(t, 900, unsorted.Steps[0].DelaySeconds, "the registered policy should not be changed")
+
+		p, _ = registry.Get("team-search")
+		assert.Equal(t, 300, p.Steps[0].DelaySeconds)
+	})
+
+	t.Run("failed load should not change the registry", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name  string
+			input string
+			err   string
+		}{
+			{"invalid json", `{"id": "team-checkout"}`, "failed to decode escalation policies"},
+			{"invalid policy", `[{"id": "team-checkout", "steps": [{"severity": "panic", "delaySeconds": 60}]}, {"id": "team-search"}]`, "invalid escalation policy at index 1"},
+			{"duplicate id", `[{"id": "team-search", "steps": [{"severity": "panic", "delaySeconds": 60}]}, {"id": "team-search", "steps": [{"severity": "panic", "delaySeconds": 60}]}]`, "escalation policy 'team-search' is defined more than once"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				registry := types.NewEscalationPolicyRegistry(nil)
+				require.NoError(t, registry.Register(newTestEscalationPolicy("team-payments")))
+
+				require.ErrorContains(t, registry.Load(strings.NewReader(tt.input)), tt.err)
+				assert.Equal(t, []string{"team-payments"}, registry.IDs())
+			})
+		}
+	})
+}
+
+func TestResolveEscalationPolicy(t *testing.T) {
+	t.Parallel()
+
+	newRegistry := func(t *testing.T) *types.EscalationPolicyRegistry {
+		t.Helper()
+
+		registry := types.NewEscalationPolicyRegistry(nil)
+		require.NoError(t, registry.Register(newTestEscalationPolicy("team-checkout")))
+
+		return registry
+	}
+
+	t.Run("policy reference should be replaced with the policy steps", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newRegistry(t)
+		a := &types.Alert{Header: "a", Severity: types.AlertError, EscalationPolicy: "team-checkout"}
+
+		require.NoError(t, a.ResolveEscalationPolicy(registry))
+
+		assert.Empty(t, a.EscalationPolicy)
+		assert.Equal(t, newTestEscalationPolicy("team-checkout").Escalation(), a.Escalation)
+		require.NoError(t, a.Validate())
+
+		a.Escalation[0].SlackMentions[0] = "<!channel>"
+
+		p, _ := registry.Get("team-checkout")
+		assert.Equal(t, "<@U1>", p.Steps[0].SlackMentions[0])
+	})
+
+	t.Run("alert without policy reference should not be changed", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60}}}
+
+		require.NoError(t, a.ResolveEscalationPolicy(newRegistry(t)))
+		assert.Equal(t, &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60}}}, a)
+	})
+
+	t.Run("unknown policy should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", EscalationPolicy: "team-search"}
+
+		err := requireValidationError(t, a.ResolveEscalationPolicy(newRegistry(t)), "escalationPolicy", types.ValidationErrorCodeInvalidValue)
+		assert.Equal(t, "escalationPolicy 'team-search' does not exist", err.Message)
+		assert.Equal(t, "team-search", a.EscalationPolicy)
+		assert.Nil(t, a.Escalation)
+	})
+
+	t.Run("nil registry should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", EscalationPolicy: "team-checkout"}
+
+		err := requireValidationError(t, a.ResolveEscalationPolicy(nil), "escalationPolicy", types.ValidationErrorCodeInvalidValue)
+		assert.Equal(t, "escalationPolicy 'team-checkout' does not exist", err.Message)
+
+		require.NoError(t, (&types.Alert{Header: "a"}).ResolveEscalationPolicy(nil))
+	})
+
+	t.Run("policy reference combined with escalation points should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", EscalationPolicy: "team-checkout", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60}}}
+
+		requireValidationError(t, a.ResolveEscalationPolicy(newRegistry(t)), "escalationPolicy", types.ValidationErrorCodeInvalidValue)
+		assert.Len(t, a.Escalation, 1)
+	})
+
+	t.Run("resolved escalation points should be validated with the registry limits", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		registry := types.NewEscalationPolicyRegistry(limits)
+		require.NoError(t, registry.Register(newTestEscalationPolicy("team-checkout")))
+
+		limits.MaxEscalationCount = 1
+
+		a := &types.Alert{Header: "a", EscalationPolicy: "team-checkout"}
+
+		requireValidationError(t, a.ResolveEscalationPolicy(registry), "escalation", types.ValidationErrorCodeTooMany)
+		assert.Equal(t, "team-checkout", a.EscalationPolicy)
+		assert.Nil(t, a.Escalation)
+	})
+}
+
+func TestValidateEscalationPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		alert *types.Alert
+		path  string
+		code  types.ValidationErrorCode
+	}{
+		{"no policy", &types.Alert{}, "", ""},
+		{"valid policy", &types.Alert{EscalationPolicy: "team-checkout.v2"}, "", ""},
+		{"policy too long", &types.Alert{EscalationPolicy: strings.Repeat("a", types.MaxEscalationPolicyIDLength+1)}, "escalationPolicy", types.ValidationErrorCodeTooLong},
+		{"invalid policy", &types.Alert{EscalationPolicy: "team checkout"}, "escalationPolicy", types.ValidationErrorCodeInvalidFormat},
+		{"policy combined with escalation", &types.Alert{EscalationPolicy: "team-checkout", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60}}}, "escalationPolicy", types.ValidationErrorCodeInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.alert.ValidateEscalationPolicy()
+
+			if tt.path == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			requireValidationError(t, err, tt.path, tt.code)
+
+			tt.alert.Header, tt.alert.Severity = "a", types.AlertError
+			requireValidationError(t, tt.alert.Validate(), tt.path, tt.code)
+		})
+	}
+
+	t.Run("clean should trim the policy reference", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", EscalationPolicy: " team-checkout "}
+		a.Clean()
+		assert.Equal(t, "team-checkout", a.EscalationPolicy)
+	})
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// EscalationReminder is a repetition of the Slack mentions of an escalation point, as returned by Alert.EscalationReminders.
+type EscalationReminder struct {
+	// EscalationIndex is the index of the escalation point in Alert.Escalation.
+	EscalationIndex int `json:"escalationIndex"`
+
+	// Repeat is the number of the reminder for the escalation point, starting at 1.
+	Repeat int `json:"repeat"`
+
+	// At is when the reminder is due.
+	At time.Time `json:"at"`
+
+	// Mentions are the Slack mentions to repeat: the mentions used when the escalation point fired.
+	Mentions []string `json:"mentions"`
+}
+
+// EscalationReminders returns the future reminders of all escalation points with RepeatSeconds set, for an issue created at
+// issueCreated, sorted by time. Reminders at or before the time reported by clock (or the system clock if nil) are not included.
+//
+// Reminders of an escalation point are due every RepeatSeconds after the escalation fires (see EvaluateEscalation),
+// up to MaxRepeats times, and stop when a later escalation point fires. Reminders repeat the mentions used when the escalation
+// fired, regardless of the escalation schedule. The caller is responsible for cancelling the reminders when the issue is
+// resolved or acknowledged. An error is returned if an escalation schedule is invalid.
+func (a *Alert) EscalationReminders(issueCreated time.Time, clock Clock) ([]*EscalationReminder, error) {
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	now := clock.Now()
+	fired := make([]*EscalationDecision, len(a.Escalation))
+
+	for i, e := range a.Escalation {
+		if e == nil {
+			continue
+		}
+
+		decision, err := e.decide(issueCreated)
+		if err != nil {
+			return nil, fmt.Errorf("escalation[%d]: %w", i, err)
+		}
+
+		if decision.Action == EscalationActionDefer {
+			decision.Mentions = e.SlackMentions
+		}
+
+		fired[i] = decision
+	}
+
+	var reminders []*EscalationReminder
+
+	for i, e := range a.Escalation {
+		if e == nil || e.RepeatSeconds <= 0 {
+			continue
+		}
+
+		// Reminders stop when a later escalation point fires
+		var stop time.Time
+
+		for _, later := range fired[i+1:] {
+			if later != nil && (stop.IsZero() || later.At.Before(stop)) {
+				stop = later.At
+			}
+		}
+
+		interval := time.Duration(e.RepeatSeconds) * time.Second
+
+		for repeat := 1; repeat <= e.MaxRepeats; repeat++ {
+			at := fired[i].At.Add(time.Duration(repeat) * interval)
+
+			if !stop.IsZero() && !at.Before(stop) {
+				break
+			}
+
+			if at.After(now) {
+				reminders = append(reminders, &EscalationReminder{EscalationIndex: i, Repeat: repeat, At: at, Mentions: fired[i].Mentions})
+			}
+		}
+	}
+
+	slices.SortStableFunc(reminders, func(a, b *EscalationReminder) int {
+		return a.At.Compare(b.At)
+	})
+
+	return reminders, nil
+}
+
+// validateRepeat validates RepeatSeconds and MaxRepeats. The path is empty for the repeat rules of an EscalationPolicy.
+func (e *Escalation) validateRepeat(v *validator, path string) {
+	repeatPath, maxPath := "repeatSeconds", "maxRepeats"
+
+	if path != "" {
+		repeatPath, maxPath = path+"."+repeatPath, path+"."+maxPath
+	}
+
+	switch {
+	case e.RepeatSeconds < 0:
+		v.add(repeatPath, ValidationErrorCodeTooLow, 0, e.RepeatSeconds, "%s '%d' is too low, expected value >=0", repeatPath, e.RepeatSeconds)
+	case e.RepeatSeconds > 0 && e.RepeatSeconds < v.limits.MinEscalationDelayDiffSeconds:
+		v.add(repeatPath, ValidationErrorCodeTooLow, v.limits.MinEscalationDelayDiffSeconds, e.RepeatSeconds, "%s '%d' is too low, expected value >=%d", repeatPath, e.RepeatSeconds, v.limits.MinEscalationDelayDiffSeconds)
+	}
+
+	switch {
+	case e.MaxRepeats < 0:
+		v.add(maxPath, ValidationErrorCodeTooLow, 0, e.MaxRepeats, "%s '%d' is too low, expected value >=0", maxPath, e.MaxRepeats)
+	case e.MaxRepeats > v.limits.MaxEscalationRepeatCount:
+		v.add(maxPath, ValidationErrorCodeTooHigh, v.limits.MaxEscalationRepeatCount, e.MaxRepeats, "%s '%d' is too high, expected value <=%d", maxPath, e.MaxRepeats, v.limits.MaxEscalationRepeatCount)
+	case e.RepeatSeconds > 0 && e.MaxRepeats == 0:
+		v.add(maxPath, ValidationErrorCodeRequired, 0, e.MaxRepeats, "%s is required when repeatSeconds is set", maxPath)
+	case e.RepeatSeconds == 0 && e.MaxRepeats > 0:
+		v.add(maxPath, ValidationErrorCodeInvalidValue, 0, e.MaxRepeats, "%s requires repeatSeconds to be set", maxPath)
+	}
+}
@@ -0,0 +1,177 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationReminders(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	at := func(seconds int) time.Time {
+		return created.Add(time.Duration(seconds) * time.Second)
+	}
+
+	t.Run("reminders should repeat the mentions up to the max count", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"<!here>"}, RepeatSeconds: 300, MaxRepeats: 3},
+		}}
+
+		reminders, err := a.EscalationReminders(created, types.FixedClock(created))
+		require.NoError(t, err)
+		assert.Equal(t, []*types.EscalationReminder{
+			{EscalationIndex: 0, Repeat: 1, At: at(360), Mentions: []string{"<!here>"}},
+			{EscalationIndex: 0, Repeat: 2, At: at(660), Mentions: []string{"<!here>"}},
+			{EscalationIndex: 0, Repeat: 3, At: at(960), Mentions: []string{"<!here>"}},
+		}, reminders)
+	})
+
+	t.Run("past reminders should not be included", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			{Severity: types.AlertPanic, DelaySeconds: 60, RepeatSeconds: 300, MaxRepeats: 3},
+		}}
+
+		reminders, err := a.EscalationReminders(created, types.FixedClock(at(660)))
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, 3, reminders[0].Repeat)
+		assert.Equal(t, at(960), reminders[0].At)
+	})
+
+	t.Run("reminders should stop when a later escalation point fires", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			{Severity: types.AlertWarning, DelaySeconds: 60, SlackMentions: []string{"<@U1>"}, RepeatSeconds: 300, MaxRepeats: 10},
+			{Severity: types.AlertPanic, DelaySeconds: 900, SlackMentions: []string{"<!channel>"}, RepeatSeconds: 600, MaxRepeats: 2},
+		}}
+
+		reminders, err := a.EscalationReminders(created, types.FixedClock(created))
+		require.NoError(t, err)
+		assert.Equal(t, []*types.EscalationReminder{
+			{EscalationIndex: 0, Repeat: 1, At: at(360), Mentions: []string{"<@U1>"}},
+			{EscalationIndex: 0, Repeat: 2, At: at(660), Mentions: []string{"<@U1>"}},
+			{EscalationIndex: 1, Repeat: 1, At: at(1500), Mentions: []string{"<!channel>"}},
+			{EscalationIndex: 1, Repeat: 2, At: at(2100), Mentions: []string{"<!channel>"}},
+		}, reminders)
+	})
+
+	t.Run("reminders of deferred escalations should start when the escalation fires", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			{
+				Severity:      types.AlertPanic,
+				DelaySeconds:  60,
+				SlackMentions: []string{"<!here>"},
+				RepeatSeconds: 300,
+				MaxRepeats:    1,
+				Schedule:      &types.EscalationSchedule{Windows: []*types.EscalationWindow{{Start: "12:00", End: "13:00"}}},
+			},
+		}}
+
+		reminders, err := a.EscalationReminders(created, types.FixedClock(created))
+		require.NoError(t, err)
+		assert.Equal(t, []*types.EscalationReminder{
+			{EscalationIndex: 0, Repeat: 1, At: time.Date(2026, 3, 2, 12, 5, 0, 0, time.UTC), Mentions: []string{"<!here>"}},
+		}, reminders)
+	})
+
+	t.Run("reminders of alternate escalations should repeat the alternate mentions", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			{
+				Severity:      types.AlertPanic,
+				DelaySeconds:  60,
+				SlackMentions: []string{"<!here>"},
+				RepeatSeconds: 300,
+				MaxRepeats:    1,
+				Schedule: &types.EscalationSchedule{
+					Windows:           []*types.EscalationWindow{{Start: "12:00", End: "13:00"}},
+					OutsideWindows:    types.EscalationScheduleAlternate,
+					AlternateMentions: []string{"<@U1>"},
+				},
+			},
+		}}
+
+		reminders, err := a.EscalationReminders(created, types.FixedClock(created))
+		require.NoError(t, err)
+		assert.Equal(t, []*types.EscalationReminder{
+			{EscalationIndex: 0, Repeat: 1, At: at(360), Mentions: []string{"<@U1>"}},
+		}, reminders)
+	})
+
+	t.Run("escalation points without repeat should have no reminders", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{nil, {Severity: types.AlertPanic, DelaySeconds: 60}}}
+
+		reminders, err := a.EscalationReminders(created, nil)
+		require.NoError(t, err)
+		assert.Empty(t, reminders)
+	})
+
+	t.Run("invalid schedule should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, Schedule: &types.EscalationSchedule{}}}}
+
+		_, err := a.EscalationReminders(created, nil)
+		require.ErrorContains(t, err, "escalation[0]: escalation schedule has no windows")
+	})
+}
+
+func TestValidateEscalationRepeat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		repeatSeconds int
+		maxRepeats    int
+		path          string
+		code          types.ValidationErrorCode
+	}{
+		{"valid repeat", types.MinEscalationDelayDiffSeconds, types.MaxEscalationRepeatCount, "", ""},
+		{"no repeat", 0, 0, "", ""},
+		{"negative repeat", -1, 1, "escalation[0].repeatSeconds", types.ValidationErrorCodeTooLow},
+		{"repeat too low", types.MinEscalationDelayDiffSeconds - 1, 1, "escalation[0].repeatSeconds", types.ValidationErrorCodeTooLow},
+		{"negative max repeats", 60, -1, "escalation[0].maxRepeats", types.ValidationErrorCodeTooLow},
+		{"max repeats too high", 60, types.MaxEscalationRepeatCount + 1, "escalation[0].maxRepeats", types.ValidationErrorCodeTooHigh},
+		{"max repeats missing", 60, 0, "escalation[0].maxRepeats", types.ValidationErrorCodeRequired},
+		{"max repeats without repeat", 0, 1, "escalation[0].maxRepeats", types.ValidationErrorCodeInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &types.Alert{Escalation: []*types.Escalation{
+				{Severity: types.AlertPanic, DelaySeconds: 60, RepeatSeconds: tt.repeatSeconds, MaxRepeats: tt.maxRepeats},
+			}}
+
+			err := a.ValidateEscalation()
+
+			if tt.path == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *types.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.path, validationErr.Path)
+			assert.Equal(t, tt.code, validationErr.Code)
+		})
+	}
+}
@@ -0,0 +1,487 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EscalationScheduleMode decides what happens to an escalation that is due outside the schedule windows.
+type EscalationScheduleMode string
+
+const (
+	// EscalationScheduleDefer defers the escalation to the start of the next window. This is the default.
+	EscalationScheduleDefer EscalationScheduleMode = "defer"
+
+	// EscalationScheduleAlternate fires the escalation when due, with the schedule's AlternateMentions
+	// instead of the escalation's SlackMentions.
+	EscalationScheduleAlternate EscalationScheduleMode = "alternate"
+)
+
+// EscalationScheduleModeIsValid returns true if the provided EscalationScheduleMode is valid.
+func EscalationScheduleModeIsValid(m EscalationScheduleMode) bool {
+	switch m {
+	case EscalationScheduleDefer, EscalationScheduleAlternate:
+		return true
+	}
+	return false
+}
+
+// ValidEscalationScheduleModes returns a slice of valid EscalationScheduleMode values.
+func ValidEscalationScheduleModes() []string {
+	return []string{
+		string(EscalationScheduleDefer),
+		string(EscalationScheduleAlternate),
+	}
+}
+
+// EscalationAction is the outcome of evaluating an escalation with EvaluateEscalation.
+type EscalationAction string
+
+const (
+	// EscalationActionPending means that the escalation is not due yet.
+	EscalationActionPending EscalationAction = "pending"
+
+	// EscalationActionFire means that the escalation should fire, with the escalation's SlackMentions.
+	EscalationActionFire EscalationAction = "fire"
+
+	// EscalationActionDefer means that the escalation is due outside the schedule windows, and deferred to the next window.
+	EscalationActionDefer EscalationAction = "defer"
+
+	// EscalationActionAlternate means that the escalation should fire, with the schedule's AlternateMentions.
+	EscalationActionAlternate EscalationAction = "alternate"
+)
+
+// escalationHolidayLayout is the date format of EscalationSchedule.Holidays.
+const escalationHolidayLayout = "2006-01-02"
+
+// escalationScheduleSearchDays is the number of days searched for the next window, which covers a year of holidays.
+const escalationScheduleSearchDays = 400
+
+// escalationWeekdays maps the weekday names accepted in EscalationWindow.Weekdays to weekdays.
+var escalationWeekdays = map[string]time.Weekday{
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+	"sunday":    time.Sunday,
+}
+
+// EscalationSchedule restricts when an escalation fires to a set of weekday and time-of-day windows in a time zone,
+// such as business hours, excluding holidays. Outside the windows, the escalation is either deferred to the next window,
+// or fires with alternate mentions (see OutsideWindows).
+type EscalationSchedule struct {
+	// TimeZone is the IANA time zone of the windows and holidays, such as 'Europe/Oslo'. UTC is used if empty.
+	TimeZone string `json:"timeZone"`
+
+	// Windows are the weekday and time-of-day windows when the escalation fires as normal. At least one window is required.
+	// Maximum of MaxEscalationWindowCount windows allowed.
+	Windows []*EscalationWindow `json:"windows"`
+
+	// Holidays are dates (YYYY-MM-DD, in the schedule time zone) that are outside all windows, such as public holidays.
+	// Maximum of MaxEscalationHolidayCount holidays allowed.
+	Holidays []string `json:"holidays"`
+
+	// OutsideWindows decides what happens when the escalation is due outside the windows: 'defer' (default) defers it
+	// to the start of the next window, and 'alternate' fires it when due, with AlternateMentions.
+	OutsideWindows EscalationScheduleMode `json:"outsideWindows"`
+
+	// AlternateMentions replace the escalation's SlackMentions outside the windows, when OutsideWindows is 'alternate'.
+	// If empty, the escalation fires without mentions outside the windows.
+	AlternateMentions []string `json:"alternateMentions"`
+}
+
+// EscalationWindow is a time-of-day window on one or more weekdays.
+type EscalationWindow struct {
+	// Weekdays are the lowercase English names of the weekdays of the window, such as 'monday'. If empty, the window applies every day.
+	Weekdays []string `json:"weekdays"`
+
+	// Start is the start of the window (inclusive), as HH:MM in 24-hour format.
+	Start string `json:"start"`
+
+	// End is the end of the window (exclusive), as HH:MM in 24-hour format. It must be after Start.
+	// Use '24:00' for the end of the day. Windows spanning midnight are expressed as two windows.
+	End string `json:"end"`
+}
+
+// EscalationDecision is the result of EvaluateEscalation.
+type EscalationDecision struct {
+	// Action is what should happen to the escalation.
+	Action EscalationAction `json:"action"`
+
+	// At is when the escalation fires: the due time for pending, fired and alternate escalations,
+	// and the start of the next window for deferred escalations (including deferred escalations that have since fired).
+	At time.Time `json:"at"`
+
+	// Mentions are the Slack mentions to add when the escalation fires. It is empty for pending and deferred escalations.
+	Mentions []string `json:"mentions"`
+}
+
+// EvaluateEscalation decides what should happen to the escalation point of an issue created at issueCreated,
+// at the time reported by clock (or the system clock if nil). The result only depends on the escalation, issueCreated and clock,
+// so the evaluation is deterministic, and stable when repeated.
+//
+// The escalation is due DelaySeconds after issueCreated. Escalations without a schedule fire when due.
+// Escalations with a schedule fire when due inside a window; outside the windows they are deferred to the start of the next window
+// (and fire once it has started), or fire with the alternate mentions, depending on Schedule.OutsideWindows.
+// An error is returned if the schedule is invalid (see ValidateEscalation).
+func EvaluateEscalation(e *Escalation, issueCreated time.Time, clock Clock) (*EscalationDecision, error) {
+	if e == nil {
+		return nil, fmt.Errorf("escalation is nil")
+	}
+
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	now := clock.Now()
+	due := issueCreated.Add(time.Duration(e.DelaySeconds) * time.Second)
+
+	if now.Before(due) {
+		return &EscalationDecision{Action: EscalationActionPending, At: due}, nil
+	}
+
+	decision, err := e.decide(issueCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deferred escalations fire once the next window has started
+	if decision.Action == EscalationActionDefer && !now.Before(decision.At) {
+		decision.Action = EscalationActionFire
+		decision.Mentions = e.SlackMentions
+	}
+
+	return decision, nil
+}
+
+// decide returns the decision for the escalation at or after its due time: when and with which mentions it fires,
+// or the start of the window it is deferred to.
+func (e *Escalation) decide(issueCreated time.Time) (*EscalationDecision, error) {
+	due := issueCreated.Add(time.Duration(e.DelaySeconds) * time.Second)
+
+	if e.Schedule == nil {
+		return &EscalationDecision{Action: EscalationActionFire, At: due, Mentions: e.SlackMentions}, nil
+	}
+
+	schedule, err := e.Schedule.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Schedule.OutsideWindows == EscalationScheduleAlternate {
+		if schedule.inWindow(due) {
+			return &EscalationDecision{Action: EscalationActionFire, At: due, Mentions: e.SlackMentions}, nil
+		}
+
+		return &EscalationDecision{Action: EscalationActionAlternate, At: due, Mentions: e.Schedule.AlternateMentions}, nil
+	}
+
+	next, ok := schedule.nextWindowStart(due)
+	if !ok {
+		return nil, fmt.Errorf("escalation schedule has no window within %d days of %s", escalationScheduleSearchDays, due.Format(time.RFC3339))
+	}
+
+	if next.Equal(due) {
+		return &EscalationDecision{Action: EscalationActionFire, At: due, Mentions: e.SlackMentions}, nil
+	}
+
+	return &EscalationDecision{Action: EscalationActionDefer, At: next}, nil
+}
+
+// InWindow returns true if t is inside one of the schedule windows, and not on a holiday.
+// An error is returned if the schedule is invalid.
+func (s *EscalationSchedule) InWindow(t time.Time) (bool, error) {
+	schedule, err := s.compile()
+	if err != nil {
+		return false, err
+	}
+
+	return schedule.inWindow(t), nil
+}
+
+// NextWindowStart returns the earliest time at or after t that is inside one of the schedule windows, and not on a holiday.
+// An error is returned if the schedule is invalid, or has no window within a year.
+func (s *EscalationSchedule) NextWindowStart(t time.Time) (time.Time, error) {
+	schedule, err := s.compile()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next, ok := schedule.nextWindowStart(t)
+	if !ok {
+		return time.Time{}, fmt.Errorf("escalation schedule has no window within %d days of %s", escalationScheduleSearchDays, t.Format(time.RFC3339))
+	}
+
+	return next, nil
+}
+
+// compiledSchedule is an EscalationSchedule with parsed values.
+type compiledSchedule struct {
+	location *time.Location
+	windows  []*compiledWindow
+	holidays map[string]bool
+}
+
+// compiledWindow is an EscalationWindow with parsed values. Start and end are minutes since midnight.
+type compiledWindow struct {
+	weekdays   [7]bool
+	start, end int
+}
+
+func (s *EscalationSchedule) compile() (*compiledSchedule, error) {
+	location, err := loadScheduleLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid escalation schedule time zone %q: %w", s.TimeZone, err)
+	}
+
+	if len(s.Windows) == 0 {
+		return nil, fmt.Errorf("escalation schedule has no windows")
+	}
+
+	schedule := &compiledSchedule{location: location, holidays: make(map[string]bool, len(s.Holidays))}
+
+	for i, w := range s.Windows {
+		if w == nil {
+			return nil, fmt.Errorf("escalation schedule windows[%d] is nil", i)
+		}
+
+		window := &compiledWindow{}
+
+		var ok bool
+
+		if window.start, ok = parseTimeOfDay(w.Start); !ok || window.start == 24*60 {
+			return nil, fmt.Errorf("escalation schedule windows[%d].start %q is not a valid time of day", i, w.Start)
+		}
+
+		if window.end, ok = parseTimeOfDay(w.End); !ok || window.end <= window.start {
+			return nil, fmt.Errorf("escalation schedule windows[%d].end %q is not a valid time of day after start", i, w.End)
+		}
+
+		for _, name := range w.Weekdays {
+			weekday, ok := escalationWeekdays[name]
+			if !ok {
+				return nil, fmt.Errorf("escalation schedule windows[%d] has invalid weekday %q", i, name)
+			}
+
+			window.weekdays[weekday] = true
+		}
+
+		if len(w.Weekdays) == 0 {
+			window.weekdays = [7]bool{true, true, true, true, true, true, true}
+		}
+
+		schedule.windows = append(schedule.windows, window)
+	}
+
+	for _, holiday := range s.Holidays {
+		if _, err := time.Parse(escalationHolidayLayout, holiday); err != nil {
+			return nil, fmt.Errorf("escalation schedule holiday %q is not a valid date, expected YYYY-MM-DD", holiday)
+		}
+
+		schedule.holidays[holiday] = true
+	}
+
+	return schedule, nil
+}
+
+func (s *compiledSchedule) inWindow(t time.Time) bool {
+	next, ok := s.nextWindowStart(t)
+	return ok && next.Equal(t)
+}
+
+// nextWindowStart returns the earliest time at or after t inside a window, searching day by day in the schedule time zone.
+func (s *compiledSchedule) nextWindowStart(t time.Time) (time.Time, bool) {
+	local := t.In(s.location)
+	year, month, day := local.Date()
+
+	for offset := range escalationScheduleSearchDays {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, s.location)
+
+		if s.holidays[date.Format(escalationHolidayLayout)] {
+			continue
+		}
+
+		var next time.Time
+
+		for _, w := range s.windows {
+			if !w.weekdays[date.Weekday()] {
+				continue
+			}
+
+			// time.Date normalizes times of day that do not exist because of daylight saving time, and 24:00
+			start := time.Date(date.Year(), date.Month(), date.Day(), w.start/60, w.start%60, 0, 0, s.location)
+			end := time.Date(date.Year(), date.Month(), date.Day(), w.end/60, w.end%60, 0, 0, s.location)
+
+			if !t.Before(end) {
+				continue
+			}
+
+			candidate := start
+			if t.After(start) {
+				candidate = t
+			}
+
+			if next.IsZero() || candidate.Before(next) {
+				next = candidate
+			}
+		}
+
+		if !next.IsZero() {
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// loadScheduleLocation loads the IANA time zone, or UTC if name is empty.
+// Note that time zones are loaded from the system, unless the program imports time/tzdata.
+func loadScheduleLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// parseTimeOfDay parses a time of day as HH:MM (00:00 to 24:00), and returns the number of minutes since midnight.
+func parseTimeOfDay(s string) (int, bool) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, false
+	}
+
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+	}
+
+	hours := int(s[0]-'0')*10 + int(s[1]-'0')
+	minutes := int(s[3]-'0')*10 + int(s[4]-'0')
+
+	if hours > 24 || minutes > 59 || hours == 24 && minutes > 0 {
+		return 0, false
+	}
+
+	return hours*60 + minutes, true
+}
+
+// validEscalationWeekdays returns the weekday names accepted in EscalationWindow.Weekdays.
+func validEscalationWeekdays() []string {
+	return []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+}
+
+func (s *EscalationSchedule) clean(c *cleaner, path string) {
+	c.normalize(path+".timeZone", &s.TimeZone, strings.TrimSpace)
+
+	mode := string(s.OutsideWindows)
+	c.normalize(path+".outsideWindows", &mode, trimLower)
+	s.OutsideWindows = EscalationScheduleMode(mode)
+
+	for i, w := range s.Windows {
+		if w == nil {
+			continue
+		}
+
+		windowPath := fmt.Sprintf("%s.windows[%d]", path, i)
+
+		for j := range w.Weekdays {
+			c.normalize(fmt.Sprintf("%s.weekdays[%d]", windowPath, j), &w.Weekdays[j], trimLower)
+		}
+
+		c.normalize(windowPath+".start", &w.Start, strings.TrimSpace)
+		c.normalize(windowPath+".end", &w.End, strings.TrimSpace)
+	}
+
+	for i := range s.Holidays {
+		c.normalize(fmt.Sprintf("%s.holidays[%d]", path, i), &s.Holidays[i], strings.TrimSpace)
+	}
+
+	for i := range s.AlternateMentions {
+		c.normalize(fmt.Sprintf("%s.alternateMentions[%d]", path, i), &s.AlternateMentions[i], strings.TrimSpace)
+	}
+}
+
+func (s *EscalationSchedule) validate(v *validator, path string) {
+	if _, err := loadScheduleLocation(s.TimeZone); err != nil {
+		v.add(path+".timeZone", ValidationErrorCodeInvalidValue, 0, s.TimeZone, "%s.timeZone '%s' is not a valid IANA time zone", path, s.TimeZone)
+	}
+
+	if len(s.Windows) == 0 {
+		v.add(path+".windows", ValidationErrorCodeRequired, 0, nil, "%s.windows must contain at least one window", path)
+	} else if len(s.Windows) > v.limits.MaxEscalationWindowCount {
+		v.add(path+".windows", ValidationErrorCodeTooMany, v.limits.MaxEscalationWindowCount, len(s.Windows), "%s.windows item count is too large, expected <=%d", path, v.limits.MaxEscalationWindowCount)
+	}
+
+	for i, w := range s.Windows {
+		windowPath := fmt.Sprintf("%s.windows[%d]", path, i)
+
+		if w == nil {
+			v.add(windowPath, ValidationErrorCodeRequired, 0, nil, "%s is nil", windowPath)
+			continue
+		}
+
+		for j, name := range w.Weekdays {
+			if _, ok := escalationWeekdays[name]; !ok {
+				v.add(fmt.Sprintf("%s.weekdays[%d]", windowPath, j), ValidationErrorCodeInvalidValue, 0, name, "%s.weekdays[%d] '%s' is not valid, expected one of [%s]", windowPath, j, name, strings.Join(validEscalationWeekdays(), ", "))
+			}
+		}
+
+		start, startOK := parseTimeOfDay(w.Start)
+		if !startOK || start == 24*60 {
+			v.add(windowPath+".start", ValidationErrorCodeInvalidFormat, 0, w.Start, "%s.start '%s' is not valid, expected HH:MM", windowPath, w.Start)
+		}
+
+		end, endOK := parseTimeOfDay(w.End)
+		if !endOK {
+			v.add(windowPath+".end", ValidationErrorCodeInvalidFormat, 0, w.End, "%s.end '%s' is not valid, expected HH:MM", windowPath, w.End)
+		} else if startOK && end <= start {
+			v.add(windowPath+".end", ValidationErrorCodeInvalidValue, 0, w.End, "%s.end '%s' must be after start '%s'", windowPath, w.End, w.Start)
+		}
+	}
+
+	if len(s.Holidays) > v.limits.MaxEscalationHolidayCount {
+		v.add(path+".holidays", ValidationErrorCodeTooMany, v.limits.MaxEscalationHolidayCount, len(s.Holidays), "%s.holidays item count is too large, expected <=%d", path, v.limits.MaxEscalationHolidayCount)
+	}
+
+	for i, holiday := range s.Holidays {
+		if _, err := time.Parse(escalationHolidayLayout, holiday); err != nil {
+			v.add(fmt.Sprintf("%s.holidays[%d]", path, i), ValidationErrorCodeInvalidFormat, 0, holiday, "%s.holidays[%d] '%s' is not valid, expected YYYY-MM-DD", path, i, holiday)
+		}
+	}
+
+	if s.OutsideWindows != "" && !EscalationScheduleModeIsValid(s.OutsideWindows) {
+		v.add(path+".outsideWindows", ValidationErrorCodeInvalidValue, 0, string(s.OutsideWindows), "%s.outsideWindows '%s' is not valid, expected one of [%s]", path, s.OutsideWindows, strings.Join(ValidEscalationScheduleModes(), ", "))
+	}
+
+	if len(s.AlternateMentions) > v.limits.MaxEscalationSlackMentionCount {
+		v.add(path+".alternateMentions", ValidationErrorCodeTooMany, v.limits.MaxEscalationSlackMentionCount, len(s.AlternateMentions), "%s.alternateMentions item count is too large, expected <=%d", path, v.limits.MaxEscalationSlackMentionCount)
+	}
+
+	for i, mention := range s.AlternateMentions {
+		if !isValidSlackMention(mention, v.limits) {
+			v.add(fmt.Sprintf("%s.alternateMentions[%d]", path, i), ValidationErrorCodeInvalidFormat, 0, mention, "%s.alternateMentions[%d] is not valid", path, i)
+		}
+	}
+}
+
+// clone returns a deep copy of the schedule.
+func (s *EscalationSchedule) clone() *EscalationSchedule {
+	c := *s
+	c.Windows = cloneSlice(s.Windows)
+
+	for _, w := range c.Windows {
+		if w != nil {
+			w.Weekdays = slices.Clone(w.Weekdays)
+		}
+	}
+
+	c.Holidays = slices.Clone(s.Holidays)
+	c.AlternateMentions = slices.Clone(s.AlternateMentions)
+
+	return &c
+}
@@ -0,0 +1,412 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata" // Time zones for schedule tests, independent of the system time zone database
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBusinessHoursSchedule returns a schedule with business hours in Oslo (UTC+1 in winter), where Wednesday 2026-03-04 is a holiday.
+func newBusinessHoursSchedule() *types.EscalationSchedule {
+	return &types.EscalationSchedule{
+		TimeZone: "Europe/Oslo",
+		Windows: []*types.EscalationWindow{
+			{Weekdays: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Start: "08:00", End: "16:00"},
+		},
+		Holidays: []string{"2026-03-04"},
+	}
+}
+
+func oslo(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	location, err := time.LoadLocation("Europe/Oslo")
+	require.NoError(t, err)
+
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, location)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func TestEscalationScheduleMode(t *testing.T) {
+	t.Parallel()
+
+	for _, m := range types.ValidEscalationScheduleModes() {
+		assert.True(t, types.EscalationScheduleModeIsValid(types.EscalationScheduleMode(m)))
+	}
+
+	assert.False(t, types.EscalationScheduleModeIsValid(""))
+	assert.False(t, types.EscalationScheduleModeIsValid("drop"))
+}
+
+func TestEscalationScheduleWindows(t *testing.T) {
+	t.Parallel()
+
+	t.Run("windows should include start and exclude end", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := newBusinessHoursSchedule()
+
+		tests := []struct {
+			at       string
+			inWindow bool
+		}{
+			{"2026-03-02 07:59", false},
+			{"2026-03-02 08:00", true},
+			{"2026-03-02 15:59", true},
+			{"2026-03-02 16:00", false},
+			{"2026-03-04 10:00", false}, // Holiday
+			{"2026-03-07 10:00", false}, // Saturday
+		}
+
+		for _, tt := range tests {
+			inWindow, err := schedule.InWindow(oslo(t, tt.at))
+			require.NoError(t, err)
+			assert.Equal(t, tt.inWindow, inWindow, tt.at)
+		}
+	})
+
+	t.Run("next window start should skip weekends and holidays", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := newBusinessHoursSchedule()
+
+		tests := []struct {
+			at   string
+			next string
+		}{
+			{"2026-03-02 06:00", "2026-03-02 08:00"},
+			{"2026-03-02 12:00", "2026-03-02 12:00"},
+			{"2026-03-03 16:00", "2026-03-05 08:00"}, // Wednesday is a holiday
+			{"2026-03-06 23:00", "2026-03-09 08:00"}, // Friday night to Monday morning
+		}
+
+		for _, tt := range tests {
+			next, err := schedule.NextWindowStart(oslo(t, tt.at))
+			require.NoError(t, err)
+			assert.True(t, oslo(t, tt.next).Equal(next), "%s: expected %s, got %s", tt.at, tt.next, next)
+		}
+	})
+
+	t.Run("times should be compared in the schedule time zone", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := newBusinessHoursSchedule()
+
+		// 07:30 UTC is 08:30 in Oslo
+		inWindow, err := schedule.InWindow(time.Date(2026, 3, 2, 7, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.True(t, inWindow)
+
+		// The UTC time zone is used by default
+		schedule.TimeZone = ""
+
+		inWindow, err = schedule.InWindow(time.Date(2026, 3, 2, 7, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.False(t, inWindow)
+	})
+
+	t.Run("windows should apply every day without weekdays, and end at midnight with 24:00", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := &types.EscalationSchedule{Windows: []*types.EscalationWindow{{Start: "22:00", End: "24:00"}}}
+
+		next, err := schedule.NextWindowStart(time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC), next)
+
+		next, err = schedule.NextWindowStart(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 8, 22, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("the earliest of several windows should be used", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := &types.EscalationSchedule{Windows: []*types.EscalationWindow{
+			{Weekdays: []string{"monday"}, Start: "12:00", End: "13:00"},
+			{Weekdays: []string{"monday"}, Start: "09:00", End: "10:00"},
+		}}
+
+		next, err := schedule.NextWindowStart(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), next)
+
+		next, err = schedule.NextWindowStart(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("schedule without windows in a year should fail", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := &types.EscalationSchedule{Windows: []*types.EscalationWindow{{Weekdays: []string{"monday"}, Start: "09:00", End: "10:00"}}}
+
+		for day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); day.Year() < 2028; day = day.AddDate(0, 0, 7) {
+			schedule.Holidays = append(schedule.Holidays, day.Format("2006-01-02"))
+		}
+
+		_, err := schedule.NextWindowStart(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+		require.ErrorContains(t, err, "has no window within")
+	})
+
+	t.Run("invalid schedules should fail", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			schedule *types.EscalationSchedule
+			err      string
+		}{
+			{&types.EscalationSchedule{TimeZone: "Mars/Olympus", Windows: []*types.EscalationWindow{{Start: "08:00", End: "16:00"}}}, "invalid escalation schedule time zone"},
+			{&types.EscalationSchedule{}, "has no windows"},
+			{&types.EscalationSchedule{Windows: []*types.EscalationWindow{nil}}, "windows[0] is nil"},
+			{&types.EscalationSchedule{Windows: []*types.EscalationWindow{{Start: "8:00", End: "16:00"}}}, "windows[0].start"},
+			{&types.EscalationSchedule{Windows: []*types.EscalationWindow{{Start: "24:00", End: "24:00"}}}, "windows[0].start"},
+			{&types.EscalationSchedule{Windows: []*types.EscalationWindow{{Start: "16:00", End: "08:00"}}}, "windows[0].end"},
+			{&types.EscalationSchedule{Windows: []*types.EscalationWindow{{Weekdays: []string{"mon"}, Start: "08:00", End: "16:00"}}}, `invalid weekday "mon"`},
+			{&types.EscalationSchedule{Windows: []*types.EscalationWindow{{Start: "08:00", End: "16:00"}}, Holidays: []string{"25.12.2026"}}, `holiday "25.12.2026"`},
+		}
+
+		for _, tt := range tests {
+			_, err := tt.schedule.InWindow(time.Now())
+			require.ErrorContains(t, err, tt.err)
+
+			_, err = tt.schedule.NextWindowStart(time.Now())
+			require.ErrorContains(t, err, tt.err)
+		}
+	})
+}
+
+func TestEvaluateEscalation(t *testing.T) {
+	t.Parallel()
+
+	// Friday 20:00 in Oslo, after business hours
+	created := oslo(t, "2026-03-06 20:00")
+
+	newEscalation := func(schedule *types.EscalationSchedule) *types.Escalation {
+		return &types.Escalation{
+			Severity:      types.AlertPanic,
+			DelaySeconds:  3600,
+			SlackMentions: []string{"<!channel>"},
+			Schedule:      schedule,
+		}
+	}
+
+	t.Run("escalation should be pending until due", func(t *testing.T) {
+		t.Parallel()
+
+		decision, err := types.EvaluateEscalation(newEscalation(nil), created, types.FixedClock(created.Add(59*time.Minute)))
+		require.NoError(t, err)
+		assert.Equal(t, &types.EscalationDecision{Action: types.EscalationActionPending, At: created.Add(time.Hour)}, decision)
+	})
+
+	t.Run("escalation without schedule should fire when due", func(t *testing.T) {
+		t.Parallel()
+
+		decision, err := types.EvaluateEscalation(newEscalation(nil), created, types.FixedClock(created.Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Equal(t, &types.EscalationDecision{Action: types.EscalationActionFire, At: created.Add(time.Hour), Mentions: []string{"<!channel>"}}, decision)
+	})
+
+	t.Run("escalation due inside a window should fire when due", func(t *testing.T) {
+		t.Parallel()
+
+		created := oslo(t, "2026-03-02 09:00")
+
+		decision, err := types.EvaluateEscalation(newEscalation(newBusinessHoursSchedule()), created, types.FixedClock(created.Add(2*time.Hour)))
+		require.NoError(t, err)
+		assert.Equal(t, types.EscalationActionFire, decision.Action)
+		assert.True(t, created.Add(time.Hour).Equal(decision.At))
+		assert.Equal(t, []string{"<!channel>"}, decision.Mentions)
+	})
+
+	t.Run("escalation due outside the windows should be deferred to the next window", func(t *testing.T) {
+		t.Parallel()
+
+		escalation := newEscalation(newBusinessHoursSchedule())
+		monday := oslo(t, "2026-03-09 08:00")
+
+		decision, err := types.EvaluateEscalation(escalation, created, types.FixedClock(oslo(t, "2026-03-07 02:00")))
+		require.NoError(t, err)
+		assert.Equal(t, types.EscalationActionDefer, decision.Action)
+		assert.True(t, monday.Equal(decision.At))
+		assert.Empty(t, decision.Mentions)
+
+		// Once the window has started, the deferred escalation fires
+		decision, err = types.EvaluateEscalation(escalation, created, types.FixedClock(oslo(t, "2026-03-09 08:15")))
+		require.NoError(t, err)
+		assert.Equal(t, types.EscalationActionFire, decision.Action)
+		assert.True(t, monday.Equal(decision.At))
+		assert.Equal(t, []string{"<!channel>"}, decision.Mentions)
+	})
+
+	t.Run("escalation due outside the windows should use the alternate mentions", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := newBusinessHoursSchedule()
+		schedule.OutsideWindows = types.EscalationScheduleAlternate
+		schedule.AlternateMentions = []string{"<@U0123456789>"}
+
+		decision, err := types.EvaluateEscalation(newEscalation(schedule), created, types.FixedClock(created.Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Equal(t, &types.EscalationDecision{Action: types.EscalationActionAlternate, At: created.Add(time.Hour), Mentions: []string{"<@U0123456789>"}}, decision)
+
+		created := oslo(t, "2026-03-02 09:00")
+
+		decision, err = types.EvaluateEscalation(newEscalation(schedule), created, types.FixedClock(created.Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Equal(t, types.EscalationActionFire, decision.Action)
+		assert.Equal(t, []string{"<!channel>"}, decision.Mentions)
+	})
+
+	t.Run("evaluation should be deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		clock := types.FixedClock(oslo(t, "2026-03-07 12:00"))
+
+		first, err := types.EvaluateEscalation(newEscalation(newBusinessHoursSchedule()), created, clock)
+		require.NoError(t, err)
+
+		second, err := types.EvaluateEscalation(newEscalation(newBusinessHoursSchedule()), created, clock)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("nil clock should use the system clock", func(t *testing.T) {
+		t.Parallel()
+
+		decision, err := types.EvaluateEscalation(newEscalation(nil), time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, types.EscalationActionPending, decision.Action)
+	})
+
+	t.Run("invalid input should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.EvaluateEscalation(nil, created, nil)
+		require.ErrorContains(t, err, "escalation is nil")
+
+		_, err = types.EvaluateEscalation(newEscalation(&types.EscalationSchedule{}), created, types.FixedClock(created.Add(time.Hour)))
+		require.ErrorContains(t, err, "has no windows")
+	})
+}
+
+func TestValidateEscalationSchedule(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(schedule *types.EscalationSchedule) *types.Alert {
+		a := types.NewPanicAlert()
+		a.Header = "header"
+		a.Escalation = []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, Schedule: schedule}}
+
+		return a
+	}
+
+	t.Run("valid schedule should pass", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := newBusinessHoursSchedule()
+		schedule.OutsideWindows = types.EscalationScheduleAlternate
+		schedule.AlternateMentions = []string{"<!here>"}
+
+		require.NoError(t, newAlert(schedule).ValidateEscalation())
+	})
+
+	t.Run("invalid schedule should fail", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := &types.EscalationSchedule{
+			TimeZone: "Mars/Olympus",
+			Windows: []*types.EscalationWindow{
+				nil,
+				{Weekdays: []string{"mon"}, Start: "8:00", End: "25:00"},
+				{Start: "16:00", End: "08:00"},
+			},
+			Holidays:          []string{"2026-02-30"},
+			OutsideWindows:    "drop",
+			AlternateMentions: []string{"@here"},
+		}
+
+		err := newAlert(schedule).ValidateAll()
+
+		var validationErrs types.ValidationErrors
+		require.True(t, errors.As(err, &validationErrs))
+
+		codes := make(map[string]types.ValidationErrorCode)
+		for _, e := range validationErrs {
+			codes[e.Path] = e.Code
+		}
+
+		assert.Equal(t, map[string]types.ValidationErrorCode{
+			"escalation[0].schedule.timeZone":               types.ValidationErrorCodeInvalidValue,
+			"escalation[0].schedule.windows[0]":             types.ValidationErrorCodeRequired,
+			"escalation[0].schedule.windows[1].weekdays[0]": types.ValidationErrorCodeInvalidValue,
+			"escalation[0].schedule.windows[1].start":       types.ValidationErrorCodeInvalidFormat,
+			"escalation[0].schedule.windows[1].end":         types.ValidationErrorCodeInvalidFormat,
+			"escalation[0].schedule.windows[2].end":         types.ValidationErrorCodeInvalidValue,
+			"escalation[0].schedule.holidays[0]":            types.ValidationErrorCodeInvalidFormat,
+			"escalation[0].schedule.outsideWindows":         types.ValidationErrorCodeInvalidValue,
+			"escalation[0].schedule.alternateMentions[0]":   types.ValidationErrorCodeInvalidFormat,
+		}, codes)
+	})
+
+	t.Run("schedule without windows should fail", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorContains(t, newAlert(&types.EscalationSchedule{}).ValidateEscalation(), "escalation[0].schedule.windows must contain at least one window")
+	})
+
+	t.Run("too many windows, holidays and mentions should fail", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxEscalationWindowCount = 1
+		limits.MaxEscalationHolidayCount = 1
+		limits.MaxEscalationSlackMentionCount = 1
+
+		schedule := newBusinessHoursSchedule()
+		schedule.Windows = append(schedule.Windows, schedule.Windows[0])
+		schedule.Holidays = append(schedule.Holidays, "2026-12-25")
+		schedule.AlternateMentions = []string{"<!here>", "<!channel>"}
+
+		err := newAlert(schedule).ValidateAllWith(limits)
+
+		var validationErrs types.ValidationErrors
+		require.True(t, errors.As(err, &validationErrs))
+		require.Len(t, validationErrs, 3)
+
+		for _, e := range validationErrs {
+			assert.Equal(t, types.ValidationErrorCodeTooMany, e.Code, e.Path)
+		}
+	})
+
+	t.Run("schedule should be cleaned", func(t *testing.T) {
+		t.Parallel()
+
+		schedule := &types.EscalationSchedule{
+			TimeZone:          " Europe/Oslo ",
+			Windows:           []*types.EscalationWindow{nil, {Weekdays: []string{" Monday "}, Start: " 08:00", End: "16:00 "}},
+			Holidays:          []string{" 2026-12-25 "},
+			OutsideWindows:    " Alternate ",
+			AlternateMentions: []string{" <!here> "},
+		}
+
+		a := newAlert(schedule)
+		a.Clean()
+
+		assert.Equal(t, &types.EscalationSchedule{
+			TimeZone:          "Europe/Oslo",
+			Windows:           []*types.EscalationWindow{nil, {Weekdays: []string{"monday"}, Start: "08:00", End: "16:00"}},
+			Holidays:          []string{"2026-12-25"},
+			OutsideWindows:    types.EscalationScheduleAlternate,
+			AlternateMentions: []string{"<!here>"},
+		}, a.Escalation[0].Schedule)
+	})
+}
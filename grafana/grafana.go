@@ -0,0 +1,209 @@
+// Package grafana converts Grafana unified alerting webhook payloads (from a webhook contact point) to Slack Manager alerts.
+//
+// Usage in an HTTP handler:
+//
+//	msg, err := grafana.Decode(r.Body)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//
+//	for _, alert := range grafana.Convert(msg, grafana.DefaultConfig()) {
+//	    alert.Clean()
+//	    if err := alert.Validate(); err != nil {
+//	        // handle error
+//	    }
+//	}
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/mapping"
+)
+
+// SupportedVersion is the Grafana webhook payload version supported by Decode.
+const SupportedVersion = "1"
+
+// Status values used by Grafana, for both messages and individual alerts.
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Message is a Grafana webhook payload.
+// See https://grafana.com/docs/grafana/latest/alerting/configure-notifications/manage-contact-points/integrations/webhook-notifier/
+type Message struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	OrgID             int64             `json:"orgId"`
+	Title             string            `json:"title"`
+	State             string            `json:"state"`
+	Message           string            `json:"message"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []*Alert          `json:"alerts"`
+}
+
+// Alert is a single alert in a Grafana webhook payload.
+type Alert struct {
+	Status       string             `json:"status"`
+	Labels       map[string]string  `json:"labels"`
+	Annotations  map[string]string  `json:"annotations"`
+	StartsAt     time.Time          `json:"startsAt"`
+	EndsAt       time.Time          `json:"endsAt"`
+	Values       map[string]float64 `json:"values"`
+	ValueString  string             `json:"valueString"`
+	GeneratorURL string             `json:"generatorURL"`
+	Fingerprint  string             `json:"fingerprint"`
+	SilenceURL   string             `json:"silenceURL"`
+	DashboardURL string             `json:"dashboardURL"`
+	PanelURL     string             `json:"panelURL"`
+	ImageURL     string             `json:"imageURL"`
+}
+
+// Decode decodes a Grafana webhook payload. An error is returned if the JSON is invalid,
+// or if the payload version is not SupportedVersion.
+func Decode(r io.Reader) (*Message, error) {
+	var msg Message
+
+	if err := json.NewDecoder(r).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("failed to decode Grafana payload: %w", err)
+	}
+
+	if msg.Version != SupportedVersion {
+		return nil, fmt.Errorf("unsupported Grafana payload version '%s', expected '%s'", msg.Version, SupportedVersion)
+	}
+
+	return &msg, nil
+}
+
+// Config defines how Grafana alerts are mapped to Slack Manager alerts. Use DefaultConfig to get the default mapping.
+type Config struct {
+	// Config holds the mapping rules shared with the other converters.
+	mapping.Config
+
+	// ValueFields adds a field for each query/expression value (such as 'B: 22.5'), after the label fields.
+	ValueFields bool
+
+	// LinkWebhooks adds webhook buttons for the dashboard and the silence page, when available. The silence button is
+	// only added for firing alerts, and only shown while the issue is open. Links longer than types.MaxWebhookURLLength are skipped.
+	LinkWebhooks bool
+
+	// Customize is called for each converted alert, and can modify the result further. Ignored if nil.
+	Customize func(alert *types.Alert, source *Alert, msg *Message)
+}
+
+// DefaultConfig returns a new Config instance with the default mapping (see mapping.DefaultConfig),
+// with value fields and link webhooks enabled.
+func DefaultConfig() *Config {
+	return &Config{
+		Config:       *mapping.DefaultConfig(),
+		ValueFields:  true,
+		LinkWebhooks: true,
+	}
+}
+
+// Convert converts each alert in the Grafana message to a Slack Manager alert, using the specified config
+// (or the default config if nil). The returned alerts are not cleaned or validated.
+func Convert(msg *Message, cfg *Config) []*types.Alert {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	alerts := make([]*types.Alert, 0, len(msg.Alerts))
+
+	for _, source := range msg.Alerts {
+		if source == nil {
+			continue
+		}
+
+		alerts = append(alerts, convertAlert(source, msg, cfg))
+	}
+
+	return alerts
+}
+
+func convertAlert(source *Alert, msg *Message, cfg *Config) *types.Alert {
+	alert := types.NewAlert(types.AlertError)
+
+	cfg.Apply(alert, &mapping.Source{
+		Labels:      source.Labels,
+		Annotations: source.Annotations,
+		Resolved:    source.Status == StatusResolved,
+		Receiver:    msg.Receiver,
+	})
+
+	alert.CorrelationID = source.Fingerprint
+
+	// The panel is the most specific link, followed by the dashboard and the alert rule
+	switch {
+	case source.PanelURL != "":
+		alert.Link = source.PanelURL
+	case source.DashboardURL != "":
+		alert.Link = source.DashboardURL
+	default:
+		alert.Link = source.GeneratorURL
+	}
+
+	switch {
+	case source.Status == StatusResolved && !source.EndsAt.IsZero():
+		alert.Timestamp = source.EndsAt
+	case !source.StartsAt.IsZero():
+		alert.Timestamp = source.StartsAt
+	}
+
+	if cfg.ValueFields {
+		for _, name := range slices.Sorted(maps.Keys(source.Values)) {
+			mapping.AppendField(alert, name, strconv.FormatFloat(source.Values[name], 'g', -1, 64))
+		}
+	}
+
+	if cfg.LinkWebhooks {
+		appendLinkWebhook(alert, "dashboard", source.DashboardURL, "Open dashboard", "")
+
+		if source.Status != StatusResolved {
+			appendLinkWebhook(alert, "silence", source.SilenceURL, "Silence alert", types.WebhookDisplayModeOpenIssue)
+		}
+	}
+
+	if msg.State != "" {
+		alert.Metadata["state"] = msg.State
+	}
+
+	if source.ValueString != "" {
+		alert.Metadata["valueString"] = source.ValueString
+	}
+
+	if cfg.Customize != nil {
+		cfg.Customize(alert, source, msg)
+	}
+
+	return alert
+}
+
+// appendLinkWebhook adds a webhook button for url, unless url is empty or too long to be a webhook URL.
+func appendLinkWebhook(alert *types.Alert, id, url, buttonText string, displayMode types.WebhookDisplayMode) {
+	if url == "" || len(url) > types.MaxWebhookURLLength {
+		return
+	}
+
+	alert.Webhooks = append(alert.Webhooks, &types.Webhook{
+		ID:          id,
+		URL:         url,
+		ButtonText:  buttonText,
+		DisplayMode: displayMode,
+	})
+}
@@ -0,0 +1,189 @@
+package grafana_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/grafana"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestConvertGolden(t *testing.T) {
+	t.Parallel()
+
+	payloads, err := filepath.Glob("testdata/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, payloads)
+
+	for _, payload := range payloads {
+		if strings.HasSuffix(payload, ".golden.json") {
+			continue
+		}
+
+		t.Run(filepath.Base(payload), func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(payload)
+			require.NoError(t, err)
+			defer f.Close()
+
+			msg, err := grafana.Decode(f)
+			require.NoError(t, err)
+
+			alerts := grafana.Convert(msg, grafana.DefaultConfig())
+
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			require.NoError(t, enc.Encode(alerts))
+			actual := buf.Bytes()
+
+			golden := strings.TrimSuffix(payload, ".json") + ".golden.json"
+
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o600))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+
+			for _, alert := range alerts {
+				alert.Timestamp = time.Now()
+				alert.Clean()
+				require.NoError(t, alert.Validate())
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported version should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := grafana.Decode(strings.NewReader(`{"version": "3", "alerts": []}`))
+		require.ErrorContains(t, err, "unsupported Grafana payload version '3'")
+	})
+
+	t.Run("invalid JSON should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := grafana.Decode(strings.NewReader(`{"version": `))
+		require.ErrorContains(t, err, "failed to decode Grafana payload")
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	newMessage := func() *grafana.Message {
+		return &grafana.Message{
+			Version:  "1",
+			State:    "alerting",
+			Receiver: "team",
+			Alerts: []*grafana.Alert{
+				{
+					Status:       grafana.StatusFiring,
+					Labels:       map[string]string{"alertname": "Test", "team": "platform"},
+					StartsAt:     time.Now(),
+					Fingerprint:  "fingerprint",
+					GeneratorURL: "https://grafana.example.com/rule",
+					DashboardURL: "https://grafana.example.com/d/1",
+					SilenceURL:   "https://grafana.example.com/silence",
+					Values:       map[string]float64{"B": 0.5, "A": 12},
+				},
+				nil,
+			},
+		}
+	}
+
+	t.Run("links and values should be mapped", func(t *testing.T) {
+		t.Parallel()
+
+		alerts := grafana.Convert(newMessage(), nil)
+		require.Len(t, alerts, 1)
+
+		alert := alerts[0]
+		assert.Equal(t, "Test", alert.Header)
+		assert.Equal(t, "fingerprint", alert.CorrelationID)
+		assert.Equal(t, "https://grafana.example.com/d/1", alert.Link)
+		assert.Equal(t, []*types.Field{
+			{Title: "team", Value: "platform"},
+			{Title: "A", Value: "12"},
+			{Title: "B", Value: "0.5"},
+		}, alert.Fields)
+		assert.Equal(t, []*types.Webhook{
+			{ID: "dashboard", URL: "https://grafana.example.com/d/1", ButtonText: "Open dashboard"},
+			{ID: "silence", URL: "https://grafana.example.com/silence", ButtonText: "Silence alert", DisplayMode: types.WebhookDisplayModeOpenIssue},
+		}, alert.Webhooks)
+		assert.Equal(t, "alerting", alert.Metadata["state"])
+	})
+
+	t.Run("links too long for a webhook should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		msg := newMessage()
+		msg.Alerts[0].SilenceURL = "https://grafana.example.com/alerting/silence/new?matcher=" + strings.Repeat("a", types.MaxWebhookURLLength)
+
+		alerts := grafana.Convert(msg, nil)
+		require.Len(t, alerts[0].Webhooks, 1)
+		assert.Equal(t, "dashboard", alerts[0].Webhooks[0].ID)
+
+		alerts[0].Clean()
+		require.NoError(t, alerts[0].Validate())
+	})
+
+	t.Run("generator URL should be used without panel and dashboard", func(t *testing.T) {
+		t.Parallel()
+
+		msg := newMessage()
+		msg.Alerts[0].DashboardURL = ""
+
+		alerts := grafana.Convert(msg, nil)
+		assert.Equal(t, "https://grafana.example.com/rule", alerts[0].Link)
+	})
+
+	t.Run("value fields and link webhooks can be disabled", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := grafana.DefaultConfig()
+		cfg.ValueFields = false
+		cfg.LinkWebhooks = false
+		cfg.Customize = func(alert *types.Alert, source *grafana.Alert, msg *grafana.Message) {
+			alert.Footer = msg.Receiver + "/" + source.Labels["team"]
+		}
+
+		alerts := grafana.Convert(newMessage(), cfg)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, []*types.Field{{Title: "team", Value: "platform"}}, alerts[0].Fields)
+		assert.Empty(t, alerts[0].Webhooks)
+		assert.Equal(t, "team/platform", alerts[0].Footer)
+	})
+
+	t.Run("shared mapping rules should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := grafana.DefaultConfig()
+		cfg.SeverityLabel = "team"
+		cfg.SeverityMapping = map[string]types.AlertSeverity{"platform": types.AlertPanic}
+		cfg.RouteKey = "route"
+
+		alerts := grafana.Convert(newMessage(), cfg)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, types.AlertPanic, alerts[0].Severity)
+		assert.Equal(t, "route", alerts[0].RouteKey)
+	})
+}
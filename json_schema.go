@@ -0,0 +1,406 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect used by the generated schemas.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a (partial) JSON Schema document, as generated by AlertJSONSchema and WebhookCallbackJSONSchema.
+// Only the keywords needed to describe the types in this package are included.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	Type                 JSONSchemaType         `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	MaxProperties        *int                   `json:"maxProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	If                   *JSONSchema            `json:"if,omitempty"`
+	Then                 *JSONSchema            `json:"then,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// JSONSchemaType is the value of the JSON Schema 'type' keyword.
+// It is encoded as a single string when it contains one type, and as an array otherwise.
+type JSONSchemaType []string
+
+// MarshalJSON encodes a single type as a string, and multiple types as an array.
+func (t JSONSchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON decodes a type from either a string or an array of strings.
+func (t *JSONSchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = JSONSchemaType{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("invalid JSON schema type: %w", err)
+	}
+
+	*t = multiple
+
+	return nil
+}
+
+// AlertJSONSchema returns a JSON Schema (draft 2020-12) describing the Alert JSON format, using the default limits.
+//
+// The schema describes the input accepted by the Slack Manager API: values that Clean truncates (such as Header and Text)
+// have no maximum length, while values that Validate rejects (such as webhook IDs and escalation delays) are constrained.
+// Formats and patterns apply to cleaned values, i.e. without surrounding whitespace.
+// Rules that cannot be expressed in JSON Schema (unique webhook and input IDs, escalation delay spacing, escalation repeat counts,
+// escalation schedule time zones, dates and window order, and the min/max length consistency of text inputs) are only enforced by Validate.
+// Note also that JSON Schema counts string lengths in characters, while Validate counts some lengths in bytes.
+func AlertJSONSchema() *JSONSchema {
+	return AlertJSONSchemaWith(DefaultLimits())
+}
+
+// AlertJSONSchemaWith returns a JSON Schema describing the Alert JSON format, like AlertJSONSchema, using the specified limits.
+// If limits is nil, the default limits are used.
+func AlertJSONSchemaWith(limits *Limits) *JSONSchema {
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	g := &schemaGenerator{
+		limits: limits,
+		defs:   make(map[string]*JSONSchema),
+	}
+
+	schema := g.object(reflect.TypeFor[Alert]())
+	schema.Schema = JSONSchemaDialect
+	schema.Title = "Alert"
+	schema.Properties[AlertSchemaVersionKey] = &JSONSchema{
+		Description: "The wire format version of the alert. Alerts without a schema version are decoded as version 1.",
+		Type:        JSONSchemaType{"integer"},
+		Minimum:     intPtr(AlertSchemaVersion1),
+		Maximum:     intPtr(CurrentAlertSchemaVersion),
+	}
+	schema.Defs = g.defs
+
+	return schema
+}
+
+// WebhookCallbackJSONSchema returns a JSON Schema (draft 2020-12) describing the WebhookCallback JSON format.
+func WebhookCallbackJSONSchema() *JSONSchema {
+	g := &schemaGenerator{
+		limits: DefaultLimits(),
+		defs:   make(map[string]*JSONSchema),
+	}
+
+	schema := g.object(reflect.TypeFor[WebhookCallback]())
+	schema.Schema = JSONSchemaDialect
+	schema.Title = "WebhookCallback"
+
+	return schema
+}
+
+// schemaGenerator generates JSON schemas from struct types, using the struct tags for property names
+// and schemaConstraints for the validation rules.
+type schemaGenerator struct {
+	limits *Limits
+	defs   map[string]*JSONSchema
+}
+
+// object returns the schema for a struct type, with one property per JSON encoded field.
+func (g *schemaGenerator) object(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{
+		Type:       JSONSchemaType{"object"},
+		Properties: make(map[string]*JSONSchema),
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		property := g.value(field.Type)
+
+		if constrain, ok := schemaConstraints[t.Name()+"."+field.Name]; ok {
+			constrain(property, g.limits)
+		}
+
+		schema.Properties[name] = property
+	}
+
+	if constrain, ok := schemaConstraints[t.Name()]; ok {
+		constrain(schema, g.limits)
+	}
+
+	return schema
+}
+
+// value returns the base schema for a Go type, without any validation constraints.
+func (g *schemaGenerator) value(t reflect.Type) *JSONSchema {
+	if t == reflect.TypeFor[time.Time]() {
+		return &JSONSchema{Type: JSONSchemaType{"string"}, Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &JSONSchema{Type: JSONSchemaType{"string"}}
+	case reflect.Bool:
+		return &JSONSchema{Type: JSONSchemaType{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &JSONSchema{Type: JSONSchemaType{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: JSONSchemaType{"number"}}
+	case reflect.Slice:
+		// Nil slices are encoded as null
+		return &JSONSchema{Type: JSONSchemaType{"array", "null"}, Items: g.value(t.Elem())}
+	case reflect.Map:
+		// Nil maps are encoded as null
+		schema := &JSONSchema{Type: JSONSchemaType{"object", "null"}}
+		if t.Elem().Kind() != reflect.Interface {
+			schema.AdditionalProperties = g.value(t.Elem())
+		}
+		return schema
+	case reflect.Pointer:
+		return g.value(t.Elem())
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // Reserve the name before recursing
+			g.defs[t.Name()] = g.object(t)
+		}
+		return &JSONSchema{Ref: "#/$defs/" + t.Name()}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// schemaConstraints maps type names ('Type') and field names ('Type.Field') to functions adding validation
+// constraints to the generated schema. The constraints mirror the rules in Alert.Validate.
+var schemaConstraints = map[string]func(s *JSONSchema, l *Limits){
+	"Alert": func(s *JSONSchema, l *Limits) {
+		s.AnyOf = []*JSONSchema{
+			{Required: []string{"header"}, Properties: map[string]*JSONSchema{"header": {MinLength: intPtr(1)}}},
+			{Required: []string{"text"}, Properties: map[string]*JSONSchema{"text": {MinLength: intPtr(1)}}},
+		}
+		s.If = &JSONSchema{
+			Required:   []string{"issueFollowUpEnabled"},
+			Properties: map[string]*JSONSchema{"issueFollowUpEnabled": {Const: true}},
+		}
+		s.Then = &JSONSchema{
+			Required:   []string{"autoResolveSeconds"},
+			Properties: map[string]*JSONSchema{"autoResolveSeconds": {Minimum: intPtr(l.MinAutoResolveSeconds), Maximum: intPtr(l.MaxAutoResolveSeconds)}},
+		}
+	},
+	"Alert.SlackChannelID": func(s *JSONSchema, l *Limits) {
+		s.Pattern = `^[0-9a-zA-Z\-_]*$`
+		s.MaxLength = intPtr(l.MaxSlackChannelIDLength)
+	},
+	"Alert.RouteKey": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxRouteKeyLength)
+	},
+	"Alert.IconEmoji": func(s *JSONSchema, l *Limits) {
+		s.Pattern = fmt.Sprintf(`^(:[^:]{1,%d}:)?$`, l.MaxIconEmojiLength)
+	},
+	"Alert.Link": func(s *JSONSchema, _ *Limits) {
+		s.AnyOf = []*JSONSchema{{MaxLength: intPtr(0)}, {Format: "uri", Pattern: `^[a-zA-Z][a-zA-Z0-9+.\-]*:`}}
+	},
+	"Alert.Severity": func(s *JSONSchema, _ *Limits) {
+		// Empty and 'critical' are accepted, and converted to 'error' by Clean
+		s.Enum = append(ValidSeverities(), "", "critical")
+	},
+	"Alert.CorrelationID": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxCorrelationIDLength)
+	},
+	"Alert.Fields": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxFieldCount)
+		// Nil fields are ignored, unlike nil webhooks and escalation points
+		s.Items = &JSONSchema{AnyOf: []*JSONSchema{{Type: JSONSchemaType{"null"}}, s.Items}}
+	},
+	"Alert.Escalation": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxEscalationCount)
+	},
+	"Alert.EscalationPolicy": func(s *JSONSchema, l *Limits) {
+		s.Pattern = `^[0-9a-zA-Z\-_.]*$`
+		s.MaxLength = intPtr(l.MaxEscalationPolicyIDLength)
+	},
+	"Alert.IgnoreIfTextContains": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxIgnoreIfTextContainsCount)
+		s.Items.MaxLength = intPtr(l.MaxIgnoreIfTextContainsLength)
+	},
+	"Alert.Webhooks": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxWebhookCount)
+	},
+	"Alert.FailOnRateLimitError": func(s *JSONSchema, _ *Limits) {
+		s.Deprecated = true
+	},
+	"Escalation.Severity": func(s *JSONSchema, _ *Limits) {
+		s.Enum = []string{string(AlertPanic), string(AlertError), string(AlertWarning)}
+	},
+	"Escalation.DelaySeconds": func(s *JSONSchema, l *Limits) {
+		s.Minimum = intPtr(l.MinEscalationDelaySeconds)
+	},
+	"Escalation.SlackMentions": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxEscalationSlackMentionCount)
+		s.Items.AnyOf = mentionSchemas(l)
+	},
+	"Escalation.RepeatSeconds": func(s *JSONSchema, l *Limits) {
+		s.Minimum = intPtr(0)
+		s.AnyOf = []*JSONSchema{{Maximum: intPtr(0)}, {Minimum: intPtr(l.MinEscalationDelayDiffSeconds)}}
+	},
+	"Escalation.MaxRepeats": func(s *JSONSchema, l *Limits) {
+		s.Minimum = intPtr(0)
+		s.Maximum = intPtr(l.MaxEscalationRepeatCount)
+	},
+	"Escalation.MoveToChannel": func(s *JSONSchema, l *Limits) {
+		s.Pattern = `^[0-9a-zA-Z\-_]*$`
+		s.MaxLength = intPtr(l.MaxSlackChannelIDLength)
+	},
+	"EscalationSchedule": func(s *JSONSchema, _ *Limits) {
+		s.Required = []string{"windows"}
+	},
+	"EscalationSchedule.Windows": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxEscalationWindowCount)
+	},
+	"EscalationSchedule.Holidays": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxEscalationHolidayCount)
+		s.Items.Format = "date"
+		s.Items.Pattern = `^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+	},
+	"EscalationSchedule.OutsideWindows": func(s *JSONSchema, _ *Limits) {
+		s.Enum = append(ValidEscalationScheduleModes(), "")
+	},
+	"EscalationSchedule.AlternateMentions": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxEscalationSlackMentionCount)
+		s.Items.AnyOf = mentionSchemas(l)
+	},
+	"EscalationWindow": func(s *JSONSchema, _ *Limits) {
+		s.Required = []string{"start", "end"}
+	},
+	"EscalationWindow.Weekdays": func(s *JSONSchema, _ *Limits) {
+		s.Items.Enum = validEscalationWeekdays()
+	},
+	"EscalationWindow.Start": func(s *JSONSchema, _ *Limits) {
+		s.Pattern = `^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	},
+	"EscalationWindow.End": func(s *JSONSchema, _ *Limits) {
+		s.Pattern = `^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`
+	},
+	"Webhook": func(s *JSONSchema, _ *Limits) {
+		s.Required = []string{"id", "url", "buttonText"}
+	},
+	"Webhook.ID": func(s *JSONSchema, l *Limits) {
+		s.MinLength = intPtr(1)
+		s.MaxLength = intPtr(l.MaxWebhookIDLength)
+	},
+	"Webhook.URL": func(s *JSONSchema, l *Limits) {
+		// Either an absolute http(s) URL, or a custom handler identifier in printable ASCII
+		s.MinLength = intPtr(1)
+		s.MaxLength = intPtr(l.MaxWebhookURLLength)
+		s.Pattern = `^[\x20-\x7E]+$`
+	},
+	"Webhook.ButtonText": func(s *JSONSchema, l *Limits) {
+		s.MinLength = intPtr(1)
+		s.MaxLength = intPtr(l.MaxWebhookButtonTextLength)
+	},
+	"Webhook.ConfirmationText": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxWebhookConfirmationTextLength)
+	},
+	"Webhook.ButtonStyle": func(s *JSONSchema, _ *Limits) {
+		s.Enum = append(ValidWebhookButtonStyles(), "", "default")
+	},
+	"Webhook.AccessLevel": func(s *JSONSchema, _ *Limits) {
+		s.Enum = append(ValidWebhookAccessLevels(), "")
+	},
+	"Webhook.DisplayMode": func(s *JSONSchema, _ *Limits) {
+		s.Enum = append(ValidWebhookDisplayModes(), "")
+	},
+	"Webhook.Payload": func(s *JSONSchema, l *Limits) {
+		s.MaxProperties = intPtr(l.MaxWebhookPayloadCount)
+	},
+	"Webhook.PlainTextInput": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxWebhookPlainTextInputCount)
+	},
+	"Webhook.CheckboxInput": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxWebhookCheckboxInputCount)
+	},
+	"WebhookPlainTextInput": func(s *JSONSchema, _ *Limits) {
+		s.Required = []string{"id"}
+	},
+	"WebhookPlainTextInput.ID": func(s *JSONSchema, l *Limits) {
+		s.MinLength = intPtr(1)
+		s.MaxLength = intPtr(l.MaxWebhookInputIDLength)
+	},
+	"WebhookPlainTextInput.Description": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxWebhookInputDescriptionLength)
+	},
+	"WebhookPlainTextInput.MinLength": func(s *JSONSchema, l *Limits) {
+		s.Minimum = intPtr(0)
+		s.Maximum = intPtr(l.MaxWebhookInputTextLength)
+	},
+	"WebhookPlainTextInput.MaxLength": func(s *JSONSchema, l *Limits) {
+		s.Minimum = intPtr(0)
+		s.Maximum = intPtr(l.MaxWebhookInputTextLength)
+	},
+	"WebhookPlainTextInput.InitialValue": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxWebhookInputTextLength)
+	},
+	"WebhookCheckboxInput": func(s *JSONSchema, _ *Limits) {
+		s.Required = []string{"id"}
+	},
+	"WebhookCheckboxInput.ID": func(s *JSONSchema, l *Limits) {
+		s.MinLength = intPtr(1)
+		s.MaxLength = intPtr(l.MaxWebhookInputIDLength)
+	},
+	"WebhookCheckboxInput.Label": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxWebhookInputLabelLength)
+	},
+	"WebhookCheckboxInput.Options": func(s *JSONSchema, l *Limits) {
+		s.MaxItems = intPtr(l.MaxWebhookCheckboxOptionCount)
+	},
+	"WebhookCheckboxOption": func(s *JSONSchema, _ *Limits) {
+		s.Required = []string{"value"}
+	},
+	"WebhookCheckboxOption.Value": func(s *JSONSchema, l *Limits) {
+		s.MinLength = intPtr(1)
+		s.MaxLength = intPtr(l.MaxCheckboxOptionValueLength)
+	},
+	"WebhookCheckboxOption.Text": func(s *JSONSchema, l *Limits) {
+		s.MaxLength = intPtr(l.MaxWebhookCheckboxOptionTextLength)
+	},
+}
+
+// mentionSchemas returns the schemas of the mention formats accepted by ParseMention.
+func mentionSchemas(l *Limits) []*JSONSchema {
+	return []*JSONSchema{
+		{Pattern: fmt.Sprintf(`^((<!here>)|(<!channel>)|(<@[^>\s]{1,%d}>)|(<!subteam\^[^>\s]{1,%d}>))$`, l.MaxMentionLength, l.MaxMentionLength)},
+		{Pattern: `^[^\s@<>]+@[^\s@<>]+\.[^\s@<>]+$`, MaxLength: intPtr(l.MaxMentionEmailLength)},
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
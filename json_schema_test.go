@@ -0,0 +1,417 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	t.Run("schema should round-trip through JSON", func(t *testing.T) {
+		t.Parallel()
+
+		schema := types.AlertJSONSchema()
+		assert.Equal(t, types.JSONSchemaDialect, schema.Schema)
+
+		data, err := json.Marshal(schema)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"type":"object"`)
+		assert.Contains(t, string(data), `"type":["array","null"]`)
+
+		var decoded types.JSONSchema
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, schema, &decoded)
+	})
+
+	t.Run("every JSON field should have a property", func(t *testing.T) {
+		t.Parallel()
+
+		schema := types.AlertJSONSchema()
+
+		assertProperties(t, schema, reflect.TypeFor[types.Alert](), types.AlertSchemaVersionKey)
+
+		for _, typ := range []reflect.Type{
+			reflect.TypeFor[types.Field](),
+			reflect.TypeFor[types.Escalation](),
+			reflect.TypeFor[types.EscalationSchedule](),
+			reflect.TypeFor[types.EscalationWindow](),
+			reflect.TypeFor[types.Webhook](),
+			reflect.TypeFor[types.WebhookPlainTextInput](),
+			reflect.TypeFor[types.WebhookCheckboxInput](),
+			reflect.TypeFor[types.WebhookCheckboxOption](),
+		} {
+			def, ok := schema.Defs[typ.Name()]
+			require.True(t, ok, "missing definition for %s", typ.Name())
+			assertProperties(t, def, typ)
+		}
+	})
+
+	t.Run("enums should match the enum helpers", func(t *testing.T) {
+		t.Parallel()
+
+		schema := types.AlertJSONSchema()
+		webhook := schema.Defs["Webhook"]
+
+		assert.Subset(t, schema.Properties["severity"].Enum, types.ValidSeverities())
+		assert.Subset(t, webhook.Properties["buttonStyle"].Enum, types.ValidWebhookButtonStyles())
+		assert.Subset(t, webhook.Properties["accessLevel"].Enum, types.ValidWebhookAccessLevels())
+		assert.Subset(t, webhook.Properties["displayMode"].Enum, types.ValidWebhookDisplayModes())
+		assert.Subset(t, schema.Defs["EscalationSchedule"].Properties["outsideWindows"].Enum, types.ValidEscalationScheduleModes())
+	})
+
+	t.Run("limits should match the default limits", func(t *testing.T) {
+		t.Parallel()
+
+		schema := types.AlertJSONSchema()
+
+		assert.Equal(t, types.MaxSlackChannelIDLength, *schema.Properties["slackChannelId"].MaxLength)
+		assert.Equal(t, types.MaxFieldCount, *schema.Properties["fields"].MaxItems)
+		assert.Equal(t, types.MaxWebhookCount, *schema.Properties["webhooks"].MaxItems)
+		assert.Equal(t, types.MaxEscalationCount, *schema.Properties["escalation"].MaxItems)
+		assert.Equal(t, types.MaxWebhookIDLength, *schema.Defs["Webhook"].Properties["id"].MaxLength)
+		assert.Equal(t, types.MinEscalationDelaySeconds, *schema.Defs["Escalation"].Properties["delaySeconds"].Minimum)
+	})
+
+	t.Run("custom limits should be used", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxFieldCount = 3
+		limits.MaxWebhookIDLength = 10
+
+		schema := types.AlertJSONSchemaWith(limits)
+		assert.Equal(t, 3, *schema.Properties["fields"].MaxItems)
+		assert.Equal(t, 10, *schema.Defs["Webhook"].Properties["id"].MaxLength)
+
+		assert.Equal(t, types.AlertJSONSchema(), types.AlertJSONSchemaWith(nil))
+	})
+
+	t.Run("schema should agree with Validate", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name  string
+			alert *types.Alert
+		}{
+			{"minimal alert", &types.Alert{Header: "header"}},
+			{"text only", &types.Alert{Text: "text"}},
+			{"missing header and text", &types.Alert{SlackChannelID: "C12345678"}},
+			{"valid channel", &types.Alert{Header: "a", SlackChannelID: "C12345678"}},
+			{"invalid channel", &types.Alert{Header: "a", SlackChannelID: "C1234#5678"}},
+			{"channel too long", &types.Alert{Header: "a", SlackChannelID: strings.Repeat("C", types.MaxSlackChannelIDLength+1)}},
+			{"route key too long", &types.Alert{Header: "a", RouteKey: strings.Repeat("r", types.MaxRouteKeyLength+1)}},
+			{"valid icon", &types.Alert{Header: "a", IconEmoji: ":status:"}},
+			{"invalid icon", &types.Alert{Header: "a", IconEmoji: "status"}},
+			{"icon too long", &types.Alert{Header: "a", IconEmoji: ":" + strings.Repeat("x", types.MaxIconEmojiLength+1) + ":"}},
+			{"valid link", &types.Alert{Header: "a", Link: "https://example.com/alerts/1"}},
+			{"invalid link", &types.Alert{Header: "a", Link: "/alerts/1"}},
+			{"valid severity", &types.Alert{Header: "a", Severity: types.AlertWarning}},
+			{"invalid severity", &types.Alert{Header: "a", Severity: "fatal"}},
+			{"correlation id too long", &types.Alert{Header: "a", CorrelationID: strings.Repeat("c", types.MaxCorrelationIDLength+1)}},
+			{"valid auto resolve", &types.Alert{Header: "a", IssueFollowUpEnabled: true, AutoResolveSeconds: 3600}},
+			{"auto resolve too low", &types.Alert{Header: "a", IssueFollowUpEnabled: true, AutoResolveSeconds: 1}},
+			{"auto resolve too high", &types.Alert{Header: "a", IssueFollowUpEnabled: true, AutoResolveSeconds: types.MaxAutoResolveSeconds + 1}},
+			{"auto resolve without follow-up", &types.Alert{Header: "a", AutoResolveSeconds: 1}},
+			{"too many fields", &types.Alert{Header: "a", Fields: make([]*types.Field, types.MaxFieldCount+1)}},
+			{"nil field", &types.Alert{Header: "a", Fields: []*types.Field{nil}}},
+			{"too many ignore patterns", &types.Alert{Header: "a", IgnoreIfTextContains: make([]string, types.MaxIgnoreIfTextContainsCount+1)}},
+			{"ignore pattern too long", &types.Alert{Header: "a", IgnoreIfTextContains: []string{strings.Repeat("i", types.MaxIgnoreIfTextContainsLength+1)}}},
+			{"valid escalation", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"<!here>", "<@U12345>"}, MoveToChannel: "C123"}}}},
+			{"escalation delay too low", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 1}}}},
+			{"escalation severity invalid", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertInfo, DelaySeconds: 60}}}},
+			{"escalation user group mention", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"<!subteam^S0123456789>"}}}}},
+			{"escalation user group mention too long", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"<!subteam^" + strings.Repeat("S", types.MaxMentionLength+1) + ">"}}}}},
+			{"escalation email mention", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"jane.doe@example.com"}}}}},
+			{"escalation email mention too long", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{strings.Repeat("j", types.MaxMentionEmailLength) + "@example.com"}}}}},
+			{"escalation email mention invalid", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"jane@example"}}}}},
+			{"escalation mention invalid", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"@here"}}}}},
+			{"escalation channel invalid", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, MoveToChannel: "#general"}}}},
+			{"valid escalation repeat", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, RepeatSeconds: types.MinEscalationDelayDiffSeconds, MaxRepeats: 3}}}},
+			{"escalation repeat too low", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, RepeatSeconds: 1, MaxRepeats: 3}}}},
+			{"escalation max repeats too high", &types.Alert{Header: "a", Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, RepeatSeconds: 60, MaxRepeats: types.MaxEscalationRepeatCount + 1}}}},
+			{"valid escalation policy", &types.Alert{Header: "a", EscalationPolicy: "team-checkout.v2"}},
+			{"escalation policy invalid format", &types.Alert{Header: "a", EscalationPolicy: "team checkout"}},
+			{"escalation policy too long", &types.Alert{Header: "a", EscalationPolicy: strings.Repeat("a", types.MaxEscalationPolicyIDLength+1)}},
+			{"valid escalation schedule", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(nil)}}},
+			{"escalation schedule invalid weekday", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(func(s *types.EscalationSchedule) { s.Windows[0].Weekdays = []string{"mon"} })}}},
+			{"escalation schedule invalid start", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(func(s *types.EscalationSchedule) { s.Windows[0].Start = "8:00" })}}},
+			{"escalation schedule invalid end", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(func(s *types.EscalationSchedule) { s.Windows[0].End = "24:30" })}}},
+			{"escalation schedule invalid holiday", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(func(s *types.EscalationSchedule) { s.Holidays = []string{"25.12.2026"} })}}},
+			{"escalation schedule invalid mode", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(func(s *types.EscalationSchedule) { s.OutsideWindows = "drop" })}}},
+			{"escalation schedule invalid mention", &types.Alert{Header: "a", Escalation: []*types.Escalation{withSchedule(func(s *types.EscalationSchedule) { s.AlternateMentions = []string{"@here"} })}}},
+			{"valid webhook", &types.Alert{Header: "a", Webhooks: []*types.Webhook{validSchemaWebhook()}}},
+			{"webhook missing id", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.ID = "" })}}},
+			{"webhook id too long", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.ID = strings.Repeat("i", types.MaxWebhookIDLength+1) })}}},
+			{"webhook missing url", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.URL = "" })}}},
+			{"webhook custom handler url", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.URL = "my-handler" })}}},
+			{"webhook non-ascii url", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.URL = "håndter" })}}},
+			{"webhook missing button text", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.ButtonText = "" })}}},
+			{"webhook invalid button style", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.ButtonStyle = "green" })}}},
+			{"webhook invalid access level", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.AccessLevel = "everyone" })}}},
+			{"webhook invalid display mode", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.DisplayMode = "never" })}}},
+			{"webhook input missing id", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.PlainTextInput[0].ID = "" })}}},
+			{"webhook input max length too high", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.PlainTextInput[0].MaxLength = types.MaxWebhookInputTextLength + 1 })}}},
+			{"webhook checkbox label too long", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) {
+				w.CheckboxInput[0].Label = strings.Repeat("l", types.MaxWebhookInputLabelLength+1)
+			})}}},
+			{"webhook checkbox option missing value", &types.Alert{Header: "a", Webhooks: []*types.Webhook{withWebhook(func(w *types.Webhook) { w.CheckboxInput[0].Options[0].Value = "" })}}},
+		}
+
+		schema := types.AlertJSONSchema()
+
+		for _, test := range tests {
+			test.alert.Clean()
+			validateErr := test.alert.Validate()
+
+			data, err := json.Marshal(test.alert)
+			require.NoError(t, err)
+
+			var doc any
+			require.NoError(t, json.Unmarshal(data, &doc))
+
+			schemaErr := checkSchema(schema, schema, doc, "")
+
+			if validateErr == nil {
+				assert.NoError(t, schemaErr, test.name)
+			} else {
+				assert.Error(t, schemaErr, "%s: Validate() failed with '%s', but the schema accepted the alert", test.name, validateErr)
+			}
+		}
+	})
+}
+
+func TestWebhookCallbackJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := types.WebhookCallbackJSONSchema()
+	assert.Equal(t, types.JSONSchemaDialect, schema.Schema)
+	assertProperties(t, schema, reflect.TypeFor[types.WebhookCallback]())
+
+	callback := &types.WebhookCallback{
+		ID:            "callback",
+		UserID:        "U12345",
+		Input:         map[string]string{"reason": "maintenance"},
+		CheckboxInput: map[string][]string{"options": {"a", "b"}},
+		Payload:       map[string]any{"count": 1},
+	}
+
+	data, err := json.Marshal(callback)
+	require.NoError(t, err)
+
+	var doc any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.NoError(t, checkSchema(schema, schema, doc, ""))
+
+	doc.(map[string]any)["input"] = map[string]any{"reason": 1}
+	require.Error(t, checkSchema(schema, schema, doc, ""))
+}
+
+func withSchedule(modify func(s *types.EscalationSchedule)) *types.Escalation {
+	e := &types.Escalation{
+		Severity:     types.AlertPanic,
+		DelaySeconds: 60,
+		Schedule: &types.EscalationSchedule{
+			TimeZone:          "UTC",
+			Windows:           []*types.EscalationWindow{{Weekdays: []string{"monday", "friday"}, Start: "08:00", End: "24:00"}},
+			Holidays:          []string{"2026-12-25"},
+			OutsideWindows:    types.EscalationScheduleAlternate,
+			AlternateMentions: []string{"<@U12345>"},
+		},
+	}
+
+	if modify != nil {
+		modify(e.Schedule)
+	}
+
+	return e
+}
+
+func validSchemaWebhook() *types.Webhook {
+	return &types.Webhook{
+		ID:         "hook",
+		URL:        "https://example.com/hook",
+		ButtonText: "Click",
+		PlainTextInput: []*types.WebhookPlainTextInput{
+			{ID: "reason", MaxLength: 100},
+		},
+		CheckboxInput: []*types.WebhookCheckboxInput{
+			{ID: "options", Options: []*types.WebhookCheckboxOption{{Value: "a"}, {Value: "b"}}},
+		},
+	}
+}
+
+func withWebhook(modify func(w *types.Webhook)) *types.Webhook {
+	w := validSchemaWebhook()
+	modify(w)
+	return w
+}
+
+// assertProperties asserts that the schema has a property for every JSON encoded field of typ, and for each of the extra properties.
+func assertProperties(t *testing.T, schema *types.JSONSchema, typ reflect.Type, extra ...string) {
+	t.Helper()
+
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		assert.Contains(t, schema.Properties, name, "%s.%s has no schema property", typ.Name(), typ.Field(i).Name)
+	}
+
+	for _, name := range extra {
+		assert.Contains(t, schema.Properties, name)
+	}
+
+	assert.Len(t, schema.Properties, typ.NumField()+len(extra))
+}
+
+// checkSchema is a minimal JSON Schema validator, supporting the keywords used by the generated schemas.
+// Formats are treated as annotations, as in the draft 2020-12 default.
+//
+//nolint:gocognit,gocyclo,cyclop,funlen
+func checkSchema(root, s *types.JSONSchema, value any, path string) error {
+	if s.Ref != "" {
+		def, ok := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			return fmt.Errorf("%s: unknown reference %s", path, s.Ref)
+		}
+
+		if err := checkSchema(root, def, value, path); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Type) > 0 && !slices.Contains(s.Type, jsonType(value)) {
+		if !(jsonType(value) == "integer" && slices.Contains(s.Type, "number")) {
+			return fmt.Errorf("%s: expected type %v, got %s", path, s.Type, jsonType(value))
+		}
+	}
+
+	if s.Const != nil && !reflect.DeepEqual(s.Const, value) {
+		return fmt.Errorf("%s: expected %v", path, s.Const)
+	}
+
+	if s.Enum != nil && !slices.Contains(s.Enum, fmt.Sprint(value)) {
+		return fmt.Errorf("%s: %v is not one of %v", path, value, s.Enum)
+	}
+
+	if str, ok := value.(string); ok {
+		if s.MinLength != nil && utf8.RuneCountInString(str) < *s.MinLength {
+			return fmt.Errorf("%s: too short", path)
+		}
+
+		if s.MaxLength != nil && utf8.RuneCountInString(str) > *s.MaxLength {
+			return fmt.Errorf("%s: too long", path)
+		}
+
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			return fmt.Errorf("%s: does not match %s", path, s.Pattern)
+		}
+	}
+
+	if num, ok := value.(float64); ok {
+		if s.Minimum != nil && num < float64(*s.Minimum) {
+			return fmt.Errorf("%s: below minimum", path)
+		}
+
+		if s.Maximum != nil && num > float64(*s.Maximum) {
+			return fmt.Errorf("%s: above maximum", path)
+		}
+	}
+
+	if arr, ok := value.([]any); ok {
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			return fmt.Errorf("%s: too many items", path)
+		}
+
+		if s.Items != nil {
+			for i, item := range arr {
+				if err := checkSchema(root, s.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if obj, ok := value.(map[string]any); ok {
+		if s.MaxProperties != nil && len(obj) > *s.MaxProperties {
+			return fmt.Errorf("%s: too many properties", path)
+		}
+
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, name)
+			}
+		}
+
+		for name, v := range obj {
+			property, ok := s.Properties[name]
+			if !ok {
+				property = s.AdditionalProperties
+			}
+
+			if property == nil {
+				continue
+			}
+
+			if err := checkSchema(root, property, v, path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+
+		for _, sub := range s.AnyOf {
+			if checkSchema(root, sub, value, path) == nil {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fmt.Errorf("%s: does not match any of the allowed schemas", path)
+		}
+	}
+
+	if s.If != nil && checkSchema(root, s.If, value, path) == nil && s.Then != nil {
+		return checkSchema(root, s.Then, value, path)
+	}
+
+	return nil
+}
+
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,171 @@
+package types
+
+import "time"
+
+// Limits defines the length, count and timing limits applied when cleaning and validating alerts.
+// Use DefaultLimits to get the standard limits (matching the package constants), and adjust individual
+// limits as needed, e.g. to apply stricter or looser policies for different tenants.
+// Truncated values end with a '...' suffix, unless the length limit is too small to hold it.
+//
+// Note that some limits exist because of Slack API restrictions (such as MaxHeaderLength and MaxWebhookButtonTextLength).
+// Raising those above their default values may cause Slack to reject the resulting posts.
+type Limits struct {
+	// MaxTimestampAge is the maximum age of an alert timestamp, before it is replaced with the current time.
+	MaxTimestampAge time.Duration
+
+	// MaxSlackChannelIDLength is the maximum length of a Slack channel ID or name.
+	MaxSlackChannelIDLength int
+	// MaxRouteKeyLength is the maximum length of a routing key.
+	MaxRouteKeyLength int
+	// MaxHeaderLength is the length at which the header and resolved header are truncated.
+	MaxHeaderLength int
+	// MaxFallbackTextLength is the length at which the fallback text is truncated.
+	MaxFallbackTextLength int
+	// MaxTextLength is the length at which the text and resolved text are truncated.
+	MaxTextLength int
+	// MaxAuthorLength is the length at which the author is truncated.
+	MaxAuthorLength int
+	// MaxHostLength is the length at which the host is truncated.
+	MaxHostLength int
+	// MaxFooterLength is the length at which the footer is truncated.
+	MaxFooterLength int
+	// MaxUsernameLength is the length at which the username is truncated.
+	MaxUsernameLength int
+	// MaxFieldTitleLength is the length at which field titles are truncated.
+	MaxFieldTitleLength int
+	// MaxFieldValueLength is the length at which field values are truncated.
+	MaxFieldValueLength int
+	// MaxIconEmojiLength is the maximum length of the icon emoji (excluding colons).
+	MaxIconEmojiLength int
+	// MaxMentionLength is the maximum length of a Slack user mention (excluding angle brackets).
+	MaxMentionLength int
+	// MaxMentionEmailLength is the maximum length of an email mention.
+	MaxMentionEmailLength int
+	// MaxCorrelationIDLength is the maximum length of the correlation ID.
+	MaxCorrelationIDLength int
+
+	// MinAutoResolveSeconds is the minimum seconds before auto-resolving an issue.
+	MinAutoResolveSeconds int
+	// MaxAutoResolveSeconds is the maximum seconds before auto-resolving an issue.
+	MaxAutoResolveSeconds int
+
+	// MaxIgnoreIfTextContainsLength is the maximum length of each ignore pattern.
+	MaxIgnoreIfTextContainsLength int
+	// MaxIgnoreIfTextContainsCount is the maximum number of ignore patterns per alert.
+	MaxIgnoreIfTextContainsCount int
+
+	// MaxFieldCount is the maximum number of fields per alert.
+	MaxFieldCount int
+
+	// MaxWebhookCount is the maximum number of webhooks per alert.
+	MaxWebhookCount int
+	// MaxWebhookIDLength is the maximum length of a webhook ID.
+	MaxWebhookIDLength int
+	// MaxWebhookURLLength is the maximum length of a webhook URL.
+	MaxWebhookURLLength int
+	// MaxWebhookButtonTextLength is the maximum length of button text.
+	MaxWebhookButtonTextLength int
+	// MaxWebhookConfirmationTextLength is the maximum length of confirmation dialog text.
+	MaxWebhookConfirmationTextLength int
+	// MaxWebhookPayloadCount is the maximum number of key-value pairs in a webhook payload.
+	MaxWebhookPayloadCount int
+	// MaxWebhookPlainTextInputCount is the maximum number of text inputs per webhook.
+	MaxWebhookPlainTextInputCount int
+	// MaxWebhookCheckboxInputCount is the maximum number of checkbox groups per webhook.
+	MaxWebhookCheckboxInputCount int
+	// MaxWebhookInputIDLength is the maximum length of an input field ID.
+	MaxWebhookInputIDLength int
+	// MaxWebhookInputDescriptionLength is the maximum length of an input field description/placeholder.
+	MaxWebhookInputDescriptionLength int
+	// MaxWebhookInputLabelLength is the maximum length of a checkbox group label.
+	MaxWebhookInputLabelLength int
+	// MaxWebhookInputTextLength is the maximum length of text input content.
+	MaxWebhookInputTextLength int
+	// MaxWebhookCheckboxOptionCount is the maximum number of options per checkbox group.
+	MaxWebhookCheckboxOptionCount int
+	// MaxWebhookCheckboxOptionTextLength is the maximum length of checkbox option text.
+	MaxWebhookCheckboxOptionTextLength int
+	// MaxCheckboxOptionValueLength is the maximum length of a checkbox option value.
+	MaxCheckboxOptionValueLength int
+
+	// MaxEscalationCount is the maximum number of escalation points per alert.
+	MaxEscalationCount int
+	// MinEscalationDelaySeconds is the minimum delay before the first escalation triggers.
+	MinEscalationDelaySeconds int
+	// MinEscalationDelayDiffSeconds is the minimum time between consecutive escalations.
+	MinEscalationDelayDiffSeconds int
+	// MaxEscalationSlackMentionCount is the maximum number of Slack mentions per escalation.
+	MaxEscalationSlackMentionCount int
+	// MaxEscalationWindowCount is the maximum number of windows per escalation schedule.
+	MaxEscalationWindowCount int
+	// MaxEscalationHolidayCount is the maximum number of holidays per escalation schedule.
+	MaxEscalationHolidayCount int
+	// MaxEscalationRepeatCount is the maximum number of reminders per escalation.
+	MaxEscalationRepeatCount int
+	// MaxEscalationPolicyIDLength is the maximum length of an escalation policy ID.
+	MaxEscalationPolicyIDLength int
+
+	// MaxAlertBatchSize is the maximum number of alerts in an AlertBatch.
+	MaxAlertBatchSize int
+
+	// EscapeText makes Clean escape text for Slack for all alerts, as if Alert.EscapeText was set. It is false by default.
+	EscapeText bool
+}
+
+// DefaultLimits returns a new Limits instance, with all limits set to the package constant defaults.
+func DefaultLimits() *Limits {
+	return &Limits{
+		MaxTimestampAge: MaxTimestampAge,
+
+		MaxSlackChannelIDLength: MaxSlackChannelIDLength,
+		MaxRouteKeyLength:       MaxRouteKeyLength,
+		MaxHeaderLength:         MaxHeaderLength,
+		MaxFallbackTextLength:   MaxFallbackTextLength,
+		MaxTextLength:           MaxTextLength,
+		MaxAuthorLength:         MaxAuthorLength,
+		MaxHostLength:           MaxHostLength,
+		MaxFooterLength:         MaxFooterLength,
+		MaxUsernameLength:       MaxUsernameLength,
+		MaxFieldTitleLength:     MaxFieldTitleLength,
+		MaxFieldValueLength:     MaxFieldValueLength,
+		MaxIconEmojiLength:      MaxIconEmojiLength,
+		MaxMentionLength:        MaxMentionLength,
+		MaxMentionEmailLength:   MaxMentionEmailLength,
+		MaxCorrelationIDLength:  MaxCorrelationIDLength,
+
+		MinAutoResolveSeconds: MinAutoResolveSeconds,
+		MaxAutoResolveSeconds: MaxAutoResolveSeconds,
+
+		MaxIgnoreIfTextContainsLength: MaxIgnoreIfTextContainsLength,
+		MaxIgnoreIfTextContainsCount:  MaxIgnoreIfTextContainsCount,
+
+		MaxFieldCount: MaxFieldCount,
+
+		MaxWebhookCount:                    MaxWebhookCount,
+		MaxWebhookIDLength:                 MaxWebhookIDLength,
+		MaxWebhookURLLength:                MaxWebhookURLLength,
+		MaxWebhookButtonTextLength:         MaxWebhookButtonTextLength,
+		MaxWebhookConfirmationTextLength:   MaxWebhookConfirmationTextLength,
+		MaxWebhookPayloadCount:             MaxWebhookPayloadCount,
+		MaxWebhookPlainTextInputCount:      MaxWebhookPlainTextInputCount,
+		MaxWebhookCheckboxInputCount:       MaxWebhookCheckboxInputCount,
+		MaxWebhookInputIDLength:            MaxWebhookInputIDLength,
+		MaxWebhookInputDescriptionLength:   MaxWebhookInputDescriptionLength,
+		MaxWebhookInputLabelLength:         MaxWebhookInputLabelLength,
+		MaxWebhookInputTextLength:          MaxWebhookInputTextLength,
+		MaxWebhookCheckboxOptionCount:      MaxWebhookCheckboxOptionCount,
+		MaxWebhookCheckboxOptionTextLength: MaxWebhookCheckboxOptionTextLength,
+		MaxCheckboxOptionValueLength:       MaxCheckboxOptionValueLength,
+
+		MaxEscalationCount:             MaxEscalationCount,
+		MinEscalationDelaySeconds:      MinEscalationDelaySeconds,
+		MinEscalationDelayDiffSeconds:  MinEscalationDelayDiffSeconds,
+		MaxEscalationSlackMentionCount: MaxEscalationSlackMentionCount,
+		MaxEscalationWindowCount:       MaxEscalationWindowCount,
+		MaxEscalationHolidayCount:      MaxEscalationHolidayCount,
+		MaxEscalationRepeatCount:       MaxEscalationRepeatCount,
+		MaxEscalationPolicyIDLength:    MaxEscalationPolicyIDLength,
+
+		MaxAlertBatchSize: MaxAlertBatchSize,
+	}
+}
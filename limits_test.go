@@ -0,0 +1,217 @@
+package types_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultLimits(t *testing.T) {
+	t.Parallel()
+
+	l := types.DefaultLimits()
+	assert.Equal(t, types.MaxTimestampAge, l.MaxTimestampAge)
+	assert.Equal(t, types.MaxHeaderLength, l.MaxHeaderLength)
+	assert.Equal(t, types.MaxTextLength, l.MaxTextLength)
+	assert.Equal(t, types.MaxFieldCount, l.MaxFieldCount)
+	assert.Equal(t, types.MaxWebhookCount, l.MaxWebhookCount)
+	assert.Equal(t, types.MaxEscalationCount, l.MaxEscalationCount)
+	assert.Equal(t, types.MinAutoResolveSeconds, l.MinAutoResolveSeconds)
+	assert.Equal(t, types.MaxEscalationSlackMentionCount, l.MaxEscalationSlackMentionCount)
+	assert.Equal(t, types.MaxEscalationWindowCount, l.MaxEscalationWindowCount)
+	assert.Equal(t, types.MaxEscalationHolidayCount, l.MaxEscalationHolidayCount)
+	assert.Equal(t, types.MaxEscalationRepeatCount, l.MaxEscalationRepeatCount)
+	assert.Equal(t, types.MaxEscalationPolicyIDLength, l.MaxEscalationPolicyIDLength)
+	assert.Equal(t, types.MaxMentionEmailLength, l.MaxMentionEmailLength)
+	assert.Equal(t, types.MaxAlertBatchSize, l.MaxAlertBatchSize)
+
+	// Each call returns a new instance, so modifications do not leak
+	l.MaxHeaderLength = 10
+	assert.Equal(t, types.MaxHeaderLength, types.DefaultLimits().MaxHeaderLength)
+}
+
+func TestCleanWith(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom limits should be used for truncation", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxHeaderLength = 10
+		limits.MaxTextLength = 20
+		limits.MaxFieldValueLength = 5
+
+		a := &types.Alert{
+			Header: "this header is too long",
+			Text:   strings.Repeat("x", 30),
+			Fields: []*types.Field{{Title: "title", Value: "value too long"}},
+		}
+
+		changes := a.CleanWith(limits)
+		assert.Equal(t, "this he...", a.Header)
+		assert.Equal(t, strings.Repeat("x", 17)+"...", a.Text)
+		assert.Equal(t, "va...", a.Fields[0].Value)
+		assert.NotEmpty(t, changes)
+	})
+
+	t.Run("custom timestamp age should be used", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxTimestampAge = time.Hour
+
+		timestamp := time.Now().Add(-2 * time.Hour)
+		a := &types.Alert{Timestamp: timestamp, Header: "a"}
+		a.CleanWith(limits)
+		assert.InDelta(t, time.Now().Unix(), a.Timestamp.Unix(), 1)
+
+		a = &types.Alert{Timestamp: timestamp, Header: "a"}
+		a.Clean()
+		assert.Equal(t, timestamp, a.Timestamp)
+	})
+
+	t.Run("tiny limits should truncate without the suffix", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxHeaderLength = 2
+		limits.MaxTextLength = 5
+		limits.MaxFieldValueLength = 0
+		limits.MaxFallbackTextLength = 2
+
+		a := &types.Alert{
+			Header:       "this header is too long",
+			Text:         "text ```code```",
+			FallbackText: "fallback",
+			Fields:       []*types.Field{{Title: "title", Value: "value"}},
+		}
+
+		a.CleanWith(limits)
+		assert.Equal(t, "th", a.Header)
+		assert.Equal(t, "te...", a.Text)
+		assert.Equal(t, "fa", a.FallbackText)
+		assert.Empty(t, a.Fields[0].Value)
+	})
+
+	t.Run("any small limits should not panic", func(t *testing.T) {
+		t.Parallel()
+
+		for n := -1; n <= 8; n++ {
+			limits := types.DefaultLimits()
+
+			v := reflect.ValueOf(limits).Elem()
+			for i := range v.NumField() {
+				if v.Field(i).Kind() == reflect.Int {
+					v.Field(i).SetInt(int64(n))
+				}
+			}
+
+			a := &types.Alert{
+				Header:         "header <@U12345678> & more",
+				Text:           "text & ```code```",
+				FallbackText:   "fallback",
+				Author:         "author",
+				Host:           "host",
+				Footer:         "footer",
+				Username:       "username",
+				SlackChannelID: "C12345678",
+				EscapeText:     true,
+				Fields:         []*types.Field{{Title: "title", Value: "value"}},
+			}
+
+			assert.NotPanics(t, func() {
+				a.CleanWith(limits)
+				_ = a.ValidateAllWith(limits)
+			}, "limit %d", n)
+		}
+	})
+
+	t.Run("nil limits should use the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: strings.Repeat("x", types.MaxHeaderLength+1)}
+		a.CleanWith(nil)
+		assert.Len(t, a.Header, types.MaxHeaderLength)
+	})
+}
+
+func TestValidateWith(t *testing.T) {
+	t.Parallel()
+
+	t.Run("looser limits should accept more escalation points", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", Escalation: []*types.Escalation{
+			{DelaySeconds: 30, Severity: types.AlertWarning},
+			{DelaySeconds: 60, Severity: types.AlertWarning},
+			{DelaySeconds: 90, Severity: types.AlertError},
+			{DelaySeconds: 120, Severity: types.AlertError},
+			{DelaySeconds: 150, Severity: types.AlertPanic},
+		}}
+		a.Clean()
+
+		require.ErrorContains(t, a.Validate(), "too many escalation points")
+
+		limits := types.DefaultLimits()
+		limits.MaxEscalationCount = 5
+		require.NoError(t, a.ValidateWith(limits))
+		require.NoError(t, a.ValidateAllWith(limits))
+	})
+
+	t.Run("stricter limits should reject more alerts", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Header:               "a",
+			SlackChannelID:       "C12345678",
+			IssueFollowUpEnabled: true,
+			AutoResolveSeconds:   60,
+			Fields:               []*types.Field{{Title: "a"}, {Title: "b"}},
+		}
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		limits := types.DefaultLimits()
+		limits.MaxSlackChannelIDLength = 5
+		limits.MinAutoResolveSeconds = 120
+		limits.MaxFieldCount = 1
+
+		require.ErrorContains(t, a.ValidateWith(limits), "slackChannelId 'C12345678' is not valid")
+
+		var errs types.ValidationErrors
+		require.ErrorAs(t, a.ValidateAllWith(limits), &errs)
+		require.Len(t, errs, 3)
+		assert.Equal(t, types.ValidationErrorCodeTooLong, errs[0].Code)
+		assert.Equal(t, 5, errs[0].Limit)
+		assert.Equal(t, "autoResolveSeconds", errs[1].Path)
+		assert.Equal(t, 120, errs[1].Limit)
+		assert.Equal(t, "fields", errs[2].Path)
+	})
+
+	t.Run("mention and icon lengths should follow the limits", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			Header:     "a",
+			IconEmoji:  ":abcdef:",
+			Escalation: []*types.Escalation{{DelaySeconds: 30, Severity: types.AlertError, SlackMentions: []string{"<@U1234567>", "<!here>"}}},
+		}
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		limits := types.DefaultLimits()
+		limits.MaxIconEmojiLength = 5
+		limits.MaxMentionLength = 7
+
+		var errs types.ValidationErrors
+		require.ErrorAs(t, a.ValidateAllWith(limits), &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "iconEmoji", errs[0].Path)
+		assert.Equal(t, "escalation[0].slackMentions[0]", errs[1].Path)
+	})
+}
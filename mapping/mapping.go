@@ -0,0 +1,195 @@
+// Package mapping provides the label and annotation mapping rules shared by the converter packages
+// (such as alertmanager and grafana), so that all converters can be configured the same way.
+package mapping
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// Config defines how labels and annotations are mapped to Slack Manager alerts. Use DefaultConfig to get the default mapping.
+type Config struct {
+	// SlackChannelID is the Slack channel ID or name used for all alerts, unless overridden by ChannelLabel.
+	SlackChannelID string
+
+	// RouteKey is the route key used for all alerts. If empty, the receiver name is used (if available).
+	RouteKey string
+
+	// ChannelLabel is the label holding the Slack channel ID or name for an alert. Ignored if empty.
+	ChannelLabel string
+
+	// SeverityLabel is the label holding the alert severity.
+	SeverityLabel string
+
+	// SeverityMapping maps (lowercase) severity label values to alert severities.
+	SeverityMapping map[string]types.AlertSeverity
+
+	// DefaultSeverity is the severity used for firing alerts without a (mapped) severity label.
+	DefaultSeverity types.AlertSeverity
+
+	// HeaderAnnotations are the annotations used for the alert header, in order of preference.
+	// If none of them are set, the 'alertname' label is used.
+	HeaderAnnotations []string
+
+	// TextAnnotations are the annotations used for the alert text, in order of preference.
+	TextAnnotations []string
+
+	// FieldLabels are the labels included as alert fields, in the specified order.
+	// If empty, all labels except ExcludedFieldLabels are included, sorted by name.
+	FieldLabels []string
+
+	// ExcludedFieldLabels are the labels never included as alert fields.
+	ExcludedFieldLabels []string
+
+	// AutoResolve is the duration after which an issue is auto-resolved, if no resolved notification is received.
+	AutoResolve time.Duration
+
+	// DisableIssueFollowUp disables issue follow-up, so resolved notifications are posted as separate messages.
+	DisableIssueFollowUp bool
+}
+
+// DefaultConfig returns a new Config instance with the default mapping:
+// severity label values critical, error, warning and info map to the corresponding severities (with 'page' mapping to panic),
+// the summary (or title) annotation is used as header, and the description (or message) annotation as text.
+func DefaultConfig() *Config {
+	return &Config{
+		ChannelLabel:  "slack_channel",
+		SeverityLabel: "severity",
+		SeverityMapping: map[string]types.AlertSeverity{
+			"page":     types.AlertPanic,
+			"panic":    types.AlertPanic,
+			"critical": types.AlertError,
+			"error":    types.AlertError,
+			"warning":  types.AlertWarning,
+			"info":     types.AlertInfo,
+		},
+		DefaultSeverity:     types.AlertError,
+		HeaderAnnotations:   []string{"summary", "title"},
+		TextAnnotations:     []string{"description", "message"},
+		ExcludedFieldLabels: []string{"alertname", "severity", "slack_channel"},
+		AutoResolve:         24 * time.Hour,
+	}
+}
+
+// Source is the converter-independent part of an alert, to which the mapping rules are applied.
+type Source struct {
+	// Labels are the alert labels.
+	Labels map[string]string
+
+	// Annotations are the alert annotations.
+	Annotations map[string]string
+
+	// Resolved is true if the alert is resolved.
+	Resolved bool
+
+	// Receiver is the name of the receiver (contact point) the alert was sent to, if any.
+	Receiver string
+}
+
+// Apply sets the severity, header, text, channel, route key, issue follow-up, fields and metadata of the alert
+// from the source, according to the mapping rules. Labels and annotations are added to the metadata.
+func (c *Config) Apply(alert *types.Alert, source *Source) {
+	alert.Severity = c.Severity(source)
+	alert.Header = firstNonEmpty(source.Annotations, c.HeaderAnnotations)
+	alert.Text = firstNonEmpty(source.Annotations, c.TextAnnotations)
+	alert.SlackChannelID = c.SlackChannelID
+	alert.RouteKey = c.RouteKey
+	alert.IssueFollowUpEnabled = !c.DisableIssueFollowUp
+
+	if alert.Header == "" {
+		alert.Header = source.Labels["alertname"]
+	}
+
+	if alert.RouteKey == "" {
+		alert.RouteKey = source.Receiver
+	}
+
+	if channel := source.Labels[c.ChannelLabel]; c.ChannelLabel != "" && channel != "" {
+		alert.SlackChannelID = channel
+	}
+
+	if alert.IssueFollowUpEnabled {
+		alert.AutoResolveSeconds = int(c.AutoResolve / time.Second)
+	}
+
+	for _, name := range c.fieldLabels(source.Labels) {
+		AppendField(alert, name, source.Labels[name])
+	}
+
+	if alert.Metadata == nil {
+		alert.Metadata = make(map[string]any)
+	}
+
+	alert.Metadata["labels"] = toAnyMap(source.Labels)
+	alert.Metadata["annotations"] = toAnyMap(source.Annotations)
+}
+
+// Severity returns the alert severity for the source: AlertResolved if resolved,
+// otherwise the mapped severity label value, or DefaultSeverity if the label is missing or not mapped.
+func (c *Config) Severity(source *Source) types.AlertSeverity {
+	if source.Resolved {
+		return types.AlertResolved
+	}
+
+	if s, ok := c.SeverityMapping[strings.ToLower(source.Labels[c.SeverityLabel])]; ok {
+		return s
+	}
+
+	return c.DefaultSeverity
+}
+
+// AppendField adds a field to the alert, unless the alert already has MaxFieldCount fields.
+// It returns false if the field was not added.
+func AppendField(alert *types.Alert, title, value string) bool {
+	if len(alert.Fields) >= types.MaxFieldCount {
+		return false
+	}
+
+	alert.Fields = append(alert.Fields, &types.Field{Title: title, Value: value})
+
+	return true
+}
+
+func (c *Config) fieldLabels(labels map[string]string) []string {
+	if len(c.FieldLabels) > 0 {
+		names := make([]string, 0, len(c.FieldLabels))
+
+		for _, name := range c.FieldLabels {
+			if _, ok := labels[name]; ok {
+				names = append(names, name)
+			}
+		}
+
+		return names
+	}
+
+	names := slices.Sorted(maps.Keys(labels))
+
+	return slices.DeleteFunc(names, func(name string) bool {
+		return slices.Contains(c.ExcludedFieldLabels, name)
+	})
+}
+
+func firstNonEmpty(values map[string]string, keys []string) string {
+	for _, key := range keys {
+		if v := values[key]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func toAnyMap(m map[string]string) map[string]any {
+	result := make(map[string]any, len(m))
+
+	for k, v := range m {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,95 @@
+package mapping_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/mapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	source := &mapping.Source{
+		Labels:      map[string]string{"alertname": "HighLatency", "severity": "Warning", "service": "checkout", "env": "prod", "slack_channel": "C123"},
+		Annotations: map[string]string{"title": "Title", "message": "Message"},
+		Receiver:    "team",
+	}
+
+	t.Run("default config should map labels and annotations", func(t *testing.T) {
+		t.Parallel()
+
+		alert := &types.Alert{}
+		mapping.DefaultConfig().Apply(alert, source)
+
+		assert.Equal(t, types.AlertWarning, alert.Severity)
+		assert.Equal(t, "Title", alert.Header)
+		assert.Equal(t, "Message", alert.Text)
+		assert.Equal(t, "C123", alert.SlackChannelID)
+		assert.Equal(t, "team", alert.RouteKey)
+		assert.True(t, alert.IssueFollowUpEnabled)
+		assert.Equal(t, 86400, alert.AutoResolveSeconds)
+		assert.Equal(t, []*types.Field{{Title: "env", Value: "prod"}, {Title: "service", Value: "checkout"}}, alert.Fields)
+		assert.Equal(t, "checkout", alert.Metadata["labels"].(map[string]any)["service"])
+		assert.Equal(t, "Title", alert.Metadata["annotations"].(map[string]any)["title"])
+	})
+
+	t.Run("header should fall back to the alertname label", func(t *testing.T) {
+		t.Parallel()
+
+		alert := &types.Alert{}
+		mapping.DefaultConfig().Apply(alert, &mapping.Source{Labels: map[string]string{"alertname": "Watchdog"}})
+		assert.Equal(t, "Watchdog", alert.Header)
+		assert.Equal(t, types.AlertError, alert.Severity)
+	})
+
+	t.Run("custom config should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := mapping.DefaultConfig()
+		cfg.ChannelLabel = ""
+		cfg.SlackChannelID = "C999"
+		cfg.RouteKey = "route"
+		cfg.FieldLabels = []string{"service", "missing"}
+		cfg.DisableIssueFollowUp = true
+
+		alert := &types.Alert{}
+		cfg.Apply(alert, source)
+
+		assert.Equal(t, "C999", alert.SlackChannelID)
+		assert.Equal(t, "route", alert.RouteKey)
+		assert.Equal(t, []*types.Field{{Title: "service", Value: "checkout"}}, alert.Fields)
+		assert.False(t, alert.IssueFollowUpEnabled)
+		assert.Zero(t, alert.AutoResolveSeconds)
+	})
+}
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	cfg := mapping.DefaultConfig()
+
+	assert.Equal(t, types.AlertPanic, cfg.Severity(&mapping.Source{Labels: map[string]string{"severity": "page"}}))
+	assert.Equal(t, types.AlertError, cfg.Severity(&mapping.Source{Labels: map[string]string{"severity": "CRITICAL"}}))
+	assert.Equal(t, types.AlertError, cfg.Severity(&mapping.Source{Labels: map[string]string{"severity": "unknown"}}))
+	assert.Equal(t, types.AlertResolved, cfg.Severity(&mapping.Source{Labels: map[string]string{"severity": "page"}, Resolved: true}))
+
+	cfg.DefaultSeverity = types.AlertInfo
+	assert.Equal(t, types.AlertInfo, cfg.Severity(&mapping.Source{}))
+}
+
+func TestAppendField(t *testing.T) {
+	t.Parallel()
+
+	alert := &types.Alert{}
+
+	for i := range types.MaxFieldCount {
+		require.True(t, mapping.AppendField(alert, strconv.Itoa(i), "value"))
+	}
+
+	assert.False(t, mapping.AppendField(alert, "one too many", "value"))
+	assert.Len(t, alert.Fields, types.MaxFieldCount)
+}
@@ -0,0 +1,275 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	// mentionIDRegex matches the user or user group ID of a mention.
+	mentionIDRegex = regexp.MustCompile(`^[^>\s]+$`)
+
+	// mentionEmailRegex matches the email address of an email mention.
+	mentionEmailRegex = regexp.MustCompile(`^[^\s@<>]+@[^\s@<>]+\.[^\s@<>]+$`)
+)
+
+// ErrMentionNotFound is returned (wrapped) by a MentionResolver when no user has the specified email address.
+var ErrMentionNotFound = errors.New("mention not found")
+
+// MentionKind is the kind of a Slack mention, see ParseMention.
+type MentionKind string
+
+const (
+	// MentionKindUser mentions a user, on the format '<@U12345678>'.
+	MentionKindUser MentionKind = "user"
+
+	// MentionKindUserGroup mentions a user group, on the format '<!subteam^S12345678>'.
+	MentionKindUserGroup MentionKind = "userGroup"
+
+	// MentionKindHere mentions the active members of the channel, on the format '<!here>'.
+	MentionKindHere MentionKind = "here"
+
+	// MentionKindChannel mentions all members of the channel, on the format '<!channel>'.
+	MentionKindChannel MentionKind = "channel"
+
+	// MentionKindEmail is a user referenced by email address, on the format 'jane@example.com'.
+	// It must be resolved to a user mention before it is posted to Slack, see MentionResolver.
+	MentionKindEmail MentionKind = "email"
+)
+
+// MentionKindIsValid returns true if the provided MentionKind is valid.
+func MentionKindIsValid(k MentionKind) bool {
+	switch k {
+	case MentionKindUser, MentionKindUserGroup, MentionKindHere, MentionKindChannel, MentionKindEmail:
+		return true
+	}
+	return false
+}
+
+// ValidMentionKinds returns a slice of valid MentionKind values.
+func ValidMentionKinds() []string {
+	return []string{
+		string(MentionKindUser),
+		string(MentionKindUserGroup),
+		string(MentionKindHere),
+		string(MentionKindChannel),
+		string(MentionKindEmail),
+	}
+}
+
+// Mention is a typed Slack mention, as used in Escalation.SlackMentions and EscalationSchedule.AlternateMentions.
+type Mention struct {
+	// Kind is the kind of mention.
+	Kind MentionKind `json:"kind"`
+
+	// ID is the user ID (MentionKindUser) or user group ID (MentionKindUserGroup).
+	ID string `json:"id,omitempty"`
+
+	// Email is the email address of the user (MentionKindEmail).
+	Email string `json:"email,omitempty"`
+}
+
+// ParseMention parses a mention on one of the formats '<@U12345678>', '<!subteam^S12345678>', '<!here>', '<!channel>'
+// or 'jane@example.com'. An error is returned if s is not a valid mention. Lengths are not checked.
+func ParseMention(s string) (*Mention, error) {
+	switch s {
+	case "<!here>":
+		return &Mention{Kind: MentionKindHere}, nil
+	case "<!channel>":
+		return &Mention{Kind: MentionKindChannel}, nil
+	}
+
+	if id, ok := cutMention(s, "<@"); ok {
+		return &Mention{Kind: MentionKindUser, ID: id}, nil
+	}
+
+	if id, ok := cutMention(s, "<!subteam^"); ok {
+		return &Mention{Kind: MentionKindUserGroup, ID: id}, nil
+	}
+
+	if mentionEmailRegex.MatchString(s) {
+		return &Mention{Kind: MentionKindEmail, Email: s}, nil
+	}
+
+	return nil, fmt.Errorf("invalid mention '%s'", s)
+}
+
+// cutMention returns the ID of a mention on the format prefix + ID + '>'.
+func cutMention(s, prefix string) (string, bool) {
+	id, ok := strings.CutPrefix(s, prefix)
+	if !ok {
+		return "", false
+	}
+
+	id, ok = strings.CutSuffix(id, ">")
+	if !ok || !mentionIDRegex.MatchString(id) {
+		return "", false
+	}
+
+	return id, true
+}
+
+// String returns the mention on the format accepted by ParseMention.
+func (m *Mention) String() string {
+	switch m.Kind {
+	case MentionKindUser:
+		return "<@" + m.ID + ">"
+	case MentionKindUserGroup:
+		return "<!subteam^" + m.ID + ">"
+	case MentionKindHere:
+		return "<!here>"
+	case MentionKindChannel:
+		return "<!channel>"
+	case MentionKindEmail:
+		return m.Email
+	}
+
+	return ""
+}
+
+// isValidSlackMention returns true if s is a valid mention (see ParseMention), where user and user group IDs are at most
+// MaxMentionLength characters and email addresses are at most MaxMentionEmailLength characters.
+func isValidSlackMention(s string, limits *Limits) bool {
+	m, err := ParseMention(s)
+	if err != nil {
+		return false
+	}
+
+	switch m.Kind {
+	case MentionKindUser, MentionKindUserGroup:
+		return utf8.RuneCountInString(m.ID) <= limits.MaxMentionLength
+	case MentionKindEmail:
+		return utf8.RuneCountInString(m.Email) <= limits.MaxMentionEmailLength
+	}
+
+	return true
+}
+
+// MentionResolver resolves email mentions to Slack user IDs, typically with the Slack users.lookupByEmail API.
+type MentionResolver interface {
+	// ResolveEmail returns the Slack user ID of the user with the specified email address.
+	// An error wrapping ErrMentionNotFound is returned if there is no such user.
+	ResolveEmail(ctx context.Context, email string) (string, error)
+}
+
+// InMemoryMentionResolver is an in-memory implementation of the MentionResolver interface,
+// for tests and for static email to user ID mappings. Email addresses are matched case-insensitively.
+type InMemoryMentionResolver struct {
+	mu    sync.RWMutex
+	users map[string]string
+}
+
+// NewInMemoryMentionResolver creates a new InMemoryMentionResolver, with the specified email addresses mapped to user IDs.
+func NewInMemoryMentionResolver(users map[string]string) *InMemoryMentionResolver {
+	r := &InMemoryMentionResolver{users: make(map[string]string, len(users))}
+
+	for email, userID := range users {
+		r.Add(email, userID)
+	}
+
+	return r
+}
+
+// Add maps an email address to a user ID, replacing any existing mapping.
+func (r *InMemoryMentionResolver) Add(email, userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[strings.ToLower(email)] = userID
+}
+
+// ResolveEmail returns the user ID mapped to the email address.
+func (r *InMemoryMentionResolver) ResolveEmail(_ context.Context, email string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userID, ok := r.users[strings.ToLower(email)]
+	if !ok {
+		return "", fmt.Errorf("no user with email '%s': %w", email, ErrMentionNotFound)
+	}
+
+	return userID, nil
+}
+
+// ResolveMentions replaces the email mentions in Escalation.SlackMentions and EscalationSchedule.AlternateMentions with user
+// mentions, using the resolver. Other mentions (including invalid ones) are kept as they are. Call it when the alert is ingested,
+// after ResolveEscalationPolicy. If an email address cannot be resolved, or the resolver returns an invalid user ID,
+// an error naming the mention path is returned and the alert is not changed. The resolver is not used (and may be nil)
+// if the alert has no email mentions. Resolved user IDs are checked against the default limits.
+func (a *Alert) ResolveMentions(ctx context.Context, resolver MentionResolver) error {
+	return a.ResolveMentionsWith(ctx, resolver, DefaultLimits())
+}
+
+// ResolveMentionsWith resolves email mentions like ResolveMentions, checking the resolved user IDs against the specified
+// limits instead of the default limits. If limits is nil, the default limits are used.
+func (a *Alert) ResolveMentionsWith(ctx context.Context, resolver MentionResolver, limits *Limits) error {
+	type emailMention struct {
+		mention *string
+		path    string
+		email   string
+	}
+
+	var emails []emailMention
+
+	collect := func(mentions []string, path string) {
+		for i := range mentions {
+			if m, err := ParseMention(mentions[i]); err == nil && m.Kind == MentionKindEmail {
+				emails = append(emails, emailMention{mention: &mentions[i], path: fmt.Sprintf("%s[%d]", path, i), email: m.Email})
+			}
+		}
+	}
+
+	for index, e := range a.Escalation {
+		if e == nil {
+			continue
+		}
+
+		path := fmt.Sprintf("escalation[%d]", index)
+
+		collect(e.SlackMentions, path+".slackMentions")
+
+		if e.Schedule != nil {
+			collect(e.Schedule.AlternateMentions, path+".schedule.alternateMentions")
+		}
+	}
+
+	if len(emails) == 0 {
+		return nil
+	}
+
+	if resolver == nil {
+		return fmt.Errorf("failed to resolve %s: no mention resolver", emails[0].path)
+	}
+
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	resolved := make([]string, len(emails))
+
+	for i, e := range emails {
+		userID, err := resolver.ResolveEmail(ctx, e.email)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", e.path, err)
+		}
+
+		user := (&Mention{Kind: MentionKindUser, ID: userID}).String()
+
+		if !isValidSlackMention(user, limits) {
+			return fmt.Errorf("failed to resolve %s: invalid user ID '%s' for email '%s'", e.path, userID, e.email)
+		}
+
+		resolved[i] = user
+	}
+
+	for i, e := range emails {
+		*e.mention = resolved[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMentionKind(t *testing.T) {
+	t.Parallel()
+
+	for _, k := range types.ValidMentionKinds() {
+		assert.True(t, types.MentionKindIsValid(types.MentionKind(k)))
+	}
+
+	assert.False(t, types.MentionKindIsValid("team"))
+	assert.False(t, types.MentionKindIsValid(""))
+}
+
+func TestParseMention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid mentions should be parsed and formatted", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			input    string
+			expected *types.Mention
+		}{
+			{"<@U0123456789>", &types.Mention{Kind: types.MentionKindUser, ID: "U0123456789"}},
+			{"<!subteam^S0123456789>", &types.Mention{Kind: types.MentionKindUserGroup, ID: "S0123456789"}},
+			{"<!here>", &types.Mention{Kind: types.MentionKindHere}},
+			{"<!channel>", &types.Mention{Kind: types.MentionKindChannel}},
+			{"jane.doe+oncall@example.com", &types.Mention{Kind: types.MentionKindEmail, Email: "jane.doe+oncall@example.com"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				t.Parallel()
+
+				m, err := types.ParseMention(tt.input)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, m)
+				assert.Equal(t, tt.input, m.String())
+			})
+		}
+	})
+
+	t.Run("invalid mentions should fail", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{"", "@here", "<!everyone>", "<@>", "<@U1 U2>", "<!subteam^>", "<!subteam^S1", "jane", "jane@example", "<jane@example.com>", "jane doe@example.com"} {
+			_, err := types.ParseMention(input)
+			require.ErrorContains(t, err, "invalid mention", "input %q", input)
+		}
+	})
+
+	t.Run("unknown kind should format as empty", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, (&types.Mention{Kind: "team", ID: "T1"}).String())
+	})
+
+	t.Run("slack mention regex should only match postable mentions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{"<@U0123456789>", "<!subteam^S0123456789>", "<!here>", "<!channel>"} {
+			assert.True(t, types.SlackMentionRegex.MatchString(input), input)
+		}
+
+		assert.False(t, types.SlackMentionRegex.MatchString("@here"))
+		assert.False(t, types.SlackMentionRegex.MatchString("jane@example.com"))
+	})
+}
+
+func TestInMemoryMentionResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("email addresses should be resolved case-insensitively", func(t *testing.T) {
+		t.Parallel()
+
+		r := types.NewInMemoryMentionResolver(map[string]string{"Jane@Example.com": "U1"})
+		r.Add("john@example.com", "U2")
+
+		userID, err := r.ResolveEmail(context.Background(), "jane@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "U1", userID)
+
+		userID, err = r.ResolveEmail(context.Background(), "JOHN@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "U2", userID)
+	})
+
+	t.Run("unknown email address should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.NewInMemoryMentionResolver(nil).ResolveEmail(context.Background(), "jane@example.com")
+		require.ErrorIs(t, err, types.ErrMentionNotFound)
+		require.ErrorContains(t, err, "no user with email 'jane@example.com'")
+	})
+}
+
+func TestResolveMentions(t *testing.T) {
+	t.Parallel()
+
+	resolver := types.NewInMemoryMentionResolver(map[string]string{"jane@example.com": "U1", "john@example.com": "U2"})
+
+	t.Run("email mentions should be replaced with user mentions", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			nil,
+			{SlackMentions: []string{"<!here>", "jane@example.com", "<!subteam^S1>", "invalid"}},
+			{SlackMentions: []string{"john@example.com"}, Schedule: &types.EscalationSchedule{AlternateMentions: []string{"jane@example.com"}}},
+		}}
+
+		require.NoError(t, a.ResolveMentions(context.Background(), resolver))
+
+		assert.Equal(t, []string{"<!here>", "<@U1>", "<!subteam^S1>", "invalid"}, a.Escalation[1].SlackMentions)
+		assert.Equal(t, []string{"<@U2>"}, a.Escalation[2].SlackMentions)
+		assert.Equal(t, []string{"<@U1>"}, a.Escalation[2].Schedule.AlternateMentions)
+	})
+
+	t.Run("unresolved email mention should fail without changing the alert", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{
+			{SlackMentions: []string{"jane@example.com"}},
+			{SlackMentions: []string{"<!here>"}, Schedule: &types.EscalationSchedule{AlternateMentions: []string{"bob@example.com"}}},
+		}}
+
+		err := a.ResolveMentions(context.Background(), resolver)
+		require.ErrorContains(t, err, "failed to resolve escalation[1].schedule.alternateMentions[0]")
+		require.True(t, errors.Is(err, types.ErrMentionNotFound))
+
+		assert.Equal(t, []string{"jane@example.com"}, a.Escalation[0].SlackMentions)
+	})
+
+	t.Run("invalid user IDs from the resolver should fail without changing the alert", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := types.NewInMemoryMentionResolver(map[string]string{
+			"jane@example.com":  "U1",
+			"empty@example.com": "",
+			"long@example.com":  strings.Repeat("U", types.MaxMentionLength+1),
+			"space@example.com": "U1 U2",
+		})
+
+		for _, email := range []string{"empty@example.com", "long@example.com", "space@example.com"} {
+			a := &types.Alert{Escalation: []*types.Escalation{{SlackMentions: []string{"jane@example.com", email}}}}
+
+			err := a.ResolveMentions(context.Background(), invalid)
+			require.ErrorContains(t, err, "failed to resolve escalation[0].slackMentions[1]: invalid user ID", email)
+			assert.Equal(t, []string{"jane@example.com", email}, a.Escalation[0].SlackMentions)
+		}
+	})
+
+	t.Run("resolved user IDs should be checked against the specified limits", func(t *testing.T) {
+		t.Parallel()
+
+		userID := strings.Repeat("U", types.MaxMentionLength+1)
+		resolver := types.NewInMemoryMentionResolver(map[string]string{"jane@example.com": userID})
+
+		limits := types.DefaultLimits()
+		limits.MaxMentionLength = types.MaxMentionLength + 10
+
+		a := &types.Alert{Escalation: []*types.Escalation{{SlackMentions: []string{"jane@example.com"}}}}
+		require.NoError(t, a.ResolveMentionsWith(context.Background(), resolver, limits))
+		assert.Equal(t, []string{"<@" + userID + ">"}, a.Escalation[0].SlackMentions)
+
+		a = &types.Alert{Escalation: []*types.Escalation{{SlackMentions: []string{"jane@example.com"}}}}
+		require.ErrorContains(t, a.ResolveMentionsWith(context.Background(), resolver, nil), "invalid user ID")
+	})
+
+	t.Run("nil resolver should only fail with email mentions", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Escalation: []*types.Escalation{{SlackMentions: []string{"<@U1>", "<!here>"}}}}
+		require.NoError(t, a.ResolveMentions(context.Background(), nil))
+		require.NoError(t, (&types.Alert{}).ResolveMentions(context.Background(), nil))
+
+		a = &types.Alert{Escalation: []*types.Escalation{{SlackMentions: []string{"<@U1>", "jane@example.com"}}}}
+		require.EqualError(t, a.ResolveMentions(context.Background(), nil), "failed to resolve escalation[0].slackMentions[1]: no mention resolver")
+	})
+}
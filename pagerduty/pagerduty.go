@@ -0,0 +1,373 @@
+// Package pagerduty converts between PagerDuty Events API v2 events and Slack Manager alerts,
+// so that producers emitting PagerDuty events can be migrated gradually.
+//
+// Usage in an HTTP handler receiving PagerDuty events:
+//
+//	event, err := pagerduty.Decode(r.Body)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//
+//	for _, alert := range pagerduty.Convert(event, pagerduty.DefaultConfig()) {
+//	    alert.Clean()
+//	    if err := alert.Validate(); err != nil {
+//	        // handle error
+//	    }
+//	}
+//
+// Use FromAlert to convert an alert to a PagerDuty event, e.g. to send the same alert to both systems.
+package pagerduty
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/mapping"
+)
+
+// Event actions.
+const (
+	EventActionTrigger     = "trigger"
+	EventActionAcknowledge = "acknowledge"
+	EventActionResolve     = "resolve"
+)
+
+// Event severities.
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+const (
+	// MaxSummaryLength is the maximum length of Payload.Summary accepted by PagerDuty.
+	MaxSummaryLength = 1024
+
+	// MaxDedupKeyLength is the maximum length of Event.DedupKey accepted by PagerDuty.
+	MaxDedupKeyLength = 255
+
+	// defaultSource is used as Payload.Source by FromAlert, when the alert has neither host nor author.
+	defaultSource = "slack-manager"
+)
+
+// Event is a PagerDuty Events API v2 event.
+// See https://developer.pagerduty.com/docs/events-api-v2/trigger-events/
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key,omitempty"`
+	Payload     *Payload `json:"payload,omitempty"`
+	Client      string   `json:"client,omitempty"`
+	ClientURL   string   `json:"client_url,omitempty"`
+	Links       []*Link  `json:"links,omitempty"`
+	Images      []*Image `json:"images,omitempty"`
+}
+
+// Payload is the payload of a PagerDuty trigger event.
+type Payload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Timestamp     string         `json:"timestamp,omitempty"`
+	Component     string         `json:"component,omitempty"`
+	Group         string         `json:"group,omitempty"`
+	Class         string         `json:"class,omitempty"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// Link is a link attached to a PagerDuty event.
+type Link struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+// Image is an image attached to a PagerDuty event.
+type Image struct {
+	Src  string `json:"src"`
+	Href string `json:"href,omitempty"`
+	Alt  string `json:"alt,omitempty"`
+}
+
+// Decode decodes a PagerDuty Events API v2 event. An error is returned if the JSON is invalid,
+// if the event action is unknown, or if the fields required for the event action are missing.
+func Decode(r io.Reader) (*Event, error) {
+	var event Event
+
+	if err := json.NewDecoder(r).Decode(&event); err != nil {
+		return nil, fmt.Errorf("failed to decode PagerDuty event: %w", err)
+	}
+
+	switch event.EventAction {
+	case EventActionTrigger:
+		if event.Payload == nil || event.Payload.Summary == "" || event.Payload.Source == "" || event.Payload.Severity == "" {
+			return nil, fmt.Errorf("PagerDuty trigger event requires payload.summary, payload.source and payload.severity")
+		}
+	case EventActionAcknowledge, EventActionResolve:
+		if event.DedupKey == "" {
+			return nil, fmt.Errorf("PagerDuty %s event requires dedup_key", event.EventAction)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PagerDuty event action '%s'", event.EventAction)
+	}
+
+	return &event, nil
+}
+
+// Config defines how PagerDuty events are mapped to Slack Manager alerts. Use DefaultConfig to get the default mapping.
+type Config struct {
+	// SlackChannelID is the Slack channel ID or name used for all alerts.
+	SlackChannelID string
+
+	// RouteKey is the route key used for all alerts. Ignored if SlackChannelID is set.
+	// If both are empty, the PagerDuty routing key is used as route key.
+	RouteKey string
+
+	// SeverityMapping maps PagerDuty severities to alert severities.
+	SeverityMapping map[string]types.AlertSeverity
+
+	// DefaultSeverity is used when the PagerDuty severity is not found in SeverityMapping.
+	DefaultSeverity types.AlertSeverity
+
+	// TextDetail is the custom_details key used as alert text, rather than as a field. Ignored if empty.
+	TextDetail string
+
+	// AutoResolve is the duration after which an issue is auto-resolved, if no resolve event is received.
+	AutoResolve time.Duration
+
+	// DisableIssueFollowUp disables issue follow-up, so resolve events are posted as separate messages.
+	DisableIssueFollowUp bool
+
+	// Customize is called for each converted alert, and can modify the result further. Ignored if nil.
+	Customize func(alert *types.Alert, event *Event)
+}
+
+// DefaultConfig returns a new Config instance with the default mapping: critical maps to panic,
+// and error, warning and info map to the alert severities of the same name.
+func DefaultConfig() *Config {
+	return &Config{
+		SeverityMapping: map[string]types.AlertSeverity{
+			SeverityCritical: types.AlertPanic,
+			SeverityError:    types.AlertError,
+			SeverityWarning:  types.AlertWarning,
+			SeverityInfo:     types.AlertInfo,
+		},
+		DefaultSeverity: types.AlertError,
+		TextDetail:      "text",
+		AutoResolve:     24 * time.Hour,
+	}
+}
+
+// Convert converts the PagerDuty event to a Slack Manager alert, using the specified config (or the default config if nil).
+// The result has zero alerts for acknowledge events, which have no Slack Manager equivalent, and one alert otherwise.
+// The returned alert is not cleaned or validated.
+//
+// The dedup key becomes the CorrelationID, the summary becomes the Header, the source becomes the Host, the class becomes the Type,
+// and the first link becomes the Link. The component, group and custom details are added as fields, and the custom details
+// are also kept as metadata. Resolve events usually have no payload, in which case the dedup key is used as header.
+func Convert(event *Event, cfg *Config) []*types.Alert {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	if event.EventAction == EventActionAcknowledge {
+		return nil
+	}
+
+	// Start from the default severity, so that a trigger event without payload never resolves the issue
+	alert := types.NewAlert(cfg.DefaultSeverity)
+
+	alert.CorrelationID = event.DedupKey
+	alert.Header = event.DedupKey
+	alert.SlackChannelID = cfg.SlackChannelID
+	alert.RouteKey = cfg.RouteKey
+	alert.IssueFollowUpEnabled = !cfg.DisableIssueFollowUp
+
+	if alert.SlackChannelID == "" && alert.RouteKey == "" {
+		alert.RouteKey = event.RoutingKey
+	}
+
+	if alert.IssueFollowUpEnabled {
+		alert.AutoResolveSeconds = int(cfg.AutoResolve / time.Second)
+	}
+
+	if len(event.Links) > 0 && event.Links[0] != nil {
+		alert.Link = event.Links[0].Href
+	}
+
+	if payload := event.Payload; payload != nil {
+		applyPayload(alert, payload, cfg)
+	}
+
+	if event.EventAction == EventActionResolve {
+		alert.Severity = types.AlertResolved
+	}
+
+	if cfg.Customize != nil {
+		cfg.Customize(alert, event)
+	}
+
+	return []*types.Alert{alert}
+}
+
+func applyPayload(alert *types.Alert, payload *Payload, cfg *Config) {
+	alert.Header = payload.Summary
+	alert.Host = payload.Source
+	alert.Type = payload.Class
+
+	alert.Severity = cfg.DefaultSeverity
+	if s, ok := cfg.SeverityMapping[strings.ToLower(payload.Severity)]; ok {
+		alert.Severity = s
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, payload.Timestamp); err == nil {
+		alert.Timestamp = t
+	}
+
+	if payload.Component != "" {
+		mapping.AppendField(alert, "Component", payload.Component)
+	}
+
+	if payload.Group != "" {
+		mapping.AppendField(alert, "Group", payload.Group)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(payload.CustomDetails)) {
+		value := detailString(payload.CustomDetails[key])
+
+		if key == cfg.TextDetail && cfg.TextDetail != "" {
+			alert.Text = value
+			continue
+		}
+
+		mapping.AppendField(alert, key, value)
+	}
+
+	if len(payload.CustomDetails) > 0 {
+		alert.Metadata["customDetails"] = payload.CustomDetails
+	}
+}
+
+// detailString returns strings as-is, and other values as JSON.
+func detailString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(b)
+}
+
+// FromAlert converts a Slack Manager alert to a PagerDuty event with the specified routing key.
+// Resolved alerts become resolve events, and all other alerts become trigger events.
+//
+// The CorrelationID (or the default correlation ID, if empty) becomes the dedup key, and is hashed if longer than MaxDedupKeyLength.
+// Panic maps to critical, and error, warning and info map to the PagerDuty severities of the same name. The header (or text, if
+// the header is empty) becomes the summary, the host (or author) becomes the source, and the link becomes a link.
+// The fields, and the text when the header is set, are added as custom details. Fields with the same title as an earlier
+// custom detail (such as a field titled 'text', when the text is added) are skipped, so that they cannot overwrite it.
+func FromAlert(alert *types.Alert, routingKey string) *Event {
+	event := &Event{
+		RoutingKey:  routingKey,
+		EventAction: EventActionTrigger,
+		DedupKey:    dedupKey(alert),
+	}
+
+	if alert.Link != "" {
+		event.Links = []*Link{{Href: alert.Link}}
+	}
+
+	if alert.Severity == types.AlertResolved {
+		event.EventAction = EventActionResolve
+		return event
+	}
+
+	payload := &Payload{
+		Summary:  alert.Header,
+		Source:   alert.Host,
+		Severity: eventSeverity(alert.Severity),
+		Class:    alert.Type,
+	}
+
+	if payload.Summary == "" {
+		payload.Summary = alert.Text
+	} else if alert.Text != "" {
+		payload.CustomDetails = map[string]any{"text": alert.Text}
+	}
+
+	if r := []rune(payload.Summary); len(r) > MaxSummaryLength {
+		payload.Summary = string(r[:MaxSummaryLength])
+	}
+
+	if payload.Source == "" {
+		payload.Source = alert.Author
+	}
+
+	if payload.Source == "" {
+		payload.Source = defaultSource
+	}
+
+	if !alert.Timestamp.IsZero() {
+		payload.Timestamp = alert.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+
+	for _, field := range alert.Fields {
+		if field == nil {
+			continue
+		}
+
+		if payload.CustomDetails == nil {
+			payload.CustomDetails = make(map[string]any)
+		}
+
+		if _, ok := payload.CustomDetails[field.Title]; ok {
+			continue
+		}
+
+		payload.CustomDetails[field.Title] = field.Value
+	}
+
+	event.Payload = payload
+
+	return event
+}
+
+func eventSeverity(severity types.AlertSeverity) string {
+	switch severity {
+	case types.AlertPanic:
+		return SeverityCritical
+	case types.AlertWarning:
+		return SeverityWarning
+	case types.AlertInfo:
+		return SeverityInfo
+	default:
+		return SeverityError
+	}
+}
+
+func dedupKey(alert *types.Alert) string {
+	key := alert.CorrelationID
+	if key == "" {
+		key = alert.DefaultCorrelationID()
+	}
+
+	if len(key) > MaxDedupKeyLength {
+		sum := sha256.Sum256([]byte(key))
+		key = hex.EncodeToString(sum[:])
+	}
+
+	return key
+}
@@ -0,0 +1,299 @@
+package pagerduty_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestConvertGolden(t *testing.T) {
+	t.Parallel()
+
+	payloads, err := filepath.Glob("testdata/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, payloads)
+
+	for _, payload := range payloads {
+		if strings.HasSuffix(payload, ".golden.json") {
+			continue
+		}
+
+		t.Run(filepath.Base(payload), func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(payload)
+			require.NoError(t, err)
+			defer f.Close()
+
+			event, err := pagerduty.Decode(f)
+			require.NoError(t, err)
+
+			start := time.Now()
+			alerts := pagerduty.Convert(event, pagerduty.DefaultConfig())
+
+			// Events without a timestamp get the current time, which is zeroed to keep the golden files stable
+			for _, alert := range alerts {
+				if !alert.Timestamp.Before(start) {
+					alert.Timestamp = time.Time{}
+				}
+			}
+
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			require.NoError(t, enc.Encode(alerts))
+			actual := buf.Bytes()
+
+			golden := strings.TrimSuffix(payload, ".json") + ".golden.json"
+
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o600))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+
+			for _, alert := range alerts {
+				alert.Timestamp = time.Now()
+				alert.Clean()
+				require.NoError(t, alert.Validate())
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "invalid JSON should fail", input: `{"event_action": `, wantErr: "failed to decode PagerDuty event"},
+		{name: "unknown event action should fail", input: `{"event_action": "snooze"}`, wantErr: "unsupported PagerDuty event action 'snooze'"},
+		{name: "trigger without payload should fail", input: `{"event_action": "trigger"}`, wantErr: "requires payload.summary"},
+		{name: "trigger without source should fail", input: `{"event_action": "trigger", "payload": {"summary": "s", "severity": "info"}}`, wantErr: "requires payload.summary"},
+		{name: "resolve without dedup key should fail", input: `{"event_action": "resolve"}`, wantErr: "PagerDuty resolve event requires dedup_key"},
+		{name: "acknowledge without dedup key should fail", input: `{"event_action": "acknowledge"}`, wantErr: "PagerDuty acknowledge event requires dedup_key"},
+		{name: "valid acknowledge should succeed", input: `{"event_action": "acknowledge", "dedup_key": "key"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := pagerduty.Decode(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, event)
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	event := &pagerduty.Event{
+		RoutingKey:  "routing",
+		EventAction: pagerduty.EventActionTrigger,
+		DedupKey:    "key",
+		Payload: &pagerduty.Payload{
+			Summary:       "Summary",
+			Source:        "host-1",
+			Severity:      "WARNING",
+			CustomDetails: map[string]any{"text": "Text", "count": 3},
+		},
+	}
+
+	t.Run("nil config should use the default config", func(t *testing.T) {
+		t.Parallel()
+
+		alerts := pagerduty.Convert(event, nil)
+		require.Len(t, alerts, 1)
+
+		alert := alerts[0]
+		assert.Equal(t, types.AlertWarning, alert.Severity)
+		assert.Equal(t, "key", alert.CorrelationID)
+		assert.Equal(t, "Summary", alert.Header)
+		assert.Equal(t, "Text", alert.Text)
+		assert.Equal(t, "host-1", alert.Host)
+		assert.Equal(t, "routing", alert.RouteKey)
+		assert.Equal(t, []*types.Field{{Title: "count", Value: "3"}}, alert.Fields)
+		assert.True(t, alert.IssueFollowUpEnabled)
+		assert.Equal(t, 86400, alert.AutoResolveSeconds)
+	})
+
+	t.Run("custom config should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := pagerduty.DefaultConfig()
+		cfg.SlackChannelID = "C123"
+		cfg.SeverityMapping = map[string]types.AlertSeverity{}
+		cfg.DefaultSeverity = types.AlertInfo
+		cfg.TextDetail = ""
+		cfg.DisableIssueFollowUp = true
+		cfg.Customize = func(alert *types.Alert, event *pagerduty.Event) {
+			alert.Footer = event.RoutingKey
+		}
+
+		alerts := pagerduty.Convert(event, cfg)
+		require.Len(t, alerts, 1)
+
+		alert := alerts[0]
+		assert.Equal(t, types.AlertInfo, alert.Severity)
+		assert.Equal(t, "C123", alert.SlackChannelID)
+		assert.Empty(t, alert.RouteKey)
+		assert.Empty(t, alert.Text)
+		assert.Equal(t, []*types.Field{{Title: "count", Value: "3"}, {Title: "text", Value: "Text"}}, alert.Fields)
+		assert.False(t, alert.IssueFollowUpEnabled)
+		assert.Zero(t, alert.AutoResolveSeconds)
+		assert.Equal(t, "routing", alert.Footer)
+	})
+
+	t.Run("trigger events without payload should not resolve the issue", func(t *testing.T) {
+		t.Parallel()
+
+		alerts := pagerduty.Convert(&pagerduty.Event{EventAction: pagerduty.EventActionTrigger, DedupKey: "key"}, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, types.AlertError, alerts[0].Severity)
+		assert.Equal(t, "key", alerts[0].Header)
+
+		alerts = pagerduty.Convert(&pagerduty.Event{EventAction: pagerduty.EventActionResolve, DedupKey: "key"}, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, types.AlertResolved, alerts[0].Severity)
+	})
+
+	t.Run("acknowledge events should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, pagerduty.Convert(&pagerduty.Event{EventAction: pagerduty.EventActionAcknowledge, DedupKey: "key"}, nil))
+	})
+}
+
+func TestFromAlert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("alert should map to a trigger event", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewPanicAlert()
+		alert.CorrelationID = "key"
+		alert.Timestamp = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+		alert.Header = "Header"
+		alert.Text = "Text"
+		alert.Author = "author"
+		alert.Type = "class"
+		alert.Link = "https://example.com"
+		alert.Fields = []*types.Field{{Title: "env", Value: "prod"}, nil}
+
+		event := pagerduty.FromAlert(alert, "routing")
+
+		assert.Equal(t, &pagerduty.Event{
+			RoutingKey:  "routing",
+			EventAction: pagerduty.EventActionTrigger,
+			DedupKey:    "key",
+			Links:       []*pagerduty.Link{{Href: "https://example.com"}},
+			Payload: &pagerduty.Payload{
+				Summary:       "Header",
+				Source:        "author",
+				Severity:      pagerduty.SeverityCritical,
+				Timestamp:     "2026-03-01T10:00:00Z",
+				Class:         "class",
+				CustomDetails: map[string]any{"text": "Text", "env": "prod"},
+			},
+		}, event)
+	})
+
+	t.Run("fields should not overwrite earlier custom details", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = "Header"
+		alert.Text = "Text"
+		alert.Fields = []*types.Field{{Title: "text", Value: "field"}, {Title: "env", Value: "prod"}, {Title: "env", Value: "dev"}}
+
+		event := pagerduty.FromAlert(alert, "routing")
+		assert.Equal(t, map[string]any{"text": "Text", "env": "prod"}, event.Payload.CustomDetails)
+	})
+
+	t.Run("resolved alert should map to a resolve event", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewResolvedAlert()
+		alert.CorrelationID = "key"
+
+		event := pagerduty.FromAlert(alert, "routing")
+		assert.Equal(t, pagerduty.EventActionResolve, event.EventAction)
+		assert.Equal(t, "key", event.DedupKey)
+		assert.Nil(t, event.Payload)
+	})
+
+	t.Run("missing values should use fallbacks", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewInfoAlert()
+		alert.Text = strings.Repeat("x", pagerduty.MaxSummaryLength+10)
+
+		event := pagerduty.FromAlert(alert, "routing")
+		assert.Equal(t, alert.DefaultCorrelationID(), event.DedupKey)
+		assert.Equal(t, pagerduty.SeverityInfo, event.Payload.Severity)
+		assert.Equal(t, "slack-manager", event.Payload.Source)
+		assert.Len(t, event.Payload.Summary, pagerduty.MaxSummaryLength)
+		assert.Nil(t, event.Payload.CustomDetails)
+	})
+
+	t.Run("long correlation ID should be hashed", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = "Header"
+		alert.CorrelationID = strings.Repeat("x", pagerduty.MaxDedupKeyLength+1)
+
+		event := pagerduty.FromAlert(alert, "routing")
+		assert.Len(t, event.DedupKey, 64)
+		assert.Equal(t, pagerduty.SeverityError, event.Payload.Severity)
+	})
+
+	t.Run("round trip should keep the alert content", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewWarningAlert()
+		alert.CorrelationID = "key"
+		alert.Header = "Header"
+		alert.Text = "Text"
+		alert.Host = "host"
+		alert.Link = "https://example.com"
+		alert.Fields = []*types.Field{{Title: "a", Value: "1"}, {Title: "b", Value: "2"}}
+
+		alerts := pagerduty.Convert(pagerduty.FromAlert(alert, "routing"), nil)
+		require.Len(t, alerts, 1)
+
+		result := alerts[0]
+		assert.Equal(t, alert.CorrelationID, result.CorrelationID)
+		assert.Equal(t, alert.Severity, result.Severity)
+		assert.Equal(t, alert.Header, result.Header)
+		assert.Equal(t, alert.Text, result.Text)
+		assert.Equal(t, alert.Host, result.Host)
+		assert.Equal(t, alert.Link, result.Link)
+		assert.Equal(t, alert.Fields, result.Fields)
+	})
+}
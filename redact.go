@@ -0,0 +1,359 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultRedactionReplacement is the default replacement for redacted secrets.
+const DefaultRedactionReplacement = "[REDACTED]"
+
+// redactionSecretGroup is the name of the capture group holding the secret, in detectors that match surrounding context.
+const redactionSecretGroup = "secret"
+
+// Names of the built-in redaction detectors.
+const (
+	RedactionDetectorAWSAccessKey   = "aws_access_key"
+	RedactionDetectorAWSSecretKey   = "aws_secret_key"
+	RedactionDetectorPEMBlock       = "pem_block"
+	RedactionDetectorJWT            = "jwt"
+	RedactionDetectorBearerToken    = "bearer_token"
+	RedactionDetectorURLCredentials = "url_credentials"
+	RedactionDetectorSlackToken     = "slack_token"
+)
+
+// RedactionDetector detects secrets in alert content.
+type RedactionDetector struct {
+	// Name identifies the detector in the redaction report.
+	Name string
+
+	// Pattern matches the secrets to redact. If the pattern has a capture group named 'secret',
+	// only that group is replaced, and the rest of the match is kept (such as the 'Bearer ' prefix).
+	Pattern *regexp.Regexp
+}
+
+// BuiltinRedactionDetectors returns new instances of the built-in detectors, for AWS access keys, AWS secret keys (when labelled),
+// PEM blocks, JWTs, bearer tokens, passwords in URLs and Slack tokens.
+func BuiltinRedactionDetectors() []*RedactionDetector {
+	return []*RedactionDetector{
+		{Name: RedactionDetectorAWSAccessKey, Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+		{Name: RedactionDetectorAWSSecretKey, Pattern: regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})\b`)},
+		{Name: RedactionDetectorPEMBlock, Pattern: regexp.MustCompile(`-----BEGIN [A-Z0-9 ]+-----[\s\S]*?-----END [A-Z0-9 ]+-----`)},
+		{Name: RedactionDetectorJWT, Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)},
+		{Name: RedactionDetectorBearerToken, Pattern: regexp.MustCompile(`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9\-._~+/]{8,}=*)`)},
+		{Name: RedactionDetectorURLCredentials, Pattern: regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9+.-]*://[^\s:/@]*:(?P<secret>[^\s/@]+)@`)},
+		{Name: RedactionDetectorSlackToken, Pattern: regexp.MustCompile(`\b(?:xox[abeoprs]|xapp)-[A-Za-z0-9-]{10,}`)},
+	}
+}
+
+// Redactor replaces secrets, such as passwords and tokens, in the free text of an alert (see Redact).
+// Use DefaultRedactor to get a redactor with the built-in detectors.
+type Redactor struct {
+	// Detectors are applied in order to each string value.
+	Detectors []*RedactionDetector
+
+	// Replacement replaces each detected secret. DefaultRedactionReplacement is used if empty.
+	Replacement string
+}
+
+// Redaction describes the secrets redacted from a single alert value by Redactor.Redact.
+type Redaction struct {
+	// Path is the JSON path of the redacted value, such as 'text', 'fields[3].value' or 'metadata.db.url'.
+	Path string `json:"path"`
+
+	// Detector is the name of the detector that found the secrets.
+	Detector string `json:"detector"`
+
+	// Count is the number of secrets redacted from the value.
+	Count int `json:"count"`
+}
+
+// DefaultRedactor returns a new Redactor with the built-in detectors and the default replacement.
+func DefaultRedactor() *Redactor {
+	return &Redactor{
+		Detectors:   BuiltinRedactionDetectors(),
+		Replacement: DefaultRedactionReplacement,
+	}
+}
+
+// WithPattern compiles the pattern and adds it as a custom detector. It panics if the pattern is invalid.
+// It returns the redactor, for chaining.
+func (r *Redactor) WithPattern(name, pattern string) *Redactor {
+	r.Detectors = append(r.Detectors, &RedactionDetector{Name: name, Pattern: regexp.MustCompile(pattern)})
+	return r
+}
+
+// RedactString returns s with all detected secrets replaced, along with the number of secrets found by each detector.
+func (r *Redactor) RedactString(s string) (string, map[string]int) {
+	var counts map[string]int
+
+	for _, detector := range r.Detectors {
+		if detector == nil || detector.Pattern == nil {
+			continue
+		}
+
+		var count int
+
+		s, count = r.replace(s, detector.Pattern)
+
+		if count > 0 {
+			if counts == nil {
+				counts = make(map[string]int)
+			}
+
+			counts[detector.Name] += count
+		}
+	}
+
+	return s, counts
+}
+
+// Redact replaces detected secrets in the free text of the alert: header, text and their resolved variants, fallback text,
+// correlation ID, username, author, host, footer, link, field titles and values, IgnoreIfTextContains items, metadata keys
+// and values (recursively), and webhook URLs, confirmation and button texts, payload keys and values (recursively),
+// input descriptions, initial values and labels, and checkbox option texts and values. Identifiers such as the type,
+// channel ID, route key, icon emoji, webhook and input IDs, and escalation settings are not redacted.
+// It returns a report of the redacted values, sorted by path and detector.
+//
+// Metadata and payload maps (and the maps and slices nested in them) are replaced with redacted copies, so that maps shared
+// with other alerts are not modified. The replacement is URL escaped in the link and webhook URLs, so that they remain valid URLs.
+// Redaction may change the length of values, so it should be applied before Clean.
+func (r *Redactor) Redact(a *Alert) []*Redaction {
+	rr := &redaction{redactor: r}
+
+	rr.redact("header", &a.Header)
+	rr.redact("headerWhenResolved", &a.HeaderWhenResolved)
+	rr.redact("text", &a.Text)
+	rr.redact("textWhenResolved", &a.TextWhenResolved)
+	rr.redact("fallbackText", &a.FallbackText)
+	rr.redact("correlationId", &a.CorrelationID)
+	rr.redact("username", &a.Username)
+	rr.redact("author", &a.Author)
+	rr.redact("host", &a.Host)
+	rr.redact("footer", &a.Footer)
+	rr.redactURL("link", &a.Link)
+
+	for i, field := range a.Fields {
+		if field == nil {
+			continue
+		}
+
+		path := fmt.Sprintf("fields[%d]", i)
+		rr.redact(path+".title", &field.Title)
+		rr.redact(path+".value", &field.Value)
+	}
+
+	for i := range a.IgnoreIfTextContains {
+		rr.redact(fmt.Sprintf("ignoreIfTextContains[%d]", i), &a.IgnoreIfTextContains[i])
+	}
+
+	a.Metadata = rr.redactMap("metadata", a.Metadata)
+
+	for i, hook := range a.Webhooks {
+		if hook == nil {
+			continue
+		}
+
+		path := fmt.Sprintf("webhooks[%d]", i)
+		rr.redactURL(path+".url", &hook.URL)
+		rr.redact(path+".confirmationText", &hook.ConfirmationText)
+		rr.redact(path+".buttonText", &hook.ButtonText)
+		hook.Payload = rr.redactMap(path+".payload", hook.Payload)
+
+		for j, input := range hook.PlainTextInput {
+			if input == nil {
+				continue
+			}
+
+			inputPath := fmt.Sprintf("%s.plainTextInput[%d]", path, j)
+			rr.redact(inputPath+".description", &input.Description)
+			rr.redact(inputPath+".initialValue", &input.InitialValue)
+		}
+
+		for j, input := range hook.CheckboxInput {
+			if input == nil {
+				continue
+			}
+
+			inputPath := fmt.Sprintf("%s.checkboxInput[%d]", path, j)
+			rr.redact(inputPath+".label", &input.Label)
+
+			for k, option := range input.Options {
+				if option == nil {
+					continue
+				}
+
+				optionPath := fmt.Sprintf("%s.options[%d]", inputPath, k)
+				rr.redact(optionPath+".text", &option.Text)
+				rr.redact(optionPath+".value", &option.Value)
+			}
+		}
+	}
+
+	slices.SortStableFunc(rr.report, func(a, b *Redaction) int {
+		return strings.Compare(a.Path+"\x00"+a.Detector, b.Path+"\x00"+b.Detector)
+	})
+
+	return rr.report
+}
+
+func (r *Redactor) replacement() string {
+	if r.Replacement == "" {
+		return DefaultRedactionReplacement
+	}
+
+	return r.Replacement
+}
+
+// replace replaces all matches of pattern in s, or only the 'secret' group if the pattern has one, and returns the match count.
+func (r *Redactor) replace(s string, pattern *regexp.Regexp) (string, int) {
+	matches := pattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, 0
+	}
+
+	replacement := r.replacement()
+	group := pattern.SubexpIndex(redactionSecretGroup)
+
+	var b strings.Builder
+	last, count := 0, 0
+
+	for _, match := range matches {
+		start, end := match[0], match[1]
+
+		if group > 0 {
+			start, end = match[2*group], match[2*group+1]
+
+			// The secret group did not participate in the match
+			if start < 0 {
+				continue
+			}
+		}
+
+		b.WriteString(s[last:start])
+		b.WriteString(replacement)
+		last = end
+		count++
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String(), count
+}
+
+// redaction applies a redactor to alert values and records what was redacted.
+type redaction struct {
+	redactor *Redactor
+	report   []*Redaction
+}
+
+func (rr *redaction) redact(path string, s *string) {
+	rr.redactWith(rr.redactor, path, s)
+}
+
+// redactURL redacts a value that must remain a valid URL, using an escaped replacement.
+func (rr *redaction) redactURL(path string, s *string) {
+	escaped := *rr.redactor
+	escaped.Replacement = url.PathEscape(rr.redactor.replacement())
+
+	rr.redactWith(&escaped, path, s)
+}
+
+func (rr *redaction) redactWith(redactor *Redactor, path string, s *string) {
+	if *s == "" {
+		return
+	}
+
+	var counts map[string]int
+
+	*s, counts = redactor.RedactString(*s)
+
+	rr.record(path, counts)
+}
+
+func (rr *redaction) record(path string, counts map[string]int) {
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		rr.report = append(rr.report, &Redaction{Path: path, Detector: name, Count: counts[name]})
+	}
+}
+
+// redactKey redacts a map key, reporting it with the path of the redacted key.
+func (rr *redaction) redactKey(path, key string) string {
+	key, counts := rr.redactor.RedactString(key)
+	rr.record(path+"."+key, counts)
+
+	return key
+}
+
+// redactMap returns a copy of m with redacted keys and string values, recursing into nested maps and slices.
+// Keys are visited in sorted order, so that the result is deterministic if redacted keys collide.
+func (rr *redaction) redactMap(path string, m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	redacted := make(map[string]any, len(m))
+
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		redactedKey := rr.redactKey(path, key)
+		redacted[redactedKey] = rr.redactValue(path+"."+redactedKey, m[key])
+	}
+
+	return redacted
+}
+
+// redactValue returns the redacted value, copying maps and slices.
+func (rr *redaction) redactValue(path string, value any) any {
+	switch v := value.(type) {
+	case string:
+		rr.redact(path, &v)
+		return v
+	case map[string]any:
+		return rr.redactMap(path, v)
+	case map[string]string:
+		if v == nil {
+			return v
+		}
+
+		redacted := make(map[string]string, len(v))
+
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			redactedKey := rr.redactKey(path, key)
+			s := v[key]
+			rr.redact(path+"."+redactedKey, &s)
+			redacted[redactedKey] = s
+		}
+
+		return redacted
+	case []any:
+		if v == nil {
+			return v
+		}
+
+		redacted := make([]any, len(v))
+
+		for i := range v {
+			redacted[i] = rr.redactValue(fmt.Sprintf("%s[%d]", path, i), v[i])
+		}
+
+		return redacted
+	case []string:
+		if v == nil {
+			return v
+		}
+
+		redacted := slices.Clone(v)
+
+		for i := range redacted {
+			rr.redact(fmt.Sprintf("%s[%d]", path, i), &redacted[i])
+		}
+
+		return redacted
+	}
+
+	return value
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// slackControlSequenceRegex matches Slack control sequences (<...>), and &, < and > characters outside of them.
+	slackControlSequenceRegex = regexp.MustCompile(`<[^<>]*>|&(amp|lt|gt);|[&<>]`)
+
+	// slackLinkRegex matches control sequences that are intentional links, with an optional label (<https://example.com|label>).
+	slackLinkRegex = regexp.MustCompile(`^<(https?://|mailto:)[^<>\s|]+(\|[^<>]*)?>$`)
+
+	// slackBroadcastRegex matches plain text broadcast mentions, which Slack may resolve when link names are enabled.
+	// The @ must start the text or follow a character that cannot be part of an email address or host name.
+	slackBroadcastRegex = regexp.MustCompile(`(?i)(^|[^\w.+-])@(here|channel|everyone)\b`)
+
+	slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// EscapeSlackText escapes s for use in Slack mrkdwn text, so that it cannot notify anyone:
+//
+//   - &, < and > are escaped as &amp;, &lt; and &gt; (existing &amp;, &lt; and &gt; entities are kept as-is)
+//   - Control sequences such as <!channel>, <!here>, <@U123> and <#C123> are escaped, and render as plain text
+//   - Plain text @here, @channel and @everyone are broken up with a zero-width space (but not in email addresses such as ops@here.com)
+//   - Links on the format <https://example.com>, <https://example.com|label> and <mailto:...> are kept
+//
+// EscapeSlackText is idempotent, i.e. escaping an already escaped text does not change it.
+func EscapeSlackText(s string) string {
+	s = slackControlSequenceRegex.ReplaceAllStringFunc(s, func(match string) string {
+		switch {
+		case match == "&amp;", match == "&lt;", match == "&gt;":
+			return match
+		case slackLinkRegex.MatchString(match):
+			return match
+		default:
+			return slackEscaper.Replace(match)
+		}
+	})
+
+	return slackBroadcastRegex.ReplaceAllString(s, "${1}@\u200b${2}")
+}
@@ -0,0 +1,129 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeSlackText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "nothing to escape", "nothing to escape"},
+		{"special characters", "a < b && c > d", "a &lt; b &amp;&amp; c &gt; d"},
+		{"existing entities", "a &lt; b &amp; c", "a &lt; b &amp; c"},
+		{"channel mention", "ping <!channel> now", "ping &lt;!channel&gt; now"},
+		{"here mention", "<!here|here>", "&lt;!here|here&gt;"},
+		{"user mention", "ask <@U12345678>", "ask &lt;@U12345678&gt;"},
+		{"group mention", "<!subteam^S123|@team>", "&lt;!subteam^S123|@team&gt;"},
+		{"channel link", "see <#C123>", "see &lt;#C123&gt;"},
+		{"nested brackets", "<<!channel>>", "&lt;&lt;!channel&gt;&gt;"},
+		{"plain broadcast", "hey @here and @Channel, not @everyone", "hey @​here and @​Channel, not @​everyone"},
+		{"broadcast at start", "@here:(@channel)", "@​here:(@​channel)"},
+		{"email addresses", "mail ops@here.com or alerts@channel.example", "mail ops@here.com or alerts@channel.example"},
+		{"plain user handle", "ask @john", "ask @john"},
+		{"link", "see <https://example.com/a?b=1&c=2>", "see <https://example.com/a?b=1&c=2>"},
+		{"link with label", "see <http://example.com|the docs>", "see <http://example.com|the docs>"},
+		{"mailto link", "<mailto:ops@example.com|ops>", "<mailto:ops@example.com|ops>"},
+		{"unsupported scheme", "<javascript:alert(1)>", "&lt;javascript:alert(1)&gt;"},
+		{"code block", "```if a > b {}```", "```if a &gt; b {}```"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			escaped := types.EscapeSlackText(test.input)
+			assert.Equal(t, test.expected, escaped)
+			assert.Equal(t, escaped, types.EscapeSlackText(escaped), "escaping should be idempotent")
+		})
+	}
+}
+
+func TestCleanEscapeText(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func() *types.Alert {
+		return &types.Alert{
+			Header:       "Disk > 90% <!channel>",
+			Text:         "<@U12345678> see <https://example.com|runbook>",
+			FallbackText: "@here",
+			Author:       "<!here>",
+			Host:         "a&b",
+			Footer:       "<!everyone>",
+			Fields:       []*types.Field{{Title: "<@U1>", Value: "x < y"}},
+			Escalation:   []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60, SlackMentions: []string{"<!here>"}}},
+		}
+	}
+
+	t.Run("text should not be escaped by default", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.Clean()
+		assert.Equal(t, "Disk > 90% <!channel>", a.Header)
+		assert.Equal(t, "<!here>", a.Author)
+	})
+
+	t.Run("text should be escaped when enabled on the alert", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.EscapeText = true
+		changes := a.CleanWithReport()
+
+		assert.Equal(t, "Disk &gt; 90% &lt;!channel&gt;", a.Header)
+		assert.Equal(t, "&lt;@U12345678&gt; see <https://example.com|runbook>", a.Text)
+		assert.Equal(t, "@​here", a.FallbackText)
+		assert.Equal(t, "&lt;!here&gt;", a.Author)
+		assert.Equal(t, "a&amp;b", a.Host)
+		assert.Equal(t, "&lt;!everyone&gt;", a.Footer)
+		assert.Equal(t, "&lt;@U1&gt;", a.Fields[0].Title)
+		assert.Equal(t, "x &lt; y", a.Fields[0].Value)
+
+		// Escalation mentions are the controlled way to notify people, and are never escaped
+		assert.Equal(t, []string{"<!here>"}, a.Escalation[0].SlackMentions)
+
+		assert.Contains(t, changes, &types.CleanChange{Path: "header", Kind: types.CleanChangeEscaped, BeforeLength: 21, AfterLength: 30})
+		assert.Contains(t, changes, &types.CleanChange{Path: "fields[0].value", Kind: types.CleanChangeEscaped, BeforeLength: 5, AfterLength: 8})
+
+		require.NoError(t, a.Validate())
+	})
+
+	t.Run("text should be escaped when enabled in the limits", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.EscapeText = true
+
+		a := newAlert()
+		a.CleanWith(limits)
+		assert.Equal(t, "Disk &gt; 90% &lt;!channel&gt;", a.Header)
+	})
+
+	t.Run("escaping should happen before truncation, without breaking entities", func(t *testing.T) {
+		t.Parallel()
+
+		limits := types.DefaultLimits()
+		limits.MaxFieldValueLength = 10
+
+		a := &types.Alert{Header: "a", EscapeText: true, Fields: []*types.Field{{Value: "<<<<<<<<"}}}
+		a.CleanWith(limits)
+		assert.Equal(t, "&lt;...", a.Fields[0].Value)
+	})
+
+	t.Run("builder should enable escaping", func(t *testing.T) {
+		t.Parallel()
+
+		alert, err := types.NewAlertBuilder(types.AlertError).WithHeader("<!channel>").EscapeText().Build()
+		require.NoError(t, err)
+		assert.Equal(t, "&lt;!channel&gt;", alert.Header)
+	})
+}
@@ -0,0 +1,1368 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: alert.proto
+
+package typespb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AlertSeverity mirrors types.AlertSeverity. ALERT_SEVERITY_UNSPECIFIED is the empty severity.
+type AlertSeverity int32
+
+const (
+	AlertSeverity_ALERT_SEVERITY_UNSPECIFIED AlertSeverity = 0
+	AlertSeverity_ALERT_SEVERITY_PANIC       AlertSeverity = 1
+	AlertSeverity_ALERT_SEVERITY_ERROR       AlertSeverity = 2
+	AlertSeverity_ALERT_SEVERITY_WARNING     AlertSeverity = 3
+	AlertSeverity_ALERT_SEVERITY_RESOLVED    AlertSeverity = 4
+	AlertSeverity_ALERT_SEVERITY_INFO        AlertSeverity = 5
+)
+
+// Enum value maps for AlertSeverity.
+var (
+	AlertSeverity_name = map[int32]string{
+		0: "ALERT_SEVERITY_UNSPECIFIED",
+		1: "ALERT_SEVERITY_PANIC",
+		2: "ALERT_SEVERITY_ERROR",
+		3: "ALERT_SEVERITY_WARNING",
+		4: "ALERT_SEVERITY_RESOLVED",
+		5: "ALERT_SEVERITY_INFO",
+	}
+	AlertSeverity_value = map[string]int32{
+		"ALERT_SEVERITY_UNSPECIFIED": 0,
+		"ALERT_SEVERITY_PANIC":       1,
+		"ALERT_SEVERITY_ERROR":       2,
+		"ALERT_SEVERITY_WARNING":     3,
+		"ALERT_SEVERITY_RESOLVED":    4,
+		"ALERT_SEVERITY_INFO":        5,
+	}
+)
+
+func (x AlertSeverity) Enum() *AlertSeverity {
+	p := new(AlertSeverity)
+	*p = x
+	return p
+}
+
+func (x AlertSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_alert_proto_enumTypes[0].Descriptor()
+}
+
+func (AlertSeverity) Type() protoreflect.EnumType {
+	return &file_alert_proto_enumTypes[0]
+}
+
+func (x AlertSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertSeverity.Descriptor instead.
+func (AlertSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{0}
+}
+
+// WebhookButtonStyle mirrors types.WebhookButtonStyle. WEBHOOK_BUTTON_STYLE_UNSPECIFIED is the empty (default) style.
+type WebhookButtonStyle int32
+
+const (
+	WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_UNSPECIFIED WebhookButtonStyle = 0
+	WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_PRIMARY     WebhookButtonStyle = 1
+	WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_DANGER      WebhookButtonStyle = 2
+)
+
+// Enum value maps for WebhookButtonStyle.
+var (
+	WebhookButtonStyle_name = map[int32]string{
+		0: "WEBHOOK_BUTTON_STYLE_UNSPECIFIED",
+		1: "WEBHOOK_BUTTON_STYLE_PRIMARY",
+		2: "WEBHOOK_BUTTON_STYLE_DANGER",
+	}
+	WebhookButtonStyle_value = map[string]int32{
+		"WEBHOOK_BUTTON_STYLE_UNSPECIFIED": 0,
+		"WEBHOOK_BUTTON_STYLE_PRIMARY":     1,
+		"WEBHOOK_BUTTON_STYLE_DANGER":      2,
+	}
+)
+
+func (x WebhookButtonStyle) Enum() *WebhookButtonStyle {
+	p := new(WebhookButtonStyle)
+	*p = x
+	return p
+}
+
+func (x WebhookButtonStyle) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookButtonStyle) Descriptor() protoreflect.EnumDescriptor {
+	return file_alert_proto_enumTypes[1].Descriptor()
+}
+
+func (WebhookButtonStyle) Type() protoreflect.EnumType {
+	return &file_alert_proto_enumTypes[1]
+}
+
+func (x WebhookButtonStyle) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookButtonStyle.Descriptor instead.
+func (WebhookButtonStyle) EnumDescriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{1}
+}
+
+// WebhookAccessLevel mirrors types.WebhookAccessLevel. WEBHOOK_ACCESS_LEVEL_UNSPECIFIED is the empty access level.
+type WebhookAccessLevel int32
+
+const (
+	WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_UNSPECIFIED     WebhookAccessLevel = 0
+	WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_GLOBAL_ADMINS   WebhookAccessLevel = 1
+	WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_CHANNEL_ADMINS  WebhookAccessLevel = 2
+	WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_CHANNEL_MEMBERS WebhookAccessLevel = 3
+)
+
+// Enum value maps for WebhookAccessLevel.
+var (
+	WebhookAccessLevel_name = map[int32]string{
+		0: "WEBHOOK_ACCESS_LEVEL_UNSPECIFIED",
+		1: "WEBHOOK_ACCESS_LEVEL_GLOBAL_ADMINS",
+		2: "WEBHOOK_ACCESS_LEVEL_CHANNEL_ADMINS",
+		3: "WEBHOOK_ACCESS_LEVEL_CHANNEL_MEMBERS",
+	}
+	WebhookAccessLevel_value = map[string]int32{
+		"WEBHOOK_ACCESS_LEVEL_UNSPECIFIED":     0,
+		"WEBHOOK_ACCESS_LEVEL_GLOBAL_ADMINS":   1,
+		"WEBHOOK_ACCESS_LEVEL_CHANNEL_ADMINS":  2,
+		"WEBHOOK_ACCESS_LEVEL_CHANNEL_MEMBERS": 3,
+	}
+)
+
+func (x WebhookAccessLevel) Enum() *WebhookAccessLevel {
+	p := new(WebhookAccessLevel)
+	*p = x
+	return p
+}
+
+func (x WebhookAccessLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookAccessLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_alert_proto_enumTypes[2].Descriptor()
+}
+
+func (WebhookAccessLevel) Type() protoreflect.EnumType {
+	return &file_alert_proto_enumTypes[2]
+}
+
+func (x WebhookAccessLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookAccessLevel.Descriptor instead.
+func (WebhookAccessLevel) EnumDescriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{2}
+}
+
+// WebhookDisplayMode mirrors types.WebhookDisplayMode. WEBHOOK_DISPLAY_MODE_UNSPECIFIED is the empty display mode.
+type WebhookDisplayMode int32
+
+const (
+	WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_UNSPECIFIED    WebhookDisplayMode = 0
+	WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_ALWAYS         WebhookDisplayMode = 1
+	WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_OPEN_ISSUE     WebhookDisplayMode = 2
+	WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_RESOLVED_ISSUE WebhookDisplayMode = 3
+)
+
+// Enum value maps for WebhookDisplayMode.
+var (
+	WebhookDisplayMode_name = map[int32]string{
+		0: "WEBHOOK_DISPLAY_MODE_UNSPECIFIED",
+		1: "WEBHOOK_DISPLAY_MODE_ALWAYS",
+		2: "WEBHOOK_DISPLAY_MODE_OPEN_ISSUE",
+		3: "WEBHOOK_DISPLAY_MODE_RESOLVED_ISSUE",
+	}
+	WebhookDisplayMode_value = map[string]int32{
+		"WEBHOOK_DISPLAY_MODE_UNSPECIFIED":    0,
+		"WEBHOOK_DISPLAY_MODE_ALWAYS":         1,
+		"WEBHOOK_DISPLAY_MODE_OPEN_ISSUE":     2,
+		"WEBHOOK_DISPLAY_MODE_RESOLVED_ISSUE": 3,
+	}
+)
+
+func (x WebhookDisplayMode) Enum() *WebhookDisplayMode {
+	p := new(WebhookDisplayMode)
+	*p = x
+	return p
+}
+
+func (x WebhookDisplayMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDisplayMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_alert_proto_enumTypes[3].Descriptor()
+}
+
+func (WebhookDisplayMode) Type() protoreflect.EnumType {
+	return &file_alert_proto_enumTypes[3]
+}
+
+func (x WebhookDisplayMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDisplayMode.Descriptor instead.
+func (WebhookDisplayMode) EnumDescriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{3}
+}
+
+// EscalationScheduleMode mirrors types.EscalationScheduleMode. ESCALATION_SCHEDULE_MODE_UNSPECIFIED is the empty (default) mode.
+type EscalationScheduleMode int32
+
+const (
+	EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_UNSPECIFIED EscalationScheduleMode = 0
+	EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_DEFER       EscalationScheduleMode = 1
+	EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_ALTERNATE   EscalationScheduleMode = 2
+)
+
+// Enum value maps for EscalationScheduleMode.
+var (
+	EscalationScheduleMode_name = map[int32]string{
+		0: "ESCALATION_SCHEDULE_MODE_UNSPECIFIED",
+		1: "ESCALATION_SCHEDULE_MODE_DEFER",
+		2: "ESCALATION_SCHEDULE_MODE_ALTERNATE",
+	}
+	EscalationScheduleMode_value = map[string]int32{
+		"ESCALATION_SCHEDULE_MODE_UNSPECIFIED": 0,
+		"ESCALATION_SCHEDULE_MODE_DEFER":       1,
+		"ESCALATION_SCHEDULE_MODE_ALTERNATE":   2,
+	}
+)
+
+func (x EscalationScheduleMode) Enum() *EscalationScheduleMode {
+	p := new(EscalationScheduleMode)
+	*p = x
+	return p
+}
+
+func (x EscalationScheduleMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EscalationScheduleMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_alert_proto_enumTypes[4].Descriptor()
+}
+
+func (EscalationScheduleMode) Type() protoreflect.EnumType {
+	return &file_alert_proto_enumTypes[4]
+}
+
+func (x EscalationScheduleMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EscalationScheduleMode.Descriptor instead.
+func (EscalationScheduleMode) EnumDescriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{4}
+}
+
+// Alert mirrors types.Alert. See the Go type for field documentation.
+// The deprecated failOnRateLimitError field is not included, as in alert schema version 2.
+type Alert struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp                 *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	CorrelationId             string                 `protobuf:"bytes,2,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Type                      string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Header                    string                 `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
+	HeaderWhenResolved        string                 `protobuf:"bytes,5,opt,name=header_when_resolved,json=headerWhenResolved,proto3" json:"header_when_resolved,omitempty"`
+	Text                      string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	TextWhenResolved          string                 `protobuf:"bytes,7,opt,name=text_when_resolved,json=textWhenResolved,proto3" json:"text_when_resolved,omitempty"`
+	FallbackText              string                 `protobuf:"bytes,8,opt,name=fallback_text,json=fallbackText,proto3" json:"fallback_text,omitempty"`
+	Author                    string                 `protobuf:"bytes,9,opt,name=author,proto3" json:"author,omitempty"`
+	Host                      string                 `protobuf:"bytes,10,opt,name=host,proto3" json:"host,omitempty"`
+	Footer                    string                 `protobuf:"bytes,11,opt,name=footer,proto3" json:"footer,omitempty"`
+	Link                      string                 `protobuf:"bytes,12,opt,name=link,proto3" json:"link,omitempty"`
+	IssueFollowUpEnabled      bool                   `protobuf:"varint,13,opt,name=issue_follow_up_enabled,json=issueFollowUpEnabled,proto3" json:"issue_follow_up_enabled,omitempty"`
+	AutoResolveSeconds        int64                  `protobuf:"varint,14,opt,name=auto_resolve_seconds,json=autoResolveSeconds,proto3" json:"auto_resolve_seconds,omitempty"`
+	AutoResolveAsInconclusive bool                   `protobuf:"varint,15,opt,name=auto_resolve_as_inconclusive,json=autoResolveAsInconclusive,proto3" json:"auto_resolve_as_inconclusive,omitempty"`
+	Severity                  AlertSeverity          `protobuf:"varint,16,opt,name=severity,proto3,enum=slackmgr.types.v1.AlertSeverity" json:"severity,omitempty"`
+	SlackChannelId            string                 `protobuf:"bytes,17,opt,name=slack_channel_id,json=slackChannelId,proto3" json:"slack_channel_id,omitempty"`
+	RouteKey                  string                 `protobuf:"bytes,18,opt,name=route_key,json=routeKey,proto3" json:"route_key,omitempty"`
+	Username                  string                 `protobuf:"bytes,19,opt,name=username,proto3" json:"username,omitempty"`
+	IconEmoji                 string                 `protobuf:"bytes,20,opt,name=icon_emoji,json=iconEmoji,proto3" json:"icon_emoji,omitempty"`
+	Fields                    []*Field               `protobuf:"bytes,21,rep,name=fields,proto3" json:"fields,omitempty"`
+	NotificationDelaySeconds  int64                  `protobuf:"varint,22,opt,name=notification_delay_seconds,json=notificationDelaySeconds,proto3" json:"notification_delay_seconds,omitempty"`
+	ArchivingDelaySeconds     int64                  `protobuf:"varint,23,opt,name=archiving_delay_seconds,json=archivingDelaySeconds,proto3" json:"archiving_delay_seconds,omitempty"`
+	Escalation                []*Escalation          `protobuf:"bytes,24,rep,name=escalation,proto3" json:"escalation,omitempty"`
+	IgnoreIfTextContains      []string               `protobuf:"bytes,25,rep,name=ignore_if_text_contains,json=ignoreIfTextContains,proto3" json:"ignore_if_text_contains,omitempty"`
+	EscapeText                bool                   `protobuf:"varint,26,opt,name=escape_text,json=escapeText,proto3" json:"escape_text,omitempty"`
+	Webhooks                  []*Webhook             `protobuf:"bytes,27,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	Metadata                  *structpb.Struct       `protobuf:"bytes,28,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	EscalationPolicy          string                 `protobuf:"bytes,29,opt,name=escalation_policy,json=escalationPolicy,proto3" json:"escalation_policy,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_alert_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Alert) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Alert) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *Alert) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Alert) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *Alert) GetHeaderWhenResolved() string {
+	if x != nil {
+		return x.HeaderWhenResolved
+	}
+	return ""
+}
+
+func (x *Alert) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Alert) GetTextWhenResolved() string {
+	if x != nil {
+		return x.TextWhenResolved
+	}
+	return ""
+}
+
+func (x *Alert) GetFallbackText() string {
+	if x != nil {
+		return x.FallbackText
+	}
+	return ""
+}
+
+func (x *Alert) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Alert) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Alert) GetFooter() string {
+	if x != nil {
+		return x.Footer
+	}
+	return ""
+}
+
+func (x *Alert) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Alert) GetIssueFollowUpEnabled() bool {
+	if x != nil {
+		return x.IssueFollowUpEnabled
+	}
+	return false
+}
+
+func (x *Alert) GetAutoResolveSeconds() int64 {
+	if x != nil {
+		return x.AutoResolveSeconds
+	}
+	return 0
+}
+
+func (x *Alert) GetAutoResolveAsInconclusive() bool {
+	if x != nil {
+		return x.AutoResolveAsInconclusive
+	}
+	return false
+}
+
+func (x *Alert) GetSeverity() AlertSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return AlertSeverity_ALERT_SEVERITY_UNSPECIFIED
+}
+
+func (x *Alert) GetSlackChannelId() string {
+	if x != nil {
+		return x.SlackChannelId
+	}
+	return ""
+}
+
+func (x *Alert) GetRouteKey() string {
+	if x != nil {
+		return x.RouteKey
+	}
+	return ""
+}
+
+func (x *Alert) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Alert) GetIconEmoji() string {
+	if x != nil {
+		return x.IconEmoji
+	}
+	return ""
+}
+
+func (x *Alert) GetFields() []*Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Alert) GetNotificationDelaySeconds() int64 {
+	if x != nil {
+		return x.NotificationDelaySeconds
+	}
+	return 0
+}
+
+func (x *Alert) GetArchivingDelaySeconds() int64 {
+	if x != nil {
+		return x.ArchivingDelaySeconds
+	}
+	return 0
+}
+
+func (x *Alert) GetEscalation() []*Escalation {
+	if x != nil {
+		return x.Escalation
+	}
+	return nil
+}
+
+func (x *Alert) GetIgnoreIfTextContains() []string {
+	if x != nil {
+		return x.IgnoreIfTextContains
+	}
+	return nil
+}
+
+func (x *Alert) GetEscapeText() bool {
+	if x != nil {
+		return x.EscapeText
+	}
+	return false
+}
+
+func (x *Alert) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+func (x *Alert) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Alert) GetEscalationPolicy() string {
+	if x != nil {
+		return x.EscalationPolicy
+	}
+	return ""
+}
+
+// Field mirrors types.Field.
+type Field struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Field) Reset() {
+	*x = Field{}
+	mi := &file_alert_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Field) ProtoMessage() {}
+
+func (x *Field) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Field.ProtoReflect.Descriptor instead.
+func (*Field) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Field) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Field) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Escalation mirrors types.Escalation.
+type Escalation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      AlertSeverity          `protobuf:"varint,1,opt,name=severity,proto3,enum=slackmgr.types.v1.AlertSeverity" json:"severity,omitempty"`
+	DelaySeconds  int64                  `protobuf:"varint,2,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
+	SlackMentions []string               `protobuf:"bytes,3,rep,name=slack_mentions,json=slackMentions,proto3" json:"slack_mentions,omitempty"`
+	MoveToChannel string                 `protobuf:"bytes,4,opt,name=move_to_channel,json=moveToChannel,proto3" json:"move_to_channel,omitempty"`
+	Schedule      *EscalationSchedule    `protobuf:"bytes,5,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	RepeatSeconds int64                  `protobuf:"varint,6,opt,name=repeat_seconds,json=repeatSeconds,proto3" json:"repeat_seconds,omitempty"`
+	MaxRepeats    int64                  `protobuf:"varint,7,opt,name=max_repeats,json=maxRepeats,proto3" json:"max_repeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Escalation) Reset() {
+	*x = Escalation{}
+	mi := &file_alert_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Escalation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Escalation) ProtoMessage() {}
+
+func (x *Escalation) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Escalation.ProtoReflect.Descriptor instead.
+func (*Escalation) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Escalation) GetSeverity() AlertSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return AlertSeverity_ALERT_SEVERITY_UNSPECIFIED
+}
+
+func (x *Escalation) GetDelaySeconds() int64 {
+	if x != nil {
+		return x.DelaySeconds
+	}
+	return 0
+}
+
+func (x *Escalation) GetSlackMentions() []string {
+	if x != nil {
+		return x.SlackMentions
+	}
+	return nil
+}
+
+func (x *Escalation) GetMoveToChannel() string {
+	if x != nil {
+		return x.MoveToChannel
+	}
+	return ""
+}
+
+func (x *Escalation) GetSchedule() *EscalationSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *Escalation) GetRepeatSeconds() int64 {
+	if x != nil {
+		return x.RepeatSeconds
+	}
+	return 0
+}
+
+func (x *Escalation) GetMaxRepeats() int64 {
+	if x != nil {
+		return x.MaxRepeats
+	}
+	return 0
+}
+
+// EscalationSchedule mirrors types.EscalationSchedule.
+type EscalationSchedule struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TimeZone          string                 `protobuf:"bytes,1,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Windows           []*EscalationWindow    `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`
+	Holidays          []string               `protobuf:"bytes,3,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	OutsideWindows    EscalationScheduleMode `protobuf:"varint,4,opt,name=outside_windows,json=outsideWindows,proto3,enum=slackmgr.types.v1.EscalationScheduleMode" json:"outside_windows,omitempty"`
+	AlternateMentions []string               `protobuf:"bytes,5,rep,name=alternate_mentions,json=alternateMentions,proto3" json:"alternate_mentions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EscalationSchedule) Reset() {
+	*x = EscalationSchedule{}
+	mi := &file_alert_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscalationSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscalationSchedule) ProtoMessage() {}
+
+func (x *EscalationSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscalationSchedule.ProtoReflect.Descriptor instead.
+func (*EscalationSchedule) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EscalationSchedule) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *EscalationSchedule) GetWindows() []*EscalationWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *EscalationSchedule) GetHolidays() []string {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+func (x *EscalationSchedule) GetOutsideWindows() EscalationScheduleMode {
+	if x != nil {
+		return x.OutsideWindows
+	}
+	return EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_UNSPECIFIED
+}
+
+func (x *EscalationSchedule) GetAlternateMentions() []string {
+	if x != nil {
+		return x.AlternateMentions
+	}
+	return nil
+}
+
+// EscalationWindow mirrors types.EscalationWindow.
+type EscalationWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weekdays      []string               `protobuf:"bytes,1,rep,name=weekdays,proto3" json:"weekdays,omitempty"`
+	Start         string                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscalationWindow) Reset() {
+	*x = EscalationWindow{}
+	mi := &file_alert_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscalationWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscalationWindow) ProtoMessage() {}
+
+func (x *EscalationWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscalationWindow.ProtoReflect.Descriptor instead.
+func (*EscalationWindow) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EscalationWindow) GetWeekdays() []string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return nil
+}
+
+func (x *EscalationWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *EscalationWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+// Webhook mirrors types.Webhook.
+type Webhook struct {
+	state            protoimpl.MessageState   `protogen:"open.v1"`
+	Id               string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url              string                   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ConfirmationText string                   `protobuf:"bytes,3,opt,name=confirmation_text,json=confirmationText,proto3" json:"confirmation_text,omitempty"`
+	ButtonText       string                   `protobuf:"bytes,4,opt,name=button_text,json=buttonText,proto3" json:"button_text,omitempty"`
+	ButtonStyle      WebhookButtonStyle       `protobuf:"varint,5,opt,name=button_style,json=buttonStyle,proto3,enum=slackmgr.types.v1.WebhookButtonStyle" json:"button_style,omitempty"`
+	AccessLevel      WebhookAccessLevel       `protobuf:"varint,6,opt,name=access_level,json=accessLevel,proto3,enum=slackmgr.types.v1.WebhookAccessLevel" json:"access_level,omitempty"`
+	DisplayMode      WebhookDisplayMode       `protobuf:"varint,7,opt,name=display_mode,json=displayMode,proto3,enum=slackmgr.types.v1.WebhookDisplayMode" json:"display_mode,omitempty"`
+	Payload          *structpb.Struct         `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	PlainTextInput   []*WebhookPlainTextInput `protobuf:"bytes,9,rep,name=plain_text_input,json=plainTextInput,proto3" json:"plain_text_input,omitempty"`
+	CheckboxInput    []*WebhookCheckboxInput  `protobuf:"bytes,10,rep,name=checkbox_input,json=checkboxInput,proto3" json:"checkbox_input,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_alert_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetConfirmationText() string {
+	if x != nil {
+		return x.ConfirmationText
+	}
+	return ""
+}
+
+func (x *Webhook) GetButtonText() string {
+	if x != nil {
+		return x.ButtonText
+	}
+	return ""
+}
+
+func (x *Webhook) GetButtonStyle() WebhookButtonStyle {
+	if x != nil {
+		return x.ButtonStyle
+	}
+	return WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_UNSPECIFIED
+}
+
+func (x *Webhook) GetAccessLevel() WebhookAccessLevel {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_UNSPECIFIED
+}
+
+func (x *Webhook) GetDisplayMode() WebhookDisplayMode {
+	if x != nil {
+		return x.DisplayMode
+	}
+	return WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_UNSPECIFIED
+}
+
+func (x *Webhook) GetPayload() *structpb.Struct {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Webhook) GetPlainTextInput() []*WebhookPlainTextInput {
+	if x != nil {
+		return x.PlainTextInput
+	}
+	return nil
+}
+
+func (x *Webhook) GetCheckboxInput() []*WebhookCheckboxInput {
+	if x != nil {
+		return x.CheckboxInput
+	}
+	return nil
+}
+
+// WebhookPlainTextInput mirrors types.WebhookPlainTextInput.
+type WebhookPlainTextInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	MinLength     int64                  `protobuf:"varint,3,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	MaxLength     int64                  `protobuf:"varint,4,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	Multiline     bool                   `protobuf:"varint,5,opt,name=multiline,proto3" json:"multiline,omitempty"`
+	InitialValue  string                 `protobuf:"bytes,6,opt,name=initial_value,json=initialValue,proto3" json:"initial_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookPlainTextInput) Reset() {
+	*x = WebhookPlainTextInput{}
+	mi := &file_alert_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookPlainTextInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookPlainTextInput) ProtoMessage() {}
+
+func (x *WebhookPlainTextInput) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookPlainTextInput.ProtoReflect.Descriptor instead.
+func (*WebhookPlainTextInput) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WebhookPlainTextInput) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookPlainTextInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WebhookPlainTextInput) GetMinLength() int64 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *WebhookPlainTextInput) GetMaxLength() int64 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *WebhookPlainTextInput) GetMultiline() bool {
+	if x != nil {
+		return x.Multiline
+	}
+	return false
+}
+
+func (x *WebhookPlainTextInput) GetInitialValue() string {
+	if x != nil {
+		return x.InitialValue
+	}
+	return ""
+}
+
+// WebhookCheckboxInput mirrors types.WebhookCheckboxInput.
+type WebhookCheckboxInput struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Id            string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label         string                   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Options       []*WebhookCheckboxOption `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookCheckboxInput) Reset() {
+	*x = WebhookCheckboxInput{}
+	mi := &file_alert_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookCheckboxInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookCheckboxInput) ProtoMessage() {}
+
+func (x *WebhookCheckboxInput) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookCheckboxInput.ProtoReflect.Descriptor instead.
+func (*WebhookCheckboxInput) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WebhookCheckboxInput) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookCheckboxInput) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *WebhookCheckboxInput) GetOptions() []*WebhookCheckboxOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// WebhookCheckboxOption mirrors types.WebhookCheckboxOption.
+type WebhookCheckboxOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Selected      bool                   `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookCheckboxOption) Reset() {
+	*x = WebhookCheckboxOption{}
+	mi := &file_alert_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookCheckboxOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookCheckboxOption) ProtoMessage() {}
+
+func (x *WebhookCheckboxOption) ProtoReflect() protoreflect.Message {
+	mi := &file_alert_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookCheckboxOption.ProtoReflect.Descriptor instead.
+func (*WebhookCheckboxOption) Descriptor() ([]byte, []int) {
+	return file_alert_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WebhookCheckboxOption) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *WebhookCheckboxOption) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *WebhookCheckboxOption) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+var File_alert_proto protoreflect.FileDescriptor
+
+const file_alert_proto_rawDesc = "" +
+	"\n" +
+	"\valert.proto\x12\x11slackmgr.types.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc8\t\n" +
+	"\x05Alert\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12%\n" +
+	"\x0ecorrelation_id\x18\x02 \x01(\tR\rcorrelationId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06header\x18\x04 \x01(\tR\x06header\x120\n" +
+	"\x14header_when_resolved\x18\x05 \x01(\tR\x12headerWhenResolved\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12,\n" +
+	"\x12text_when_resolved\x18\a \x01(\tR\x10textWhenResolved\x12#\n" +
+	"\rfallback_text\x18\b \x01(\tR\ffallbackText\x12\x16\n" +
+	"\x06author\x18\t \x01(\tR\x06author\x12\x12\n" +
+	"\x04host\x18\n" +
+	" \x01(\tR\x04host\x12\x16\n" +
+	"\x06footer\x18\v \x01(\tR\x06footer\x12\x12\n" +
+	"\x04link\x18\f \x01(\tR\x04link\x125\n" +
+	"\x17issue_follow_up_enabled\x18\r \x01(\bR\x14issueFollowUpEnabled\x120\n" +
+	"\x14auto_resolve_seconds\x18\x0e \x01(\x03R\x12autoResolveSeconds\x12?\n" +
+	"\x1cauto_resolve_as_inconclusive\x18\x0f \x01(\bR\x19autoResolveAsInconclusive\x12<\n" +
+	"\bseverity\x18\x10 \x01(\x0e2 .slackmgr.types.v1.AlertSeverityR\bseverity\x12(\n" +
+	"\x10slack_channel_id\x18\x11 \x01(\tR\x0eslackChannelId\x12\x1b\n" +
+	"\troute_key\x18\x12 \x01(\tR\brouteKey\x12\x1a\n" +
+	"\busername\x18\x13 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"icon_emoji\x18\x14 \x01(\tR\ticonEmoji\x120\n" +
+	"\x06fields\x18\x15 \x03(\v2\x18.slackmgr.types.v1.FieldR\x06fields\x12<\n" +
+	"\x1anotification_delay_seconds\x18\x16 \x01(\x03R\x18notificationDelaySeconds\x126\n" +
+	"\x17archiving_delay_seconds\x18\x17 \x01(\x03R\x15archivingDelaySeconds\x12=\n" +
+	"\n" +
+	"escalation\x18\x18 \x03(\v2\x1d.slackmgr.types.v1.EscalationR\n" +
+	"escalation\x125\n" +
+	"\x17ignore_if_text_contains\x18\x19 \x03(\tR\x14ignoreIfTextContains\x12\x1f\n" +
+	"\vescape_text\x18\x1a \x01(\bR\n" +
+	"escapeText\x126\n" +
+	"\bwebhooks\x18\x1b \x03(\v2\x1a.slackmgr.types.v1.WebhookR\bwebhooks\x123\n" +
+	"\bmetadata\x18\x1c \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12+\n" +
+	"\x11escalation_policy\x18\x1d \x01(\tR\x10escalationPolicy\"3\n" +
+	"\x05Field\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xc9\x02\n" +
+	"\n" +
+	"Escalation\x12<\n" +
+	"\bseverity\x18\x01 \x01(\x0e2 .slackmgr.types.v1.AlertSeverityR\bseverity\x12#\n" +
+	"\rdelay_seconds\x18\x02 \x01(\x03R\fdelaySeconds\x12%\n" +
+	"\x0eslack_mentions\x18\x03 \x03(\tR\rslackMentions\x12&\n" +
+	"\x0fmove_to_channel\x18\x04 \x01(\tR\rmoveToChannel\x12A\n" +
+	"\bschedule\x18\x05 \x01(\v2%.slackmgr.types.v1.EscalationScheduleR\bschedule\x12%\n" +
+	"\x0erepeat_seconds\x18\x06 \x01(\x03R\rrepeatSeconds\x12\x1f\n" +
+	"\vmax_repeats\x18\a \x01(\x03R\n" +
+	"maxRepeats\"\x8f\x02\n" +
+	"\x12EscalationSchedule\x12\x1b\n" +
+	"\ttime_zone\x18\x01 \x01(\tR\btimeZone\x12=\n" +
+	"\awindows\x18\x02 \x03(\v2#.slackmgr.types.v1.EscalationWindowR\awindows\x12\x1a\n" +
+	"\bholidays\x18\x03 \x03(\tR\bholidays\x12R\n" +
+	"\x0foutside_windows\x18\x04 \x01(\x0e2).slackmgr.types.v1.EscalationScheduleModeR\x0eoutsideWindows\x12-\n" +
+	"\x12alternate_mentions\x18\x05 \x03(\tR\x11alternateMentions\"V\n" +
+	"\x10EscalationWindow\x12\x1a\n" +
+	"\bweekdays\x18\x01 \x03(\tR\bweekdays\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\"\xae\x04\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12+\n" +
+	"\x11confirmation_text\x18\x03 \x01(\tR\x10confirmationText\x12\x1f\n" +
+	"\vbutton_text\x18\x04 \x01(\tR\n" +
+	"buttonText\x12H\n" +
+	"\fbutton_style\x18\x05 \x01(\x0e2%.slackmgr.types.v1.WebhookButtonStyleR\vbuttonStyle\x12H\n" +
+	"\faccess_level\x18\x06 \x01(\x0e2%.slackmgr.types.v1.WebhookAccessLevelR\vaccessLevel\x12H\n" +
+	"\fdisplay_mode\x18\a \x01(\x0e2%.slackmgr.types.v1.WebhookDisplayModeR\vdisplayMode\x121\n" +
+	"\apayload\x18\b \x01(\v2\x17.google.protobuf.StructR\apayload\x12R\n" +
+	"\x10plain_text_input\x18\t \x03(\v2(.slackmgr.types.v1.WebhookPlainTextInputR\x0eplainTextInput\x12N\n" +
+	"\x0echeckbox_input\x18\n" +
+	" \x03(\v2'.slackmgr.types.v1.WebhookCheckboxInputR\rcheckboxInput\"\xca\x01\n" +
+	"\x15WebhookPlainTextInput\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\x03 \x01(\x03R\tminLength\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x04 \x01(\x03R\tmaxLength\x12\x1c\n" +
+	"\tmultiline\x18\x05 \x01(\bR\tmultiline\x12#\n" +
+	"\rinitial_value\x18\x06 \x01(\tR\finitialValue\"\x80\x01\n" +
+	"\x14WebhookCheckboxInput\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12B\n" +
+	"\aoptions\x18\x03 \x03(\v2(.slackmgr.types.v1.WebhookCheckboxOptionR\aoptions\"]\n" +
+	"\x15WebhookCheckboxOption\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1a\n" +
+	"\bselected\x18\x03 \x01(\bR\bselected*\xb5\x01\n" +
+	"\rAlertSeverity\x12\x1e\n" +
+	"\x1aALERT_SEVERITY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ALERT_SEVERITY_PANIC\x10\x01\x12\x18\n" +
+	"\x14ALERT_SEVERITY_ERROR\x10\x02\x12\x1a\n" +
+	"\x16ALERT_SEVERITY_WARNING\x10\x03\x12\x1b\n" +
+	"\x17ALERT_SEVERITY_RESOLVED\x10\x04\x12\x17\n" +
+	"\x13ALERT_SEVERITY_INFO\x10\x05*}\n" +
+	"\x12WebhookButtonStyle\x12$\n" +
+	" WEBHOOK_BUTTON_STYLE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cWEBHOOK_BUTTON_STYLE_PRIMARY\x10\x01\x12\x1f\n" +
+	"\x1bWEBHOOK_BUTTON_STYLE_DANGER\x10\x02*\xb5\x01\n" +
+	"\x12WebhookAccessLevel\x12$\n" +
+	" WEBHOOK_ACCESS_LEVEL_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"WEBHOOK_ACCESS_LEVEL_GLOBAL_ADMINS\x10\x01\x12'\n" +
+	"#WEBHOOK_ACCESS_LEVEL_CHANNEL_ADMINS\x10\x02\x12(\n" +
+	"$WEBHOOK_ACCESS_LEVEL_CHANNEL_MEMBERS\x10\x03*\xa9\x01\n" +
+	"\x12WebhookDisplayMode\x12$\n" +
+	" WEBHOOK_DISPLAY_MODE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bWEBHOOK_DISPLAY_MODE_ALWAYS\x10\x01\x12#\n" +
+	"\x1fWEBHOOK_DISPLAY_MODE_OPEN_ISSUE\x10\x02\x12'\n" +
+	"#WEBHOOK_DISPLAY_MODE_RESOLVED_ISSUE\x10\x03*\x8e\x01\n" +
+	"\x16EscalationScheduleMode\x12(\n" +
+	"$ESCALATION_SCHEDULE_MODE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eESCALATION_SCHEDULE_MODE_DEFER\x10\x01\x12&\n" +
+	"\"ESCALATION_SCHEDULE_MODE_ALTERNATE\x10\x02B#Z!github.com/slackmgr/types/typespbb\x06proto3"
+
+var (
+	file_alert_proto_rawDescOnce sync.Once
+	file_alert_proto_rawDescData []byte
+)
+
+func file_alert_proto_rawDescGZIP() []byte {
+	file_alert_proto_rawDescOnce.Do(func() {
+		file_alert_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_alert_proto_rawDesc), len(file_alert_proto_rawDesc)))
+	})
+	return file_alert_proto_rawDescData
+}
+
+var file_alert_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_alert_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_alert_proto_goTypes = []any{
+	(AlertSeverity)(0),            // 0: slackmgr.types.v1.AlertSeverity
+	(WebhookButtonStyle)(0),       // 1: slackmgr.types.v1.WebhookButtonStyle
+	(WebhookAccessLevel)(0),       // 2: slackmgr.types.v1.WebhookAccessLevel
+	(WebhookDisplayMode)(0),       // 3: slackmgr.types.v1.WebhookDisplayMode
+	(EscalationScheduleMode)(0),   // 4: slackmgr.types.v1.EscalationScheduleMode
+	(*Alert)(nil),                 // 5: slackmgr.types.v1.Alert
+	(*Field)(nil),                 // 6: slackmgr.types.v1.Field
+	(*Escalation)(nil),            // 7: slackmgr.types.v1.Escalation
+	(*EscalationSchedule)(nil),    // 8: slackmgr.types.v1.EscalationSchedule
+	(*EscalationWindow)(nil),      // 9: slackmgr.types.v1.EscalationWindow
+	(*Webhook)(nil),               // 10: slackmgr.types.v1.Webhook
+	(*WebhookPlainTextInput)(nil), // 11: slackmgr.types.v1.WebhookPlainTextInput
+	(*WebhookCheckboxInput)(nil),  // 12: slackmgr.types.v1.WebhookCheckboxInput
+	(*WebhookCheckboxOption)(nil), // 13: slackmgr.types.v1.WebhookCheckboxOption
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 15: google.protobuf.Struct
+}
+var file_alert_proto_depIdxs = []int32{
+	14, // 0: slackmgr.types.v1.Alert.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 1: slackmgr.types.v1.Alert.severity:type_name -> slackmgr.types.v1.AlertSeverity
+	6,  // 2: slackmgr.types.v1.Alert.fields:type_name -> slackmgr.types.v1.Field
+	7,  // 3: slackmgr.types.v1.Alert.escalation:type_name -> slackmgr.types.v1.Escalation
+	10, // 4: slackmgr.types.v1.Alert.webhooks:type_name -> slackmgr.types.v1.Webhook
+	15, // 5: slackmgr.types.v1.Alert.metadata:type_name -> google.protobuf.Struct
+	0,  // 6: slackmgr.types.v1.Escalation.severity:type_name -> slackmgr.types.v1.AlertSeverity
+	8,  // 7: slackmgr.types.v1.Escalation.schedule:type_name -> slackmgr.types.v1.EscalationSchedule
+	9,  // 8: slackmgr.types.v1.EscalationSchedule.windows:type_name -> slackmgr.types.v1.EscalationWindow
+	4,  // 9: slackmgr.types.v1.EscalationSchedule.outside_windows:type_name -> slackmgr.types.v1.EscalationScheduleMode
+	1,  // 10: slackmgr.types.v1.Webhook.button_style:type_name -> slackmgr.types.v1.WebhookButtonStyle
+	2,  // 11: slackmgr.types.v1.Webhook.access_level:type_name -> slackmgr.types.v1.WebhookAccessLevel
+	3,  // 12: slackmgr.types.v1.Webhook.display_mode:type_name -> slackmgr.types.v1.WebhookDisplayMode
+	15, // 13: slackmgr.types.v1.Webhook.payload:type_name -> google.protobuf.Struct
+	11, // 14: slackmgr.types.v1.Webhook.plain_text_input:type_name -> slackmgr.types.v1.WebhookPlainTextInput
+	12, // 15: slackmgr.types.v1.Webhook.checkbox_input:type_name -> slackmgr.types.v1.WebhookCheckboxInput
+	13, // 16: slackmgr.types.v1.WebhookCheckboxInput.options:type_name -> slackmgr.types.v1.WebhookCheckboxOption
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_alert_proto_init() }
+func file_alert_proto_init() {
+	if File_alert_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_alert_proto_rawDesc), len(file_alert_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_alert_proto_goTypes,
+		DependencyIndexes: file_alert_proto_depIdxs,
+		EnumInfos:         file_alert_proto_enumTypes,
+		MessageInfos:      file_alert_proto_msgTypes,
+	}.Build()
+	File_alert_proto = out.File
+	file_alert_proto_goTypes = nil
+	file_alert_proto_depIdxs = nil
+}
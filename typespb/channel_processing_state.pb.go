@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: channel_processing_state.proto
+
+package typespb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ChannelProcessingState mirrors types.ChannelProcessingState.
+type ChannelProcessingState struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId           string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Created             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	LastChannelActivity *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_channel_activity,json=lastChannelActivity,proto3" json:"last_channel_activity,omitempty"`
+	LastProcessed       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_processed,json=lastProcessed,proto3" json:"last_processed,omitempty"`
+	OpenIssues          int64                  `protobuf:"varint,5,opt,name=open_issues,json=openIssues,proto3" json:"open_issues,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ChannelProcessingState) Reset() {
+	*x = ChannelProcessingState{}
+	mi := &file_channel_processing_state_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelProcessingState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelProcessingState) ProtoMessage() {}
+
+func (x *ChannelProcessingState) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_processing_state_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelProcessingState.ProtoReflect.Descriptor instead.
+func (*ChannelProcessingState) Descriptor() ([]byte, []int) {
+	return file_channel_processing_state_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChannelProcessingState) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ChannelProcessingState) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *ChannelProcessingState) GetLastChannelActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastChannelActivity
+	}
+	return nil
+}
+
+func (x *ChannelProcessingState) GetLastProcessed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastProcessed
+	}
+	return nil
+}
+
+func (x *ChannelProcessingState) GetOpenIssues() int64 {
+	if x != nil {
+		return x.OpenIssues
+	}
+	return 0
+}
+
+var File_channel_processing_state_proto protoreflect.FileDescriptor
+
+const file_channel_processing_state_proto_rawDesc = "" +
+	"\n" +
+	"\x1echannel_processing_state.proto\x12\x11slackmgr.types.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa1\x02\n" +
+	"\x16ChannelProcessingState\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x124\n" +
+	"\acreated\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x12N\n" +
+	"\x15last_channel_activity\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x13lastChannelActivity\x12A\n" +
+	"\x0elast_processed\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rlastProcessed\x12\x1f\n" +
+	"\vopen_issues\x18\x05 \x01(\x03R\n" +
+	"openIssuesB#Z!github.com/slackmgr/types/typespbb\x06proto3"
+
+var (
+	file_channel_processing_state_proto_rawDescOnce sync.Once
+	file_channel_processing_state_proto_rawDescData []byte
+)
+
+func file_channel_processing_state_proto_rawDescGZIP() []byte {
+	file_channel_processing_state_proto_rawDescOnce.Do(func() {
+		file_channel_processing_state_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_channel_processing_state_proto_rawDesc), len(file_channel_processing_state_proto_rawDesc)))
+	})
+	return file_channel_processing_state_proto_rawDescData
+}
+
+var file_channel_processing_state_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_channel_processing_state_proto_goTypes = []any{
+	(*ChannelProcessingState)(nil), // 0: slackmgr.types.v1.ChannelProcessingState
+	(*timestamppb.Timestamp)(nil),  // 1: google.protobuf.Timestamp
+}
+var file_channel_processing_state_proto_depIdxs = []int32{
+	1, // 0: slackmgr.types.v1.ChannelProcessingState.created:type_name -> google.protobuf.Timestamp
+	1, // 1: slackmgr.types.v1.ChannelProcessingState.last_channel_activity:type_name -> google.protobuf.Timestamp
+	1, // 2: slackmgr.types.v1.ChannelProcessingState.last_processed:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_channel_processing_state_proto_init() }
+func file_channel_processing_state_proto_init() {
+	if File_channel_processing_state_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_channel_processing_state_proto_rawDesc), len(file_channel_processing_state_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_channel_processing_state_proto_goTypes,
+		DependencyIndexes: file_channel_processing_state_proto_depIdxs,
+		MessageInfos:      file_channel_processing_state_proto_msgTypes,
+	}.Build()
+	File_channel_processing_state_proto = out.File
+	file_channel_processing_state_proto_goTypes = nil
+	file_channel_processing_state_proto_depIdxs = nil
+}
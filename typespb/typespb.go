@@ -0,0 +1,647 @@
+// Package typespb provides Protocol Buffers definitions for Alert, WebhookCallback and ChannelProcessingState,
+// for compact binary encoding on queues and gRPC ingest, with conversion functions to and from the types package.
+//
+// The .proto files are in this directory, and the Go code is generated with 'make proto' (requires buf and protoc-gen-go).
+//
+// Usage on a queue:
+//
+//	data, err := typespb.MarshalAlert(alert)
+//	if err != nil {
+//	    // handle error
+//	}
+//
+//	alert, err := typespb.UnmarshalAlert(data)
+//
+// Conversion is lossless, with these exceptions:
+//   - Timestamps are converted to UTC, and the zero time is encoded as an unset timestamp.
+//   - Nil and empty lists (and webhook callback input maps) are not distinguished, and are decoded as nil.
+//   - Nil list items are skipped.
+//   - Metadata and payload values are encoded as google.protobuf.Struct, which holds the same values as JSON:
+//     numbers are decoded as float64, and other Go types (such as []string) are converted as by a JSON round-trip.
+//   - The deprecated Alert.FailOnRateLimitError field is not encoded, as in alert schema version 2.
+//
+// Enum values that have no proto equivalent (such as an uncleaned alert severity) are reported as errors,
+// so clean alerts before converting them.
+package typespb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/slackmgr/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var severities = map[types.AlertSeverity]AlertSeverity{
+	"":                  AlertSeverity_ALERT_SEVERITY_UNSPECIFIED,
+	types.AlertPanic:    AlertSeverity_ALERT_SEVERITY_PANIC,
+	types.AlertError:    AlertSeverity_ALERT_SEVERITY_ERROR,
+	types.AlertWarning:  AlertSeverity_ALERT_SEVERITY_WARNING,
+	types.AlertResolved: AlertSeverity_ALERT_SEVERITY_RESOLVED,
+	types.AlertInfo:     AlertSeverity_ALERT_SEVERITY_INFO,
+}
+
+var buttonStyles = map[types.WebhookButtonStyle]WebhookButtonStyle{
+	"":                              WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_UNSPECIFIED,
+	types.WebhookButtonStylePrimary: WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_PRIMARY,
+	types.WebhookButtonStyleDanger:  WebhookButtonStyle_WEBHOOK_BUTTON_STYLE_DANGER,
+}
+
+var accessLevels = map[types.WebhookAccessLevel]WebhookAccessLevel{
+	"":                                     WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_UNSPECIFIED,
+	types.WebhookAccessLevelGlobalAdmins:   WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_GLOBAL_ADMINS,
+	types.WebhookAccessLevelChannelAdmins:  WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_CHANNEL_ADMINS,
+	types.WebhookAccessLevelChannelMembers: WebhookAccessLevel_WEBHOOK_ACCESS_LEVEL_CHANNEL_MEMBERS,
+}
+
+var displayModes = map[types.WebhookDisplayMode]WebhookDisplayMode{
+	"":                                    WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_UNSPECIFIED,
+	types.WebhookDisplayModeAlways:        WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_ALWAYS,
+	types.WebhookDisplayModeOpenIssue:     WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_OPEN_ISSUE,
+	types.WebhookDisplayModeResolvedIssue: WebhookDisplayMode_WEBHOOK_DISPLAY_MODE_RESOLVED_ISSUE,
+}
+
+var scheduleModes = map[types.EscalationScheduleMode]EscalationScheduleMode{
+	"":                                EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_UNSPECIFIED,
+	types.EscalationScheduleDefer:     EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_DEFER,
+	types.EscalationScheduleAlternate: EscalationScheduleMode_ESCALATION_SCHEDULE_MODE_ALTERNATE,
+}
+
+// MarshalAlert encodes the alert in the Protocol Buffers binary format.
+func MarshalAlert(a *types.Alert) ([]byte, error) {
+	msg, err := FromAlert(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(msg, "alert")
+}
+
+// UnmarshalAlert decodes an alert encoded by MarshalAlert.
+func UnmarshalAlert(data []byte) (*types.Alert, error) {
+	msg := &Alert{}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+
+	return ToAlert(msg)
+}
+
+// MarshalWebhookCallback encodes the webhook callback in the Protocol Buffers binary format.
+func MarshalWebhookCallback(c *types.WebhookCallback) ([]byte, error) {
+	msg, err := FromWebhookCallback(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(msg, "webhook callback")
+}
+
+// UnmarshalWebhookCallback decodes a webhook callback encoded by MarshalWebhookCallback.
+func UnmarshalWebhookCallback(data []byte) (*types.WebhookCallback, error) {
+	msg := &WebhookCallback{}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook callback: %w", err)
+	}
+
+	return ToWebhookCallback(msg), nil
+}
+
+// MarshalChannelProcessingState encodes the channel processing state in the Protocol Buffers binary format.
+func MarshalChannelProcessingState(s *types.ChannelProcessingState) ([]byte, error) {
+	return marshal(FromChannelProcessingState(s), "channel processing state")
+}
+
+// UnmarshalChannelProcessingState decodes a channel processing state encoded by MarshalChannelProcessingState.
+func UnmarshalChannelProcessingState(data []byte) (*types.ChannelProcessingState, error) {
+	msg := &ChannelProcessingState{}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode channel processing state: %w", err)
+	}
+
+	return ToChannelProcessingState(msg), nil
+}
+
+// FromAlert converts an alert to its proto message. It returns nil if the alert is nil.
+// An error is returned if an enum value has no proto equivalent, or if the metadata or a webhook payload cannot be encoded.
+func FromAlert(a *types.Alert) (*Alert, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	severity, ok := severities[a.Severity]
+	if !ok {
+		return nil, fmt.Errorf("unsupported alert severity %q", a.Severity)
+	}
+
+	metadata, err := fromMap(a.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode alert metadata: %w", err)
+	}
+
+	msg := &Alert{
+		Timestamp:                 fromTime(a.Timestamp),
+		CorrelationId:             a.CorrelationID,
+		Type:                      a.Type,
+		Header:                    a.Header,
+		HeaderWhenResolved:        a.HeaderWhenResolved,
+		Text:                      a.Text,
+		TextWhenResolved:          a.TextWhenResolved,
+		FallbackText:              a.FallbackText,
+		Author:                    a.Author,
+		Host:                      a.Host,
+		Footer:                    a.Footer,
+		Link:                      a.Link,
+		IssueFollowUpEnabled:      a.IssueFollowUpEnabled,
+		AutoResolveSeconds:        int64(a.AutoResolveSeconds),
+		AutoResolveAsInconclusive: a.AutoResolveAsInconclusive,
+		Severity:                  severity,
+		SlackChannelId:            a.SlackChannelID,
+		RouteKey:                  a.RouteKey,
+		Username:                  a.Username,
+		IconEmoji:                 a.IconEmoji,
+		NotificationDelaySeconds:  int64(a.NotificationDelaySeconds),
+		ArchivingDelaySeconds:     int64(a.ArchivingDelaySeconds),
+		EscalationPolicy:          a.EscalationPolicy,
+		IgnoreIfTextContains:      a.IgnoreIfTextContains,
+		EscapeText:                a.EscapeText,
+		Metadata:                  metadata,
+	}
+
+	for _, field := range a.Fields {
+		if field != nil {
+			msg.Fields = append(msg.Fields, &Field{Title: field.Title, Value: field.Value})
+		}
+	}
+
+	for i, escalation := range a.Escalation {
+		if escalation == nil {
+			continue
+		}
+
+		severity, ok := severities[escalation.Severity]
+		if !ok {
+			return nil, fmt.Errorf("unsupported severity %q in escalation[%d]", escalation.Severity, i)
+		}
+
+		schedule, err := fromEscalationSchedule(escalation.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode escalation[%d]: %w", i, err)
+		}
+
+		msg.Escalation = append(msg.Escalation, &Escalation{
+			Severity:      severity,
+			DelaySeconds:  int64(escalation.DelaySeconds),
+			SlackMentions: escalation.SlackMentions,
+			MoveToChannel: escalation.MoveToChannel,
+			Schedule:      schedule,
+			RepeatSeconds: int64(escalation.RepeatSeconds),
+			MaxRepeats:    int64(escalation.MaxRepeats),
+		})
+	}
+
+	for i, hook := range a.Webhooks {
+		if hook == nil {
+			continue
+		}
+
+		webhook, err := fromWebhook(hook)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode webhooks[%d]: %w", i, err)
+		}
+
+		msg.Webhooks = append(msg.Webhooks, webhook)
+	}
+
+	return msg, nil
+}
+
+// ToAlert converts a proto message to an alert. It returns nil if the message is nil.
+// An error is returned if an enum value is unknown, such as a value added in a newer version of the proto definitions.
+func ToAlert(msg *Alert) (*types.Alert, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	severity, err := toEnum(severities, msg.GetSeverity())
+	if err != nil {
+		return nil, err
+	}
+
+	a := &types.Alert{
+		Timestamp:                 toTime(msg.GetTimestamp()),
+		CorrelationID:             msg.GetCorrelationId(),
+		Type:                      msg.GetType(),
+		Header:                    msg.GetHeader(),
+		HeaderWhenResolved:        msg.GetHeaderWhenResolved(),
+		Text:                      msg.GetText(),
+		TextWhenResolved:          msg.GetTextWhenResolved(),
+		FallbackText:              msg.GetFallbackText(),
+		Author:                    msg.GetAuthor(),
+		Host:                      msg.GetHost(),
+		Footer:                    msg.GetFooter(),
+		Link:                      msg.GetLink(),
+		IssueFollowUpEnabled:      msg.GetIssueFollowUpEnabled(),
+		AutoResolveSeconds:        int(msg.GetAutoResolveSeconds()),
+		AutoResolveAsInconclusive: msg.GetAutoResolveAsInconclusive(),
+		Severity:                  severity,
+		SlackChannelID:            msg.GetSlackChannelId(),
+		RouteKey:                  msg.GetRouteKey(),
+		Username:                  msg.GetUsername(),
+		IconEmoji:                 msg.GetIconEmoji(),
+		NotificationDelaySeconds:  int(msg.GetNotificationDelaySeconds()),
+		ArchivingDelaySeconds:     int(msg.GetArchivingDelaySeconds()),
+		EscalationPolicy:          msg.GetEscalationPolicy(),
+		IgnoreIfTextContains:      msg.GetIgnoreIfTextContains(),
+		EscapeText:                msg.GetEscapeText(),
+		Metadata:                  toMap(msg.GetMetadata()),
+	}
+
+	for _, field := range msg.GetFields() {
+		a.Fields = append(a.Fields, &types.Field{Title: field.GetTitle(), Value: field.GetValue()})
+	}
+
+	for i, escalation := range msg.GetEscalation() {
+		severity, err := toEnum(severities, escalation.GetSeverity())
+		if err != nil {
+			return nil, fmt.Errorf("invalid escalation[%d]: %w", i, err)
+		}
+
+		schedule, err := toEscalationSchedule(escalation.GetSchedule())
+		if err != nil {
+			return nil, fmt.Errorf("invalid escalation[%d]: %w", i, err)
+		}
+
+		a.Escalation = append(a.Escalation, &types.Escalation{
+			Severity:      severity,
+			DelaySeconds:  int(escalation.GetDelaySeconds()),
+			SlackMentions: escalation.GetSlackMentions(),
+			MoveToChannel: escalation.GetMoveToChannel(),
+			RepeatSeconds: int(escalation.GetRepeatSeconds()),
+			MaxRepeats:    int(escalation.GetMaxRepeats()),
+			Schedule:      schedule,
+		})
+	}
+
+	for i, webhook := range msg.GetWebhooks() {
+		hook, err := toWebhook(webhook)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhooks[%d]: %w", i, err)
+		}
+
+		a.Webhooks = append(a.Webhooks, hook)
+	}
+
+	return a, nil
+}
+
+// FromWebhookCallback converts a webhook callback to its proto message. It returns nil if the callback is nil.
+// An error is returned if the payload cannot be encoded.
+func FromWebhookCallback(c *types.WebhookCallback) (*WebhookCallback, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	payload, err := fromMap(c.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook callback payload: %w", err)
+	}
+
+	msg := &WebhookCallback{
+		Id:           c.ID,
+		UserId:       c.UserID,
+		UserRealName: c.UserRealName,
+		ChannelId:    c.ChannelID,
+		MessageId:    c.MessageID,
+		Timestamp:    fromTime(c.Timestamp),
+		Input:        c.Input,
+		Payload:      payload,
+	}
+
+	if len(c.CheckboxInput) > 0 {
+		msg.CheckboxInput = make(map[string]*StringList, len(c.CheckboxInput))
+
+		for key, values := range c.CheckboxInput {
+			msg.CheckboxInput[key] = &StringList{Values: values}
+		}
+	}
+
+	return msg, nil
+}
+
+// ToWebhookCallback converts a proto message to a webhook callback. It returns nil if the message is nil.
+func ToWebhookCallback(msg *WebhookCallback) *types.WebhookCallback {
+	if msg == nil {
+		return nil
+	}
+
+	c := &types.WebhookCallback{
+		ID:           msg.GetId(),
+		UserID:       msg.GetUserId(),
+		UserRealName: msg.GetUserRealName(),
+		ChannelID:    msg.GetChannelId(),
+		MessageID:    msg.GetMessageId(),
+		Timestamp:    toTime(msg.GetTimestamp()),
+		Payload:      toMap(msg.GetPayload()),
+	}
+
+	if len(msg.GetInput()) > 0 {
+		c.Input = msg.GetInput()
+	}
+
+	if len(msg.GetCheckboxInput()) > 0 {
+		c.CheckboxInput = make(map[string][]string, len(msg.GetCheckboxInput()))
+
+		for key, values := range msg.GetCheckboxInput() {
+			c.CheckboxInput[key] = values.GetValues()
+		}
+	}
+
+	return c
+}
+
+// FromChannelProcessingState converts a channel processing state to its proto message. It returns nil if the state is nil.
+func FromChannelProcessingState(s *types.ChannelProcessingState) *ChannelProcessingState {
+	if s == nil {
+		return nil
+	}
+
+	return &ChannelProcessingState{
+		ChannelId:           s.ChannelID,
+		Created:             fromTime(s.Created),
+		LastChannelActivity: fromTime(s.LastChannelActivity),
+		LastProcessed:       fromTime(s.LastProcessed),
+		OpenIssues:          int64(s.OpenIssues),
+	}
+}
+
+// ToChannelProcessingState converts a proto message to a channel processing state. It returns nil if the message is nil.
+func ToChannelProcessingState(msg *ChannelProcessingState) *types.ChannelProcessingState {
+	if msg == nil {
+		return nil
+	}
+
+	return &types.ChannelProcessingState{
+		ChannelID:           msg.GetChannelId(),
+		Created:             toTime(msg.GetCreated()),
+		LastChannelActivity: toTime(msg.GetLastChannelActivity()),
+		LastProcessed:       toTime(msg.GetLastProcessed()),
+		OpenIssues:          int(msg.GetOpenIssues()),
+	}
+}
+
+func fromEscalationSchedule(schedule *types.EscalationSchedule) (*EscalationSchedule, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+
+	mode, ok := scheduleModes[schedule.OutsideWindows]
+	if !ok {
+		return nil, fmt.Errorf("unsupported schedule mode %q", schedule.OutsideWindows)
+	}
+
+	msg := &EscalationSchedule{
+		TimeZone:          schedule.TimeZone,
+		Holidays:          schedule.Holidays,
+		OutsideWindows:    mode,
+		AlternateMentions: schedule.AlternateMentions,
+	}
+
+	for _, window := range schedule.Windows {
+		if window != nil {
+			msg.Windows = append(msg.Windows, &EscalationWindow{Weekdays: window.Weekdays, Start: window.Start, End: window.End})
+		}
+	}
+
+	return msg, nil
+}
+
+func toEscalationSchedule(msg *EscalationSchedule) (*types.EscalationSchedule, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	mode, err := toEnum(scheduleModes, msg.GetOutsideWindows())
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &types.EscalationSchedule{
+		TimeZone:          msg.GetTimeZone(),
+		Holidays:          msg.GetHolidays(),
+		OutsideWindows:    mode,
+		AlternateMentions: msg.GetAlternateMentions(),
+	}
+
+	for _, window := range msg.GetWindows() {
+		schedule.Windows = append(schedule.Windows, &types.EscalationWindow{
+			Weekdays: window.GetWeekdays(),
+			Start:    window.GetStart(),
+			End:      window.GetEnd(),
+		})
+	}
+
+	return schedule, nil
+}
+
+func fromWebhook(hook *types.Webhook) (*Webhook, error) {
+	buttonStyle, ok := buttonStyles[hook.ButtonStyle]
+	if !ok {
+		return nil, fmt.Errorf("unsupported button style %q", hook.ButtonStyle)
+	}
+
+	accessLevel, ok := accessLevels[hook.AccessLevel]
+	if !ok {
+		return nil, fmt.Errorf("unsupported access level %q", hook.AccessLevel)
+	}
+
+	displayMode, ok := displayModes[hook.DisplayMode]
+	if !ok {
+		return nil, fmt.Errorf("unsupported display mode %q", hook.DisplayMode)
+	}
+
+	payload, err := fromMap(hook.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	msg := &Webhook{
+		Id:               hook.ID,
+		Url:              hook.URL,
+		ConfirmationText: hook.ConfirmationText,
+		ButtonText:       hook.ButtonText,
+		ButtonStyle:      buttonStyle,
+		AccessLevel:      accessLevel,
+		DisplayMode:      displayMode,
+		Payload:          payload,
+	}
+
+	for _, input := range hook.PlainTextInput {
+		if input == nil {
+			continue
+		}
+
+		msg.PlainTextInput = append(msg.PlainTextInput, &WebhookPlainTextInput{
+			Id:           input.ID,
+			Description:  input.Description,
+			MinLength:    int64(input.MinLength),
+			MaxLength:    int64(input.MaxLength),
+			Multiline:    input.Multiline,
+			InitialValue: input.InitialValue,
+		})
+	}
+
+	for _, input := range hook.CheckboxInput {
+		if input == nil {
+			continue
+		}
+
+		checkbox := &WebhookCheckboxInput{Id: input.ID, Label: input.Label}
+
+		for _, option := range input.Options {
+			if option != nil {
+				checkbox.Options = append(checkbox.Options, &WebhookCheckboxOption{Value: option.Value, Text: option.Text, Selected: option.Selected})
+			}
+		}
+
+		msg.CheckboxInput = append(msg.CheckboxInput, checkbox)
+	}
+
+	return msg, nil
+}
+
+func toWebhook(msg *Webhook) (*types.Webhook, error) {
+	buttonStyle, err := toEnum(buttonStyles, msg.GetButtonStyle())
+	if err != nil {
+		return nil, err
+	}
+
+	accessLevel, err := toEnum(accessLevels, msg.GetAccessLevel())
+	if err != nil {
+		return nil, err
+	}
+
+	displayMode, err := toEnum(displayModes, msg.GetDisplayMode())
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &types.Webhook{
+		ID:               msg.GetId(),
+		URL:              msg.GetUrl(),
+		ConfirmationText: msg.GetConfirmationText(),
+		ButtonText:       msg.GetButtonText(),
+		ButtonStyle:      buttonStyle,
+		AccessLevel:      accessLevel,
+		DisplayMode:      displayMode,
+		Payload:          toMap(msg.GetPayload()),
+	}
+
+	for _, input := range msg.GetPlainTextInput() {
+		hook.PlainTextInput = append(hook.PlainTextInput, &types.WebhookPlainTextInput{
+			ID:           input.GetId(),
+			Description:  input.GetDescription(),
+			MinLength:    int(input.GetMinLength()),
+			MaxLength:    int(input.GetMaxLength()),
+			Multiline:    input.GetMultiline(),
+			InitialValue: input.GetInitialValue(),
+		})
+	}
+
+	for _, input := range msg.GetCheckboxInput() {
+		checkbox := &types.WebhookCheckboxInput{ID: input.GetId(), Label: input.GetLabel()}
+
+		for _, option := range input.GetOptions() {
+			checkbox.Options = append(checkbox.Options, &types.WebhookCheckboxOption{
+				Value:    option.GetValue(),
+				Text:     option.GetText(),
+				Selected: option.GetSelected(),
+			})
+		}
+
+		hook.CheckboxInput = append(hook.CheckboxInput, checkbox)
+	}
+
+	return hook, nil
+}
+
+// toEnum returns the Go value mapped to the proto enum value, or an error if the value is unknown.
+func toEnum[T ~string, E interface {
+	comparable
+	fmt.Stringer
+}](values map[T]E, value E) (T, error) {
+	for key, v := range values {
+		if v == value {
+			return key, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown enum value %s", value)
+}
+
+// fromTime converts t to a proto timestamp, or nil if t is the zero time.
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// toTime converts a proto timestamp to a UTC time, or the zero time if ts is nil.
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
+
+// fromMap converts m to a proto struct, or nil if m is nil. Values that structpb does not support
+// (such as []string or structs) are converted through JSON.
+func fromMap(m map[string]any) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	if s, err := structpb.NewStruct(m); err == nil {
+		return s, nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized map[string]any
+
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewStruct(normalized)
+}
+
+// toMap converts a proto struct to a map, or nil if s is nil.
+func toMap(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+
+	return s.AsMap()
+}
+
+func marshal(msg proto.Message, name string) ([]byte, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,300 @@
+package typespb_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/typespb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAlert() *types.Alert {
+	return &types.Alert{
+		Timestamp:                 time.Date(2026, 3, 1, 10, 0, 0, 123456789, time.UTC),
+		CorrelationID:             "checkout-5xx-prod",
+		Type:                      "metrics",
+		Header:                    ":status: Checkout 5xx rate above 5%",
+		HeaderWhenResolved:        ":status: Checkout 5xx rate back to normal",
+		Text:                      "The 5xx rate has been above 5% for 10 minutes.",
+		TextWhenResolved:          "The 5xx rate is below 5%.",
+		FallbackText:              "Checkout 5xx rate above 5%",
+		Author:                    "Prometheus",
+		Host:                      "prometheus-0",
+		Footer:                    "team-checkout",
+		Link:                      "https://runbooks.example.com/checkout-5xx",
+		IssueFollowUpEnabled:      true,
+		AutoResolveSeconds:        3600,
+		AutoResolveAsInconclusive: true,
+		Severity:                  types.AlertError,
+		SlackChannelID:            "C0123456789",
+		RouteKey:                  "checkout",
+		Username:                  "Checkout Alerts",
+		IconEmoji:                 ":rotating_light:",
+		Fields:                    []*types.Field{{Title: "Region", Value: "eu-west-1"}, {Title: "Rate", Value: "7.2%"}},
+		NotificationDelaySeconds:  60,
+		ArchivingDelaySeconds:     86400,
+		Escalation: []*types.Escalation{
+			{Severity: types.AlertWarning, DelaySeconds: 300, SlackMentions: []string{"<@U0123456789>"}, RepeatSeconds: 120, MaxRepeats: 2},
+			{
+				Severity:      types.AlertPanic,
+				DelaySeconds:  900,
+				SlackMentions: []string{"<!here>"},
+				MoveToChannel: "C0987654321",
+				Schedule: &types.EscalationSchedule{
+					TimeZone:          "Europe/Oslo",
+					Windows:           []*types.EscalationWindow{{Weekdays: []string{"monday", "tuesday"}, Start: "08:00", End: "16:00"}},
+					Holidays:          []string{"2026-12-25"},
+					OutsideWindows:    types.EscalationScheduleAlternate,
+					AlternateMentions: []string{"<@U0123456789>"},
+				},
+			},
+		},
+		EscalationPolicy:     "team-checkout",
+		IgnoreIfTextContains: []string{"maintenance"},
+		EscapeText:           true,
+		Webhooks: []*types.Webhook{
+			{
+				ID:               "restart",
+				URL:              "https://ops.example.com/restart",
+				ConfirmationText: "Restart all pods?",
+				ButtonText:       "Restart pods",
+				ButtonStyle:      types.WebhookButtonStyleDanger,
+				AccessLevel:      types.WebhookAccessLevelChannelAdmins,
+				DisplayMode:      types.WebhookDisplayModeOpenIssue,
+				Payload:          map[string]any{"service": "checkout", "replicas": float64(3), "tags": []any{"a", "b"}},
+				PlainTextInput: []*types.WebhookPlainTextInput{
+					{ID: "reason", Description: "Reason", MinLength: 5, MaxLength: 200, Multiline: true, InitialValue: "5xx"},
+				},
+				CheckboxInput: []*types.WebhookCheckboxInput{
+					{ID: "scope", Label: "Scope", Options: []*types.WebhookCheckboxOption{{Value: "all", Text: "All pods", Selected: true}}},
+				},
+			},
+		},
+		Metadata: map[string]any{"region": "eu-west-1", "nested": map[string]any{"enabled": true, "count": float64(2)}},
+	}
+}
+
+func TestAlert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("test alert should set every field", func(t *testing.T) {
+		t.Parallel()
+
+		// Fails when a field is added to Alert, as a reminder to add it to the proto definition
+		v := reflect.ValueOf(newTestAlert()).Elem()
+
+		for i := range v.NumField() {
+			if name := v.Type().Field(i).Name; name != "FailOnRateLimitError" {
+				assert.False(t, v.Field(i).IsZero(), "Alert.%s is not set", name)
+			}
+		}
+	})
+
+	t.Run("alert should round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		alert := newTestAlert()
+
+		data, err := typespb.MarshalAlert(alert)
+		require.NoError(t, err)
+
+		decoded, err := typespb.UnmarshalAlert(data)
+		require.NoError(t, err)
+		assert.Equal(t, alert, decoded)
+
+		jsonData, err := json.Marshal(alert)
+		require.NoError(t, err)
+		assert.Less(t, len(data), len(jsonData))
+	})
+
+	t.Run("empty alert should round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := typespb.MarshalAlert(&types.Alert{})
+		require.NoError(t, err)
+
+		decoded, err := typespb.UnmarshalAlert(data)
+		require.NoError(t, err)
+		assert.Equal(t, &types.Alert{}, decoded)
+	})
+
+	t.Run("nil alert should convert to nil", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := typespb.FromAlert(nil)
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+
+		alert, err := typespb.ToAlert(nil)
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+	})
+
+	t.Run("empty metadata should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+
+		data, err := typespb.MarshalAlert(alert)
+		require.NoError(t, err)
+
+		decoded, err := typespb.UnmarshalAlert(data)
+		require.NoError(t, err)
+		assert.Equal(t, alert, decoded)
+	})
+
+	t.Run("values should be converted as by JSON", func(t *testing.T) {
+		t.Parallel()
+
+		alert := &types.Alert{
+			Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600)),
+			Fields:    []*types.Field{nil, {Title: "a"}},
+			Metadata:  map[string]any{"count": 2, "tags": []string{"a", "b"}, "labels": map[string]string{"env": "prod"}},
+		}
+
+		msg, err := typespb.FromAlert(alert)
+		require.NoError(t, err)
+
+		decoded, err := typespb.ToAlert(msg)
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC), decoded.Timestamp)
+		assert.Equal(t, []*types.Field{{Title: "a"}}, decoded.Fields)
+		assert.Equal(t, map[string]any{"count": float64(2), "tags": []any{"a", "b"}, "labels": map[string]any{"env": "prod"}}, decoded.Metadata)
+	})
+
+	t.Run("unsupported enum values should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := typespb.FromAlert(&types.Alert{Severity: "critical"})
+		require.ErrorContains(t, err, `unsupported alert severity "critical"`)
+
+		_, err = typespb.FromAlert(&types.Alert{Escalation: []*types.Escalation{{Severity: "critical"}}})
+		require.ErrorContains(t, err, "escalation[0]")
+
+		_, err = typespb.FromAlert(&types.Alert{Escalation: []*types.Escalation{{Schedule: &types.EscalationSchedule{OutsideWindows: "drop"}}}})
+		require.ErrorContains(t, err, `unsupported schedule mode "drop"`)
+
+		_, err = typespb.FromAlert(&types.Alert{Webhooks: []*types.Webhook{{ButtonStyle: "link"}}})
+		require.ErrorContains(t, err, `unsupported button style "link"`)
+
+		_, err = typespb.FromAlert(&types.Alert{Webhooks: []*types.Webhook{{AccessLevel: "everyone"}}})
+		require.ErrorContains(t, err, `unsupported access level "everyone"`)
+
+		_, err = typespb.FromAlert(&types.Alert{Webhooks: []*types.Webhook{{DisplayMode: "never"}}})
+		require.ErrorContains(t, err, `unsupported display mode "never"`)
+
+		_, err = typespb.ToAlert(&typespb.Alert{Severity: typespb.AlertSeverity(42)})
+		require.ErrorContains(t, err, "unknown enum value 42")
+
+		_, err = typespb.ToAlert(&typespb.Alert{Webhooks: []*typespb.Webhook{{DisplayMode: typespb.WebhookDisplayMode(42)}}})
+		require.ErrorContains(t, err, "webhooks[0]")
+	})
+
+	t.Run("invalid metadata should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := typespb.FromAlert(&types.Alert{Metadata: map[string]any{"ch": make(chan int)}})
+		require.ErrorContains(t, err, "failed to encode alert metadata")
+	})
+
+	t.Run("invalid data should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := typespb.UnmarshalAlert([]byte{0xff})
+		require.ErrorContains(t, err, "failed to decode alert")
+	})
+}
+
+func TestWebhookCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("webhook callback should round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		callback := &types.WebhookCallback{
+			ID:            "restart",
+			UserID:        "U0123456789",
+			UserRealName:  "Jane Doe",
+			ChannelID:     "C0123456789",
+			MessageID:     "1700000000.000100",
+			Timestamp:     time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			Input:         map[string]string{"reason": "5xx"},
+			CheckboxInput: map[string][]string{"scope": {"all", "canary"}},
+			Payload:       map[string]any{"service": "checkout"},
+		}
+
+		data, err := typespb.MarshalWebhookCallback(callback)
+		require.NoError(t, err)
+
+		decoded, err := typespb.UnmarshalWebhookCallback(data)
+		require.NoError(t, err)
+		assert.Equal(t, callback, decoded)
+	})
+
+	t.Run("empty webhook callback should round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := typespb.MarshalWebhookCallback(&types.WebhookCallback{})
+		require.NoError(t, err)
+
+		decoded, err := typespb.UnmarshalWebhookCallback(data)
+		require.NoError(t, err)
+		assert.Equal(t, &types.WebhookCallback{}, decoded)
+	})
+
+	t.Run("nil webhook callback should convert to nil", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := typespb.FromWebhookCallback(nil)
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+		assert.Nil(t, typespb.ToWebhookCallback(nil))
+	})
+
+	t.Run("invalid payload should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := typespb.MarshalWebhookCallback(&types.WebhookCallback{Payload: map[string]any{"f": func() {}}})
+		require.ErrorContains(t, err, "failed to encode webhook callback payload")
+
+		_, err = typespb.UnmarshalWebhookCallback([]byte{0xff})
+		require.ErrorContains(t, err, "failed to decode webhook callback")
+	})
+}
+
+func TestChannelProcessingState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("channel processing state should round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		state := types.NewChannelProcessingState("C0123456789")
+		state.OpenIssues = 12
+
+		data, err := typespb.MarshalChannelProcessingState(state)
+		require.NoError(t, err)
+
+		decoded, err := typespb.UnmarshalChannelProcessingState(data)
+		require.NoError(t, err)
+		assert.Equal(t, state, decoded)
+	})
+
+	t.Run("nil channel processing state should convert to nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, typespb.FromChannelProcessingState(nil))
+		assert.Nil(t, typespb.ToChannelProcessingState(nil))
+	})
+
+	t.Run("invalid data should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := typespb.UnmarshalChannelProcessingState([]byte{0xff})
+		require.ErrorContains(t, err, "failed to decode channel processing state")
+	})
+}
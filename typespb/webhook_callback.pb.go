@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: webhook_callback.proto
+
+package typespb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// WebhookCallback mirrors types.WebhookCallback.
+type WebhookCallback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserRealName  string                 `protobuf:"bytes,3,opt,name=user_real_name,json=userRealName,proto3" json:"user_real_name,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,5,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Input         map[string]string      `protobuf:"bytes,7,rep,name=input,proto3" json:"input,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CheckboxInput map[string]*StringList `protobuf:"bytes,8,rep,name=checkbox_input,json=checkboxInput,proto3" json:"checkbox_input,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Payload       *structpb.Struct       `protobuf:"bytes,9,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookCallback) Reset() {
+	*x = WebhookCallback{}
+	mi := &file_webhook_callback_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookCallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookCallback) ProtoMessage() {}
+
+func (x *WebhookCallback) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_callback_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookCallback.ProtoReflect.Descriptor instead.
+func (*WebhookCallback) Descriptor() ([]byte, []int) {
+	return file_webhook_callback_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebhookCallback) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookCallback) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WebhookCallback) GetUserRealName() string {
+	if x != nil {
+		return x.UserRealName
+	}
+	return ""
+}
+
+func (x *WebhookCallback) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *WebhookCallback) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *WebhookCallback) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WebhookCallback) GetInput() map[string]string {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *WebhookCallback) GetCheckboxInput() map[string]*StringList {
+	if x != nil {
+		return x.CheckboxInput
+	}
+	return nil
+}
+
+func (x *WebhookCallback) GetPayload() *structpb.Struct {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// StringList is a list of strings, used for map values.
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_webhook_callback_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_callback_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_webhook_callback_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_webhook_callback_proto protoreflect.FileDescriptor
+
+const file_webhook_callback_proto_rawDesc = "" +
+	"\n" +
+	"\x16webhook_callback.proto\x12\x11slackmgr.types.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x04\n" +
+	"\x0fWebhookCallback\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
+	"\x0euser_real_name\x18\x03 \x01(\tR\fuserRealName\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x04 \x01(\tR\tchannelId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x05 \x01(\tR\tmessageId\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12C\n" +
+	"\x05input\x18\a \x03(\v2-.slackmgr.types.v1.WebhookCallback.InputEntryR\x05input\x12\\\n" +
+	"\x0echeckbox_input\x18\b \x03(\v25.slackmgr.types.v1.WebhookCallback.CheckboxInputEntryR\rcheckboxInput\x121\n" +
+	"\apayload\x18\t \x01(\v2\x17.google.protobuf.StructR\apayload\x1a8\n" +
+	"\n" +
+	"InputEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a_\n" +
+	"\x12CheckboxInputEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\x05value\x18\x02 \x01(\v2\x1d.slackmgr.types.v1.StringListR\x05value:\x028\x01\"$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06valuesB#Z!github.com/slackmgr/types/typespbb\x06proto3"
+
+var (
+	file_webhook_callback_proto_rawDescOnce sync.Once
+	file_webhook_callback_proto_rawDescData []byte
+)
+
+func file_webhook_callback_proto_rawDescGZIP() []byte {
+	file_webhook_callback_proto_rawDescOnce.Do(func() {
+		file_webhook_callback_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_webhook_callback_proto_rawDesc), len(file_webhook_callback_proto_rawDesc)))
+	})
+	return file_webhook_callback_proto_rawDescData
+}
+
+var file_webhook_callback_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_webhook_callback_proto_goTypes = []any{
+	(*WebhookCallback)(nil),       // 0: slackmgr.types.v1.WebhookCallback
+	(*StringList)(nil),            // 1: slackmgr.types.v1.StringList
+	nil,                           // 2: slackmgr.types.v1.WebhookCallback.InputEntry
+	nil,                           // 3: slackmgr.types.v1.WebhookCallback.CheckboxInputEntry
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 5: google.protobuf.Struct
+}
+var file_webhook_callback_proto_depIdxs = []int32{
+	4, // 0: slackmgr.types.v1.WebhookCallback.timestamp:type_name -> google.protobuf.Timestamp
+	2, // 1: slackmgr.types.v1.WebhookCallback.input:type_name -> slackmgr.types.v1.WebhookCallback.InputEntry
+	3, // 2: slackmgr.types.v1.WebhookCallback.checkbox_input:type_name -> slackmgr.types.v1.WebhookCallback.CheckboxInputEntry
+	5, // 3: slackmgr.types.v1.WebhookCallback.payload:type_name -> google.protobuf.Struct
+	1, // 4: slackmgr.types.v1.WebhookCallback.CheckboxInputEntry.value:type_name -> slackmgr.types.v1.StringList
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_webhook_callback_proto_init() }
+func file_webhook_callback_proto_init() {
+	if File_webhook_callback_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_webhook_callback_proto_rawDesc), len(file_webhook_callback_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_webhook_callback_proto_goTypes,
+		DependencyIndexes: file_webhook_callback_proto_depIdxs,
+		MessageInfos:      file_webhook_callback_proto_msgTypes,
+	}.Build()
+	File_webhook_callback_proto = out.File
+	file_webhook_callback_proto_goTypes = nil
+	file_webhook_callback_proto_depIdxs = nil
+}
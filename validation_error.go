@@ -0,0 +1,137 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationErrorCode is a machine-readable code describing why a value failed validation.
+type ValidationErrorCode string
+
+const (
+	// ValidationErrorCodeRequired indicates that a required value is empty or nil.
+	ValidationErrorCodeRequired ValidationErrorCode = "required"
+
+	// ValidationErrorCodeTooLong indicates that a string value exceeds its maximum length.
+	ValidationErrorCodeTooLong ValidationErrorCode = "too_long"
+
+	// ValidationErrorCodeTooShort indicates that a string value is shorter than its minimum length.
+	ValidationErrorCodeTooShort ValidationErrorCode = "too_short"
+
+	// ValidationErrorCodeTooMany indicates that a list or map contains more items than allowed.
+	ValidationErrorCodeTooMany ValidationErrorCode = "too_many"
+
+	// ValidationErrorCodeTooLow indicates that a numeric value is below its minimum.
+	ValidationErrorCodeTooLow ValidationErrorCode = "too_low"
+
+	// ValidationErrorCodeTooHigh indicates that a numeric value is above its maximum.
+	ValidationErrorCodeTooHigh ValidationErrorCode = "too_high"
+
+	// ValidationErrorCodeNotUnique indicates that a value must be unique among its siblings, but is not.
+	ValidationErrorCodeNotUnique ValidationErrorCode = "not_unique"
+
+	// ValidationErrorCodeInvalidFormat indicates that a value does not match the expected format (URL, emoji, channel ID etc).
+	ValidationErrorCodeInvalidFormat ValidationErrorCode = "invalid_format"
+
+	// ValidationErrorCodeInvalidValue indicates that a value is not one of the allowed values (such as an invalid severity).
+	ValidationErrorCodeInvalidValue ValidationErrorCode = "invalid_value"
+
+	// ValidationErrorCodeInvalidJSON indicates that a value could not be decoded from JSON (such as a malformed alert in an AlertBatch).
+	ValidationErrorCodeInvalidJSON ValidationErrorCode = "invalid_json"
+)
+
+// ValidationError describes a single validation failure, addressed by the JSON path of the offending value.
+type ValidationError struct {
+	// Path is the JSON path of the invalid value, such as 'webhooks[2].checkboxInput[0].options[3].value'.
+	// Path is empty if the alert itself is nil.
+	Path string `json:"path"`
+
+	// Code is the machine-readable reason for the failure.
+	Code ValidationErrorCode `json:"code"`
+
+	// Limit is the limit that was violated, such as a maximum length or count.
+	// It is zero when no numeric limit applies (e.g. for ValidationErrorCodeRequired).
+	Limit int `json:"limit,omitempty"`
+
+	// Actual is the actual value that violated the limit. For length and count violations this is the
+	// actual length or count, for format and value violations it is the offending value itself.
+	Actual any `json:"actual,omitempty"`
+
+	// Message is the human-readable description of the failure.
+	Message string `json:"message"`
+}
+
+// Error returns the human-readable description of the validation failure.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors is an aggregate of validation failures, as returned by Alert.ValidateAll.
+// It supports errors.As and errors.Is for each individual *ValidationError, through Unwrap.
+type ValidationErrors []*ValidationError
+
+// Error returns the messages of all validation failures, separated by newlines (same format as errors.Join).
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the individual validation failures.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// validator collects validation failures for an alert.
+type validator struct {
+	limits *Limits
+	errs   ValidationErrors
+}
+
+// newValidator returns a validator using the specified limits, or the default limits if nil.
+func newValidator(limits *Limits) *validator {
+	if limits == nil {
+		limits = DefaultLimits()
+	}
+
+	return &validator{limits: limits}
+}
+
+// add records a validation failure. The message is constructed from format and args.
+func (v *validator) add(path string, code ValidationErrorCode, limit int, actual any, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:    path,
+		Code:    code,
+		Limit:   limit,
+		Actual:  actual,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// first returns the first recorded failure, or nil if there are none.
+func (v *validator) first() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs[0]
+}
+
+// all returns all recorded failures as ValidationErrors, or nil if there are none.
+func (v *validator) all() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
@@ -0,0 +1,152 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid alert should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", RouteKey: "b"}
+		a.Clean()
+		require.NoError(t, a.ValidateAll())
+	})
+
+	t.Run("nil alert should return a single error", func(t *testing.T) {
+		t.Parallel()
+
+		var a *types.Alert
+		err := a.ValidateAll()
+		require.ErrorContains(t, err, "alert is nil")
+
+		var errs types.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, types.ValidationErrorCodeRequired, errs[0].Code)
+	})
+
+	t.Run("all failures should be collected", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			IconEmoji: "foo",
+			Link:      "/relative",
+			Webhooks: []*types.Webhook{
+				{ID: "a", URL: "http://foo.bar", ButtonText: strings.Repeat("x", types.MaxWebhookButtonTextLength+1)},
+				{ID: "a", URL: "http://foo.bar", ButtonText: "press me", CheckboxInput: []*types.WebhookCheckboxInput{{
+					ID: "cb",
+					Options: []*types.WebhookCheckboxOption{
+						{Value: "v1"},
+						{Value: "v1"},
+						{Value: strings.Repeat("x", types.MaxCheckboxOptionValueLength+1)},
+					},
+				}}},
+			},
+		}
+		a.Clean()
+
+		err := a.ValidateAll()
+		require.Error(t, err)
+
+		var errs types.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+
+		paths := make([]string, 0, len(errs))
+		for _, e := range errs {
+			paths = append(paths, e.Path)
+		}
+
+		assert.Equal(t, []string{
+			"header",
+			"iconEmoji",
+			"link",
+			"webhooks[0].buttonText",
+			"webhooks[1].id",
+			"webhooks[1].checkboxInput[0].options[1].value",
+			"webhooks[1].checkboxInput[0].options[2].value",
+		}, paths)
+
+		assert.Equal(t, types.ValidationErrorCodeRequired, errs[0].Code)
+		assert.Equal(t, types.ValidationErrorCodeInvalidFormat, errs[1].Code)
+		assert.Equal(t, "foo", errs[1].Actual)
+		assert.Equal(t, types.ValidationErrorCodeTooLong, errs[3].Code)
+		assert.Equal(t, types.MaxWebhookButtonTextLength, errs[3].Limit)
+		assert.Equal(t, types.MaxWebhookButtonTextLength+1, errs[3].Actual)
+		assert.Equal(t, types.ValidationErrorCodeNotUnique, errs[4].Code)
+		assert.Equal(t, types.ValidationErrorCodeNotUnique, errs[5].Code)
+		assert.Equal(t, types.ValidationErrorCodeTooLong, errs[6].Code)
+		assert.Equal(t, "webhook[1].checkboxInput[0].options[2].value is too long, expected <=100", errs[6].Message)
+	})
+
+	t.Run("validate should return the first failure", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{IconEmoji: "foo", Link: "/relative"}
+		a.Clean()
+
+		err := a.Validate()
+		require.EqualError(t, err, "header and text cannot both be empty")
+
+		var validationErr *types.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "header", validationErr.Path)
+
+		all := a.ValidateAll()
+		require.ErrorAs(t, all, &validationErr)
+		assert.Equal(t, "header", validationErr.Path, "errors.As should find the first failure in the aggregate")
+	})
+
+	t.Run("cross-field input checks are skipped when lengths are invalid", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", Webhooks: []*types.Webhook{{
+			ID:         "foo",
+			URL:        "http://foo.bar",
+			ButtonText: "press me",
+			PlainTextInput: []*types.WebhookPlainTextInput{
+				{ID: "input1", MinLength: 10, MaxLength: -1},
+			},
+		}}}
+		a.Clean()
+
+		var errs types.ValidationErrors
+		require.ErrorAs(t, a.ValidateAll(), &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "webhooks[0].plainTextInput[0].maxLength", errs[0].Path)
+		assert.Equal(t, types.ValidationErrorCodeTooLow, errs[0].Code)
+	})
+}
+
+func TestValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	errs := types.ValidationErrors{
+		{Path: "header", Code: types.ValidationErrorCodeRequired, Message: "first"},
+		{Path: "fields", Code: types.ValidationErrorCodeTooMany, Limit: 20, Actual: 21, Message: "second"},
+	}
+
+	assert.Equal(t, "first\nsecond", errs.Error())
+	assert.Len(t, errs.Unwrap(), 2)
+
+	joined := errors.Join(errs, errors.New("other"))
+	var validationErr *types.ValidationError
+	require.ErrorAs(t, joined, &validationErr)
+	assert.Equal(t, "header", validationErr.Path)
+
+	body, err := json.Marshal(errs)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"path": "header", "code": "required", "message": "first"},
+		{"path": "fields", "code": "too_many", "limit": 20, "actual": 21, "message": "second"}
+	]`, string(body))
+}
@@ -0,0 +1,283 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookDispatchMetric is the counter registered by WebhookDispatcher, with the label 'result', incremented once per callback.
+	WebhookDispatchMetric = "webhook_dispatch_total"
+
+	// WebhookDispatchDurationMetric is the histogram registered by WebhookDispatcher, with the label 'result',
+	// observing the duration of each callback in seconds (including retries).
+	WebhookDispatchDurationMetric = "webhook_dispatch_duration_seconds"
+
+	// WebhookDispatchRetryMetric is the counter registered by WebhookDispatcher, incremented once per retry.
+	WebhookDispatchRetryMetric = "webhook_dispatch_retries_total"
+
+	// WebhookDispatchResultHTTPError is the result label of callbacks rejected by the receiver (or failing with 5xx after all attempts).
+	WebhookDispatchResultHTTPError = "http_error"
+
+	// WebhookDispatchResultNetworkError is the result label of callbacks that could not be delivered (network errors, timeouts).
+	WebhookDispatchResultNetworkError = "network_error"
+)
+
+// WebhookDispatcherOptions configures a WebhookDispatcher. Use DefaultWebhookDispatcherOptions to get the default options.
+type WebhookDispatcherOptions struct {
+	// Client is the HTTP client used to send requests. If nil, a new client is used.
+	Client *http.Client
+
+	// Timeout is the timeout of each attempt. If zero, the default timeout is used.
+	Timeout time.Duration
+
+	// MaxAttempts is the maximum number of attempts per callback, including the first one. If zero, the default is used.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. The delay is doubled for each retry, up to MaxBackoff.
+	// If zero, the default is used.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between attempts, also when the receiver asks for a longer delay with Retry-After.
+	// If zero, the default is used.
+	MaxBackoff time.Duration
+
+	// Metrics receives the dispatch outcomes. If nil, no metrics are recorded.
+	Metrics Metrics
+
+	// Clock provides the signature timestamps. If nil, the system clock is used.
+	Clock Clock
+}
+
+// DefaultWebhookDispatcherOptions returns a new WebhookDispatcherOptions instance, with a 10 second timeout per attempt,
+// and up to 4 attempts with backoff from 500 milliseconds to 30 seconds.
+func DefaultWebhookDispatcherOptions() *WebhookDispatcherOptions {
+	return &WebhookDispatcherOptions{
+		Timeout:        10 * time.Second,
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// WebhookDispatcher delivers webhook callbacks to webhooks with an http or https URL. Each callback is POSTed as JSON,
+// signed with the WebhookSignatureHeader and WebhookTimestampHeader headers (see SignWebhookPayload). It is safe for concurrent use.
+type WebhookDispatcher struct {
+	secret string
+	opts   *WebhookDispatcherOptions
+	client *http.Client
+	clock  Clock
+}
+
+// WebhookDispatchError is returned by WebhookDispatcher.Dispatch when a callback could not be delivered.
+type WebhookDispatchError struct {
+	// URL is the webhook URL.
+	URL string
+
+	// StatusCode is the HTTP status code of the last attempt, or zero if no response was received.
+	StatusCode int
+
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// Err is the error of the last attempt, if no response was received.
+	Err error
+}
+
+// Error returns a description of the failure.
+func (e *WebhookDispatchError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("webhook '%s' failed with status %d after %d attempt(s)", e.URL, e.StatusCode, e.Attempts)
+	}
+
+	return fmt.Sprintf("webhook '%s' failed after %d attempt(s): %v", e.URL, e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *WebhookDispatchError) Unwrap() error {
+	return e.Err
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher, signing requests with secret, using the specified options
+// (or the default options if nil). Zero Timeout, MaxAttempts, InitialBackoff and MaxBackoff options are replaced
+// with the defaults. An error is returned if secret is empty, since receivers reject unsigned requests (see WebhookVerifier).
+func NewWebhookDispatcher(secret string, opts *WebhookDispatcherOptions) (*WebhookDispatcher, error) {
+	if secret == "" {
+		return nil, errors.New("webhook dispatcher requires a non-empty secret")
+	}
+
+	defaults := DefaultWebhookDispatcherOptions()
+
+	if opts == nil {
+		opts = defaults
+	}
+
+	o := *opts
+	opts = &o
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaults.InitialBackoff
+	}
+
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaults.MaxBackoff
+	}
+
+	d := &WebhookDispatcher{secret: secret, opts: opts, client: opts.Client, clock: opts.Clock}
+
+	if d.client == nil {
+		d.client = &http.Client{}
+	}
+
+	if d.clock == nil {
+		d.clock = SystemClock()
+	}
+
+	if opts.Metrics != nil {
+		opts.Metrics.RegisterCounter(WebhookDispatchMetric, "Number of webhook callbacks dispatched", "result")
+		opts.Metrics.RegisterHistogram(WebhookDispatchDurationMetric, "Duration of webhook callback dispatches, in seconds", nil, "result")
+		opts.Metrics.RegisterCounter(WebhookDispatchRetryMetric, "Number of webhook callback retries")
+	}
+
+	return d, nil
+}
+
+// Dispatch POSTs the callback to the webhook URL. Network errors (including timeouts), 429 and 5xx responses are retried
+// with exponential backoff, or after the delay in the Retry-After response header, up to MaxAttempts attempts.
+// Other responses than 2xx are not retried. A *WebhookDispatchError is returned if the callback could not be delivered,
+// and the context error if ctx is done.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, webhookURL string, callback *WebhookCallback) error {
+	if u, err := url.Parse(webhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("webhook URL '%s' is not an http or https URL", webhookURL)
+	}
+
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook callback: %w", err)
+	}
+
+	start := time.Now()
+	backoff := d.opts.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		statusCode, retryAfter, err := d.send(ctx, webhookURL, body)
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			d.observe(start, WebhookDispatchResultNetworkError)
+			return ctxErr
+		}
+
+		if err == nil && statusCode >= 200 && statusCode < 300 {
+			d.observe(start, WebhookResultOK)
+			return nil
+		}
+
+		retryable := err != nil || statusCode == http.StatusTooManyRequests || statusCode >= 500
+
+		if !retryable || attempt >= d.opts.MaxAttempts {
+			result := WebhookDispatchResultHTTPError
+			if err != nil {
+				result = WebhookDispatchResultNetworkError
+			}
+
+			d.observe(start, result)
+
+			return &WebhookDispatchError{URL: webhookURL, StatusCode: statusCode, Attempts: attempt, Err: err}
+		}
+
+		delay := backoff
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+
+		timer := time.NewTimer(min(delay, d.opts.MaxBackoff))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			d.observe(start, WebhookDispatchResultNetworkError)
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, d.opts.MaxBackoff)
+
+		if d.opts.Metrics != nil {
+			d.opts.Metrics.Inc(WebhookDispatchRetryMetric)
+		}
+	}
+}
+
+// send makes one attempt, returning the response status code and Retry-After delay (if any).
+func (d *WebhookDispatcher) send(ctx context.Context, webhookURL string, body []byte) (int, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	now := d.clock.Now()
+	timestamp := now.Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(d.secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	// Drain the body, so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, parseRetryAfter(resp.Header.Get("Retry-After"), now), nil
+}
+
+// observe records the outcome of a dispatch.
+func (d *WebhookDispatcher) observe(start time.Time, result string) {
+	if d.opts.Metrics == nil {
+		return
+	}
+
+	d.opts.Metrics.Inc(WebhookDispatchMetric, result)
+	d.opts.Metrics.Observe(WebhookDispatchDurationMetric, time.Since(start).Seconds(), result)
+}
+
+// parseRetryAfter returns the delay in a Retry-After header, either in seconds or as an HTTP date, or zero if not set or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,288 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastDispatcherOptions returns dispatcher options with short timeouts and backoff, for tests.
+func fastDispatcherOptions() *types.WebhookDispatcherOptions {
+	opts := types.DefaultWebhookDispatcherOptions()
+	opts.Timeout = time.Second
+	opts.InitialBackoff = time.Millisecond
+	opts.MaxBackoff = 10 * time.Millisecond
+
+	return opts
+}
+
+// newDispatcher returns a dispatcher signing requests with 's3cret', using opts.
+func newDispatcher(t *testing.T, opts *types.WebhookDispatcherOptions) *types.WebhookDispatcher {
+	t.Helper()
+
+	d, err := types.NewWebhookDispatcher("s3cret", opts)
+	require.NoError(t, err)
+
+	return d
+}
+
+// statusServer returns a server responding with the status codes in order, repeating the last one.
+func statusServer(t *testing.T, requests *atomic.Int32, statusCodes ...int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := int(requests.Add(1))
+		w.WriteHeader(statusCodes[min(n, len(statusCodes))-1])
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	t.Parallel()
+
+	callback := &types.WebhookCallback{ID: "restart", UserID: "U1", ChannelID: "C1", Payload: map[string]any{"service": "checkout"}}
+
+	t.Run("callback should be posted as signed JSON", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, strconv.FormatInt(now.Unix(), 10), r.Header.Get(types.WebhookTimestampHeader))
+			assert.Equal(t, types.SignWebhookPayload("s3cret", now.Unix(), body), r.Header.Get(types.WebhookSignatureHeader))
+
+			var received types.WebhookCallback
+			assert.NoError(t, json.Unmarshal(body, &received))
+			assert.Equal(t, "restart", received.ID)
+			assert.Equal(t, "checkout", received.GetPayloadString("service"))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		opts := fastDispatcherOptions()
+		opts.Clock = types.FixedClock(now)
+
+		require.NoError(t, newDispatcher(t, opts).Dispatch(context.Background(), server.URL, callback))
+	})
+
+	t.Run("empty secret should fail", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := types.NewWebhookDispatcher("", fastDispatcherOptions())
+		require.EqualError(t, err, "webhook dispatcher requires a non-empty secret")
+		assert.Nil(t, d)
+	})
+
+	t.Run("server errors should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+		server := statusServer(t, &requests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK)
+
+		require.NoError(t, newDispatcher(t, fastDispatcherOptions()).Dispatch(context.Background(), server.URL, callback))
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("retries should stop after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+		server := statusServer(t, &requests, http.StatusServiceUnavailable)
+
+		err := newDispatcher(t, fastDispatcherOptions()).Dispatch(context.Background(), server.URL, callback)
+
+		var dispatchErr *types.WebhookDispatchError
+		require.True(t, errors.As(err, &dispatchErr))
+		assert.Equal(t, http.StatusServiceUnavailable, dispatchErr.StatusCode)
+		assert.Equal(t, 4, dispatchErr.Attempts)
+		assert.Equal(t, int32(4), requests.Load())
+		require.ErrorContains(t, err, "failed with status 503 after 4 attempt(s)")
+	})
+
+	t.Run("client errors should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+		server := statusServer(t, &requests, http.StatusUnauthorized)
+
+		err := newDispatcher(t, fastDispatcherOptions()).Dispatch(context.Background(), server.URL, callback)
+
+		var dispatchErr *types.WebhookDispatchError
+		require.True(t, errors.As(err, &dispatchErr))
+		assert.Equal(t, http.StatusUnauthorized, dispatchErr.StatusCode)
+		assert.Equal(t, 1, dispatchErr.Attempts)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("retry after should be respected", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}))
+		defer server.Close()
+
+		opts := fastDispatcherOptions()
+		opts.MaxBackoff = 5 * time.Second
+
+		start := time.Now()
+		require.NoError(t, newDispatcher(t, opts).Dispatch(context.Background(), server.URL, callback))
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("retry after should be capped by max backoff", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) == 1 {
+				w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		start := time.Now()
+		require.NoError(t, newDispatcher(t, fastDispatcherOptions()).Dispatch(context.Background(), server.URL, callback))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("network errors should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		server.Close()
+
+		opts := fastDispatcherOptions()
+		opts.MaxAttempts = 2
+
+		err := newDispatcher(t, opts).Dispatch(context.Background(), server.URL, callback)
+
+		var dispatchErr *types.WebhookDispatchError
+		require.True(t, errors.As(err, &dispatchErr))
+		assert.Zero(t, dispatchErr.StatusCode)
+		assert.Equal(t, 2, dispatchErr.Attempts)
+		require.Error(t, dispatchErr.Err)
+	})
+
+	t.Run("zero options should be replaced with the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		start := time.Now()
+		require.NoError(t, newDispatcher(t, &types.WebhookDispatcherOptions{MaxAttempts: 2}).Dispatch(context.Background(), server.URL, callback))
+		assert.GreaterOrEqual(t, time.Since(start), time.Second, "Retry-After should be respected with the default max backoff")
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("slow attempts should time out and be retried", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		release := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-release:
+				}
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		opts := fastDispatcherOptions()
+		opts.Timeout = 50 * time.Millisecond
+
+		require.NoError(t, newDispatcher(t, opts).Dispatch(context.Background(), server.URL, callback))
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("cancelled context should stop retries", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+		server := statusServer(t, &requests, http.StatusInternalServerError)
+
+		opts := fastDispatcherOptions()
+		opts.InitialBackoff = time.Hour
+		opts.MaxBackoff = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := newDispatcher(t, opts).Dispatch(ctx, server.URL, callback)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("non-http URLs should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		err := newDispatcher(t, nil).Dispatch(context.Background(), "restart-pods", callback)
+		require.ErrorContains(t, err, "webhook URL 'restart-pods' is not an http or https URL")
+	})
+
+	t.Run("outcomes should be reported as metrics", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+		server := statusServer(t, &requests, http.StatusInternalServerError, http.StatusOK, http.StatusBadRequest)
+
+		metrics := &recordingMetrics{}
+		opts := fastDispatcherOptions()
+		opts.Metrics = metrics
+
+		d := newDispatcher(t, opts)
+		require.NoError(t, d.Dispatch(context.Background(), server.URL, callback))
+		require.Error(t, d.Dispatch(context.Background(), server.URL, callback))
+
+		assert.Equal(t, []string{"webhook_dispatch_duration_seconds[result]"}, metrics.histograms)
+		assert.Equal(t, [][]string{
+			{types.WebhookDispatchDurationMetric, types.WebhookResultOK},
+			{types.WebhookDispatchDurationMetric, types.WebhookDispatchResultHTTPError},
+		}, metrics.observations)
+		assert.Equal(t, []string{
+			"webhook_dispatch_retries_total[]",
+			"webhook_dispatch_total[ok]",
+			"webhook_dispatch_total[http_error]",
+		}, metrics.counters)
+	})
+}
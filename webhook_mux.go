@@ -0,0 +1,254 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWebhookHandlerNotFound is returned (wrapped) by WebhookMux when no handler is registered for a webhook target.
+var ErrWebhookHandlerNotFound = errors.New("webhook handler not found")
+
+// ErrWebhookAccessDenied is returned (wrapped) by the WebhookAccess middleware when a user may not invoke a webhook.
+var ErrWebhookAccessDenied = errors.New("webhook access denied")
+
+// WebhookHandler handles callbacks for webhooks with a custom handler identifier as URL (i.e. not an http or https URL).
+type WebhookHandler interface {
+	// HandleWebhook handles a callback for the webhook target, which is the Webhook.URL of the clicked webhook.
+	HandleWebhook(ctx context.Context, target string, callback *WebhookCallback) error
+}
+
+// WebhookHandlerFunc is a function implementing WebhookHandler.
+type WebhookHandlerFunc func(ctx context.Context, target string, callback *WebhookCallback) error
+
+// HandleWebhook returns f(ctx, target, callback).
+func (f WebhookHandlerFunc) HandleWebhook(ctx context.Context, target string, callback *WebhookCallback) error {
+	return f(ctx, target, callback)
+}
+
+// WebhookMiddleware wraps a WebhookHandler, such as to add logging, metrics or access checks.
+type WebhookMiddleware func(next WebhookHandler) WebhookHandler
+
+// webhookPatternKey is the context key of the pattern matched by WebhookMux.
+type webhookPatternKey struct{}
+
+// WebhookPattern returns the WebhookMux pattern matched for the callback being handled, or an empty string if no
+// pattern matched (or the context does not come from a WebhookMux). Use it as a low-cardinality label in middleware.
+func WebhookPattern(ctx context.Context) string {
+	pattern, _ := ctx.Value(webhookPatternKey{}).(string)
+	return pattern
+}
+
+// WebhookMux routes webhook callbacks to handlers by target. Patterns are either an exact target, such as 'restart-pods',
+// or a prefix ending with '*', such as 'runbook:*'. Exact patterns take precedence over prefixes, and longer prefixes
+// over shorter ones. A WebhookMux is itself a WebhookHandler, and is safe for concurrent use.
+type WebhookMux struct {
+	mu         sync.RWMutex
+	exact      map[string]WebhookHandler
+	prefixes   []*webhookPrefixRoute
+	middleware []WebhookMiddleware
+}
+
+type webhookPrefixRoute struct {
+	pattern string
+	prefix  string
+	handler WebhookHandler
+}
+
+// NewWebhookMux creates a new, empty WebhookMux.
+func NewWebhookMux() *WebhookMux {
+	return &WebhookMux{exact: make(map[string]WebhookHandler)}
+}
+
+// Handle registers the handler for the pattern. It panics if the pattern is empty or already registered, or if handler is nil.
+func (m *WebhookMux) Handle(pattern string, handler WebhookHandler) {
+	if pattern == "" || pattern == "*" {
+		panic(fmt.Sprintf("invalid webhook pattern '%s'", pattern))
+	}
+
+	if handler == nil {
+		panic(fmt.Sprintf("nil handler for webhook pattern '%s'", pattern))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix, isPrefix := strings.CutSuffix(pattern, "*")
+
+	if !isPrefix {
+		if _, ok := m.exact[pattern]; ok {
+			panic(fmt.Sprintf("webhook pattern '%s' is already registered", pattern))
+		}
+
+		m.exact[pattern] = handler
+
+		return
+	}
+
+	if slices.ContainsFunc(m.prefixes, func(r *webhookPrefixRoute) bool { return r.pattern == pattern }) {
+		panic(fmt.Sprintf("webhook pattern '%s' is already registered", pattern))
+	}
+
+	m.prefixes = append(m.prefixes, &webhookPrefixRoute{pattern: pattern, prefix: prefix, handler: handler})
+
+	// Longest prefix first
+	slices.SortStableFunc(m.prefixes, func(a, b *webhookPrefixRoute) int {
+		return len(b.prefix) - len(a.prefix)
+	})
+}
+
+// HandleFunc registers the handler function for the pattern, see Handle.
+func (m *WebhookMux) HandleFunc(pattern string, handler func(ctx context.Context, target string, callback *WebhookCallback) error) {
+	m.Handle(pattern, WebhookHandlerFunc(handler))
+}
+
+// Use adds middleware applied to all callbacks handled by the mux, including callbacks without a matching handler.
+// The first middleware is the outermost.
+func (m *WebhookMux) Use(middleware ...WebhookMiddleware) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.middleware = append(m.middleware, middleware...)
+}
+
+// Handler returns the handler and pattern for the target, and false if no pattern matches.
+// The handler is returned without the mux middleware.
+func (m *WebhookMux) Handler(target string) (WebhookHandler, string, bool) { //nolint:ireturn
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if handler, ok := m.exact[target]; ok {
+		return handler, target, true
+	}
+
+	for _, route := range m.prefixes {
+		if strings.HasPrefix(target, route.prefix) {
+			return route.handler, route.pattern, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// HandleWebhook dispatches the callback to the handler matching the target, through the mux middleware.
+// An error wrapping ErrWebhookHandlerNotFound is returned if no pattern matches.
+func (m *WebhookMux) HandleWebhook(ctx context.Context, target string, callback *WebhookCallback) error {
+	handler, pattern, ok := m.Handler(target)
+	if !ok {
+		handler = WebhookHandlerFunc(func(_ context.Context, target string, _ *WebhookCallback) error {
+			return fmt.Errorf("%w for target '%s'", ErrWebhookHandlerNotFound, target)
+		})
+	}
+
+	m.mu.RLock()
+	middleware := m.middleware
+	m.mu.RUnlock()
+
+	for _, mw := range slices.Backward(middleware) {
+		handler = mw(handler)
+	}
+
+	return handler.HandleWebhook(context.WithValue(ctx, webhookPatternKey{}, pattern), target, callback)
+}
+
+// WebhookLogging returns middleware logging each callback, with the target, pattern, webhook ID, user ID, channel ID and duration
+// as fields. Successful callbacks are logged at info level, and failed callbacks at error level.
+func WebhookLogging(logger Logger) WebhookMiddleware {
+	return func(next WebhookHandler) WebhookHandler {
+		return WebhookHandlerFunc(func(ctx context.Context, target string, callback *WebhookCallback) error {
+			start := time.Now()
+			err := next.HandleWebhook(ctx, target, callback)
+
+			fields := map[string]any{
+				"target":   target,
+				"pattern":  WebhookPattern(ctx),
+				"duration": time.Since(start).String(),
+			}
+
+			if callback != nil {
+				fields["webhookId"] = callback.ID
+				fields["userId"] = callback.UserID
+				fields["channelId"] = callback.ChannelID
+			}
+
+			if err != nil {
+				logger.WithFields(fields).Errorf("Webhook callback failed: %v", err)
+			} else {
+				logger.WithFields(fields).Info("Webhook callback handled")
+			}
+
+			return err
+		})
+	}
+}
+
+const (
+	// WebhookDurationMetric is the histogram registered by the WebhookMetrics middleware, with the labels 'pattern' and 'result'.
+	WebhookDurationMetric = "webhook_handler_duration_seconds"
+
+	// WebhookResultOK is the result label of successfully handled callbacks.
+	WebhookResultOK = "ok"
+
+	// WebhookResultError is the result label of failed callbacks.
+	WebhookResultError = "error"
+
+	// WebhookResultNotFound is the result label of callbacks without a matching handler.
+	WebhookResultNotFound = "not_found"
+
+	// WebhookResultDenied is the result label of callbacks denied by the WebhookAccess middleware.
+	WebhookResultDenied = "denied"
+)
+
+// WebhookMetrics returns middleware observing the duration of each callback in the WebhookDurationMetric histogram,
+// labelled with the matched pattern (see WebhookPattern) and the result. The histogram is registered when WebhookMetrics is called.
+func WebhookMetrics(metrics Metrics) WebhookMiddleware {
+	metrics.RegisterHistogram(WebhookDurationMetric, "Duration of webhook handler callbacks, in seconds", nil, "pattern", "result")
+
+	return func(next WebhookHandler) WebhookHandler {
+		return WebhookHandlerFunc(func(ctx context.Context, target string, callback *WebhookCallback) error {
+			start := time.Now()
+			err := next.HandleWebhook(ctx, target, callback)
+
+			result := WebhookResultOK
+
+			switch {
+			case errors.Is(err, ErrWebhookHandlerNotFound):
+				result = WebhookResultNotFound
+			case errors.Is(err, ErrWebhookAccessDenied):
+				result = WebhookResultDenied
+			case err != nil:
+				result = WebhookResultError
+			}
+
+			metrics.Observe(WebhookDurationMetric, time.Since(start).Seconds(), WebhookPattern(ctx), result)
+
+			return err
+		})
+	}
+}
+
+// WebhookAccessFunc decides whether the user in the callback may invoke the webhook target.
+type WebhookAccessFunc func(ctx context.Context, target string, callback *WebhookCallback) bool
+
+// WebhookAccess returns middleware calling the next handler only if allowed returns true.
+// Otherwise, an error wrapping ErrWebhookAccessDenied is returned.
+func WebhookAccess(allowed WebhookAccessFunc) WebhookMiddleware {
+	return func(next WebhookHandler) WebhookHandler {
+		return WebhookHandlerFunc(func(ctx context.Context, target string, callback *WebhookCallback) error {
+			if !allowed(ctx, target, callback) {
+				userID := ""
+				if callback != nil {
+					userID = callback.UserID
+				}
+
+				return fmt.Errorf("%w: user '%s' may not invoke '%s'", ErrWebhookAccessDenied, userID, target)
+			}
+
+			return next.HandleWebhook(ctx, target, callback)
+		})
+	}
+}
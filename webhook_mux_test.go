@@ -0,0 +1,263 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	types.NoopLogger
+
+	mu      sync.Mutex
+	fields  map[string]any
+	entries []string
+}
+
+func (l *recordingLogger) Info(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, "info: "+msg)
+}
+
+func (l *recordingLogger) Errorf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, "error: "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) WithFields(fields map[string]any) types.Logger { //nolint:ireturn
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fields = fields
+	return l
+}
+
+type recordingMetrics struct {
+	types.NoopMetrics
+
+	mu           sync.Mutex
+	histograms   []string
+	observations [][]string
+	counters     []string
+}
+
+func (m *recordingMetrics) Inc(name string, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters = append(m.counters, fmt.Sprintf("%s%v", name, labelValues))
+}
+
+func (m *recordingMetrics) RegisterHistogram(name, _ string, _ []float64, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.histograms = append(m.histograms, fmt.Sprintf("%s%v", name, labels))
+}
+
+func (m *recordingMetrics) Observe(name string, _ float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observations = append(m.observations, append([]string{name}, labelValues...))
+}
+
+// namedHandler returns a handler recording its name and the matched pattern in the callback payload.
+func namedHandler(name string) types.WebhookHandlerFunc {
+	return func(ctx context.Context, _ string, callback *types.WebhookCallback) error {
+		callback.Payload = map[string]any{"handler": name, "pattern": types.WebhookPattern(ctx)}
+		return nil
+	}
+}
+
+func TestWebhookMux(t *testing.T) {
+	t.Parallel()
+
+	t.Run("callbacks should be routed by exact target or longest prefix", func(t *testing.T) {
+		t.Parallel()
+
+		mux := types.NewWebhookMux()
+		mux.Handle("restart-pods", namedHandler("restart"))
+		mux.Handle("runbook:*", namedHandler("runbook"))
+		mux.Handle("runbook:checkout:*", namedHandler("checkout-runbook"))
+		mux.Handle("runbook:checkout:rollback", namedHandler("rollback"))
+
+		tests := []struct {
+			target  string
+			handler string
+			pattern string
+		}{
+			{"restart-pods", "restart", "restart-pods"},
+			{"runbook:search", "runbook", "runbook:*"},
+			{"runbook:", "runbook", "runbook:*"},
+			{"runbook:checkout:scale", "checkout-runbook", "runbook:checkout:*"},
+			{"runbook:checkout:rollback", "rollback", "runbook:checkout:rollback"},
+		}
+
+		for _, tt := range tests {
+			callback := &types.WebhookCallback{}
+			require.NoError(t, mux.HandleWebhook(context.Background(), tt.target, callback), tt.target)
+			assert.Equal(t, map[string]any{"handler": tt.handler, "pattern": tt.pattern}, callback.Payload, tt.target)
+		}
+	})
+
+	t.Run("unknown target should fail", func(t *testing.T) {
+		t.Parallel()
+
+		mux := types.NewWebhookMux()
+		mux.Handle("restart-pods", namedHandler("restart"))
+
+		err := mux.HandleWebhook(context.Background(), "restart", &types.WebhookCallback{})
+		require.ErrorIs(t, err, types.ErrWebhookHandlerNotFound)
+		require.ErrorContains(t, err, "target 'restart'")
+
+		_, _, ok := mux.Handler("restart")
+		assert.False(t, ok)
+	})
+
+	t.Run("handler should receive the context, target and callback", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{}
+
+		mux := types.NewWebhookMux()
+		mux.HandleFunc("scale:*", func(ctx context.Context, target string, callback *types.WebhookCallback) error {
+			assert.Equal(t, "value", ctx.Value(key{}))
+			assert.Equal(t, "scale:checkout", target)
+			assert.Equal(t, "scale", callback.ID)
+			return errors.New("scaling failed")
+		})
+
+		ctx := context.WithValue(context.Background(), key{}, "value")
+		err := mux.HandleWebhook(ctx, "scale:checkout", &types.WebhookCallback{ID: "scale"})
+		require.EqualError(t, err, "scaling failed")
+	})
+
+	t.Run("middleware should be applied in order to all callbacks", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+
+		trace := func(name string) types.WebhookMiddleware {
+			return func(next types.WebhookHandler) types.WebhookHandler {
+				return types.WebhookHandlerFunc(func(ctx context.Context, target string, callback *types.WebhookCallback) error {
+					calls = append(calls, name+" "+types.WebhookPattern(ctx))
+					return next.HandleWebhook(ctx, target, callback)
+				})
+			}
+		}
+
+		mux := types.NewWebhookMux()
+		mux.Use(trace("first"), trace("second"))
+		mux.Handle("restart-*", namedHandler("restart"))
+
+		require.NoError(t, mux.HandleWebhook(context.Background(), "restart-pods", &types.WebhookCallback{}))
+		require.Error(t, mux.HandleWebhook(context.Background(), "scale", &types.WebhookCallback{}))
+
+		assert.Equal(t, []string{"first restart-*", "second restart-*", "first ", "second "}, calls)
+	})
+
+	t.Run("mux should be nestable", func(t *testing.T) {
+		t.Parallel()
+
+		inner := types.NewWebhookMux()
+		inner.Handle("runbook:checkout", namedHandler("checkout"))
+
+		mux := types.NewWebhookMux()
+		mux.Handle("runbook:*", inner)
+
+		callback := &types.WebhookCallback{}
+		require.NoError(t, mux.HandleWebhook(context.Background(), "runbook:checkout", callback))
+		assert.Equal(t, "checkout", callback.Payload["handler"])
+	})
+
+	t.Run("invalid registrations should panic", func(t *testing.T) {
+		t.Parallel()
+
+		mux := types.NewWebhookMux()
+		mux.Handle("restart-pods", namedHandler("restart"))
+		mux.Handle("runbook:*", namedHandler("runbook"))
+
+		assert.PanicsWithValue(t, "invalid webhook pattern ''", func() { mux.Handle("", namedHandler("empty")) })
+		assert.PanicsWithValue(t, "invalid webhook pattern '*'", func() { mux.Handle("*", namedHandler("all")) })
+		assert.PanicsWithValue(t, "nil handler for webhook pattern 'scale'", func() { mux.Handle("scale", nil) })
+		assert.PanicsWithValue(t, "webhook pattern 'restart-pods' is already registered", func() { mux.Handle("restart-pods", namedHandler("restart")) })
+		assert.PanicsWithValue(t, "webhook pattern 'runbook:*' is already registered", func() { mux.Handle("runbook:*", namedHandler("runbook")) })
+	})
+}
+
+func TestWebhookLogging(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+
+	mux := types.NewWebhookMux()
+	mux.Use(types.WebhookLogging(logger))
+	mux.Handle("restart-pods", namedHandler("restart"))
+
+	callback := &types.WebhookCallback{ID: "restart", UserID: "U1", ChannelID: "C1"}
+	require.NoError(t, mux.HandleWebhook(context.Background(), "restart-pods", callback))
+
+	assert.Equal(t, []string{"info: Webhook callback handled"}, logger.entries)
+	assert.Equal(t, "restart-pods", logger.fields["target"])
+	assert.Equal(t, "restart-pods", logger.fields["pattern"])
+	assert.Equal(t, "restart", logger.fields["webhookId"])
+	assert.Equal(t, "U1", logger.fields["userId"])
+	assert.Equal(t, "C1", logger.fields["channelId"])
+	assert.NotEmpty(t, logger.fields["duration"])
+
+	require.Error(t, mux.HandleWebhook(context.Background(), "scale", nil))
+	assert.Equal(t, "error: Webhook callback failed: webhook handler not found for target 'scale'", logger.entries[1])
+	assert.Empty(t, logger.fields["pattern"])
+}
+
+func TestWebhookMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := &recordingMetrics{}
+
+	mux := types.NewWebhookMux()
+	mux.Use(types.WebhookMetrics(metrics), types.WebhookAccess(func(_ context.Context, _ string, callback *types.WebhookCallback) bool {
+		return callback.UserID != "U2"
+	}))
+	mux.Handle("restart-*", namedHandler("restart"))
+	mux.HandleFunc("scale", func(context.Context, string, *types.WebhookCallback) error { return errors.New("failed") })
+
+	_ = mux.HandleWebhook(context.Background(), "restart-pods", &types.WebhookCallback{UserID: "U1"})
+	_ = mux.HandleWebhook(context.Background(), "restart-pods", &types.WebhookCallback{UserID: "U2"})
+	_ = mux.HandleWebhook(context.Background(), "scale", &types.WebhookCallback{UserID: "U1"})
+	_ = mux.HandleWebhook(context.Background(), "rollback", &types.WebhookCallback{UserID: "U1"})
+
+	assert.Equal(t, []string{"webhook_handler_duration_seconds[pattern result]"}, metrics.histograms)
+	assert.Equal(t, [][]string{
+		{types.WebhookDurationMetric, "restart-*", types.WebhookResultOK},
+		{types.WebhookDurationMetric, "restart-*", types.WebhookResultDenied},
+		{types.WebhookDurationMetric, "scale", types.WebhookResultError},
+		{types.WebhookDurationMetric, "", types.WebhookResultNotFound},
+	}, metrics.observations)
+}
+
+func TestWebhookAccess(t *testing.T) {
+	t.Parallel()
+
+	admins := types.WebhookAccess(func(_ context.Context, target string, callback *types.WebhookCallback) bool {
+		return target == "restart-pods" && callback.UserID == "U1"
+	})
+
+	mux := types.NewWebhookMux()
+	mux.Handle("restart-pods", admins(namedHandler("restart")))
+
+	callback := &types.WebhookCallback{UserID: "U1"}
+	require.NoError(t, mux.HandleWebhook(context.Background(), "restart-pods", callback))
+	assert.Equal(t, "restart", callback.Payload["handler"])
+
+	callback = &types.WebhookCallback{UserID: "U2"}
+	err := mux.HandleWebhook(context.Background(), "restart-pods", callback)
+	require.ErrorIs(t, err, types.ErrWebhookAccessDenied)
+	require.ErrorContains(t, err, "user 'U2' may not invoke 'restart-pods'")
+	assert.Nil(t, callback.Payload)
+}
@@ -0,0 +1,225 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader is the HTTP header holding the signature of a webhook callback request, see SignWebhookPayload.
+	WebhookSignatureHeader = "X-Slack-Manager-Signature"
+
+	// WebhookTimestampHeader is the HTTP header holding the time a webhook callback request was signed, in Unix seconds.
+	WebhookTimestampHeader = "X-Slack-Manager-Timestamp"
+
+	// WebhookTimestampTolerance is the maximum difference between the WebhookTimestampHeader and the current time accepted
+	// by VerifyWebhookCallback, protecting against replayed requests.
+	WebhookTimestampTolerance = 5 * time.Minute
+
+	// MaxWebhookCallbackBodySize is the maximum request body size accepted by VerifyWebhookCallback, in bytes.
+	MaxWebhookCallbackBodySize = 1 << 20
+
+	// webhookSignatureVersion prefixes signatures, so that the signing scheme can be changed later.
+	webhookSignatureVersion = "v1"
+)
+
+// ErrWebhookSignatureInvalid is returned (wrapped) by VerifyWebhookCallback when a request has a missing or invalid signature.
+var ErrWebhookSignatureInvalid = errors.New("invalid webhook signature")
+
+// ErrWebhookTimestampInvalid is returned (wrapped) by VerifyWebhookCallback when a request has a missing, invalid or stale timestamp.
+var ErrWebhookTimestampInvalid = errors.New("invalid webhook timestamp")
+
+// SignWebhookPayload returns the signature of a webhook request body, sent at timestamp (in Unix seconds), as
+// 'v1=' followed by the hex-encoded HMAC-SHA256 of 'v1:<timestamp>:<body>' keyed with secret.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(webhookSignatureVersion + ":" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(body)
+
+	return webhookSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookVerifierOptions configures a WebhookVerifier. Use DefaultWebhookVerifierOptions to get the default options.
+type WebhookVerifierOptions struct {
+	// Tolerance is the maximum difference between the request timestamp and the current time, in either direction.
+	// If zero, WebhookTimestampTolerance is used.
+	Tolerance time.Duration
+
+	// Clock provides the current time that request timestamps are compared with. If nil, the system clock is used.
+	Clock Clock
+}
+
+// DefaultWebhookVerifierOptions returns a new WebhookVerifierOptions instance, with a tolerance of WebhookTimestampTolerance
+// and the system clock.
+func DefaultWebhookVerifierOptions() *WebhookVerifierOptions {
+	return &WebhookVerifierOptions{
+		Tolerance: WebhookTimestampTolerance,
+		Clock:     SystemClock(),
+	}
+}
+
+// WebhookVerifier verifies the signatures of webhook callback requests sent by a WebhookDispatcher. It is safe for concurrent use.
+type WebhookVerifier struct {
+	secrets   []string
+	tolerance time.Duration
+	clock     Clock
+}
+
+// NewWebhookVerifier creates a new WebhookVerifier accepting requests signed with any of the secrets, so that secrets can be
+// rotated without downtime, using the specified options (or the default options if nil). Empty secrets are ignored,
+// and an error is returned if no secret remains.
+func NewWebhookVerifier(opts *WebhookVerifierOptions, secrets ...string) (*WebhookVerifier, error) {
+	defaults := DefaultWebhookVerifierOptions()
+
+	if opts == nil {
+		opts = defaults
+	}
+
+	v := &WebhookVerifier{tolerance: opts.Tolerance, clock: opts.Clock}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			v.secrets = append(v.secrets, secret)
+		}
+	}
+
+	if len(v.secrets) == 0 {
+		return nil, errors.New("webhook verifier requires at least one non-empty secret")
+	}
+
+	if v.tolerance <= 0 {
+		v.tolerance = defaults.Tolerance
+	}
+
+	if v.clock == nil {
+		v.clock = defaults.Clock
+	}
+
+	return v, nil
+}
+
+// VerifyWebhookCallback verifies a webhook callback request like WebhookVerifier.Verify, with the default options.
+// An error is returned if no non-empty secret is given.
+func VerifyWebhookCallback(r *http.Request, secrets ...string) (*WebhookCallback, error) {
+	v, err := NewWebhookVerifier(nil, secrets...)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Verify(r)
+}
+
+// Verify verifies the signature of a webhook callback request, and decodes the body into a WebhookCallback.
+// The request is accepted if it is signed with any of the secrets, and if its timestamp is within the tolerance
+// of the current time. Errors wrap ErrWebhookSignatureInvalid or ErrWebhookTimestampInvalid when the request could
+// not be verified. The request body is consumed, and replaced with a copy so that it can be read again.
+func (v *WebhookVerifier) Verify(r *http.Request) (*WebhookCallback, error) {
+	if r.Body == nil {
+		return nil, errors.New("webhook request has no body")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxWebhookCallbackBodySize+1))
+	_ = r.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook request body: %w", err)
+	}
+
+	if len(body) > MaxWebhookCallbackBodySize {
+		return nil, fmt.Errorf("webhook request body exceeds %d bytes", MaxWebhookCallbackBodySize)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	timestampHeader := r.Header.Get(WebhookTimestampHeader)
+
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s' is not a Unix timestamp", ErrWebhookTimestampInvalid, timestampHeader)
+	}
+
+	signature := r.Header.Get(WebhookSignatureHeader)
+	if signature == "" {
+		return nil, fmt.Errorf("%w: missing %s header", ErrWebhookSignatureInvalid, WebhookSignatureHeader)
+	}
+
+	if !matchesWebhookSignature(signature, timestamp, body, v.secrets) {
+		return nil, fmt.Errorf("%w: signature does not match any secret", ErrWebhookSignatureInvalid)
+	}
+
+	if age := v.clock.Now().Sub(time.Unix(timestamp, 0)); age > v.tolerance || age < -v.tolerance {
+		return nil, fmt.Errorf("%w: timestamp %d is more than %s from the current time", ErrWebhookTimestampInvalid, timestamp, v.tolerance)
+	}
+
+	var callback WebhookCallback
+
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook callback: %w", err)
+	}
+
+	return &callback, nil
+}
+
+// matchesWebhookSignature reports whether signature matches the body signed with any of the secrets.
+func matchesWebhookSignature(signature string, timestamp int64, body []byte, secrets []string) bool {
+	for _, secret := range secrets {
+		if hmac.Equal([]byte(signature), []byte(SignWebhookPayload(secret, timestamp, body))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// webhookCallbackKey is the context key of the callback verified by the WebhookVerifier middleware.
+type webhookCallbackKey struct{}
+
+// WebhookCallbackFromContext returns the callback verified by the WebhookVerifier middleware (see VerifyWebhookSignature),
+// or nil if the context does not come from the middleware.
+func WebhookCallbackFromContext(ctx context.Context) *WebhookCallback {
+	callback, _ := ctx.Value(webhookCallbackKey{}).(*WebhookCallback)
+	return callback
+}
+
+// VerifyWebhookSignature returns HTTP middleware verifying webhook callback requests, with the default options.
+// See WebhookVerifier.Middleware. It panics if no non-empty secret is given, so that a misconfiguration is detected at startup
+// rather than by rejecting every request.
+func VerifyWebhookSignature(secrets ...string) func(next http.Handler) http.Handler {
+	v, err := NewWebhookVerifier(nil, secrets...)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return v.Middleware
+}
+
+// Middleware returns an HTTP handler verifying webhook callback requests with Verify. Verified requests are passed to next,
+// with the decoded callback available from WebhookCallbackFromContext. Requests with an invalid signature or timestamp are
+// rejected with 401 Unauthorized, and other invalid requests with 400 Bad Request.
+func (v *WebhookVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callback, err := v.Verify(r)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, ErrWebhookSignatureInvalid) || errors.Is(err, ErrWebhookTimestampInvalid) {
+				status = http.StatusUnauthorized
+			}
+
+			http.Error(w, http.StatusText(status), status)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), webhookCallbackKey{}, callback)))
+	})
+}
@@ -0,0 +1,248 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedRequest returns a webhook callback request for body, signed with secret at timestamp.
+func signedRequest(t *testing.T, secret string, timestamp time.Time, body string) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	r.Header.Set(types.WebhookTimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	r.Header.Set(types.WebhookSignatureHeader, types.SignWebhookPayload(secret, timestamp.Unix(), []byte(body)))
+
+	return r
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	t.Parallel()
+
+	signature := types.SignWebhookPayload("s3cret", 1767225600, []byte(`{"id":"restart"}`))
+
+	assert.Equal(t, "v1=fc655d3117ebd46b1278c2e8479df5b8705e177ca207d61d7626ce0e036c5471", signature)
+	assert.NotEqual(t, signature, types.SignWebhookPayload("s3cret", 1767225601, []byte(`{"id":"restart"}`)))
+	assert.NotEqual(t, signature, types.SignWebhookPayload("other", 1767225600, []byte(`{"id":"restart"}`)))
+}
+
+func TestVerifyWebhookCallback(t *testing.T) {
+	t.Parallel()
+
+	body := `{"id":"restart","userId":"U1","channelId":"C1","payload":{"service":"checkout"}}`
+
+	t.Run("valid signature should return the callback", func(t *testing.T) {
+		t.Parallel()
+
+		r := signedRequest(t, "s3cret", time.Now(), body)
+
+		callback, err := types.VerifyWebhookCallback(r, "s3cret")
+		require.NoError(t, err)
+		assert.Equal(t, "restart", callback.ID)
+		assert.Equal(t, "U1", callback.UserID)
+		assert.Equal(t, "checkout", callback.GetPayloadString("service"))
+
+		again, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, body, string(again))
+	})
+
+	t.Run("any of the secrets should be accepted", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.VerifyWebhookCallback(signedRequest(t, "old", time.Now(), body), "", "new", "old")
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid signatures should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.VerifyWebhookCallback(signedRequest(t, "other", time.Now(), body), "s3cret")
+		require.ErrorIs(t, err, types.ErrWebhookSignatureInvalid)
+
+		r := signedRequest(t, "s3cret", time.Now(), body)
+		r.Header.Del(types.WebhookSignatureHeader)
+		_, err = types.VerifyWebhookCallback(r, "s3cret")
+		require.ErrorIs(t, err, types.ErrWebhookSignatureInvalid)
+		require.ErrorContains(t, err, "missing X-Slack-Manager-Signature header")
+	})
+
+	t.Run("missing secrets should fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.VerifyWebhookCallback(signedRequest(t, "", time.Now(), body))
+		require.EqualError(t, err, "webhook verifier requires at least one non-empty secret")
+
+		_, err = types.NewWebhookVerifier(nil, "", "")
+		require.EqualError(t, err, "webhook verifier requires at least one non-empty secret")
+	})
+
+	t.Run("tampered requests should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+
+		r := signedRequest(t, "s3cret", now, body)
+		r.Body = io.NopCloser(strings.NewReader(strings.Replace(body, "U1", "U2", 1)))
+		_, err := types.VerifyWebhookCallback(r, "s3cret")
+		require.ErrorIs(t, err, types.ErrWebhookSignatureInvalid)
+
+		r = signedRequest(t, "s3cret", now, body)
+		r.Header.Set(types.WebhookTimestampHeader, strconv.FormatInt(now.Unix()+1, 10))
+		_, err = types.VerifyWebhookCallback(r, "s3cret")
+		require.ErrorIs(t, err, types.ErrWebhookSignatureInvalid)
+	})
+
+	t.Run("stale timestamps should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		for _, timestamp := range []time.Time{time.Now().Add(-10 * time.Minute), time.Now().Add(10 * time.Minute)} {
+			_, err := types.VerifyWebhookCallback(signedRequest(t, "s3cret", timestamp, body), "s3cret")
+			require.ErrorIs(t, err, types.ErrWebhookTimestampInvalid)
+			require.ErrorContains(t, err, "is more than 5m0s from the current time")
+		}
+
+		_, err := types.VerifyWebhookCallback(signedRequest(t, "s3cret", time.Now().Add(-time.Minute), body), "s3cret")
+		require.NoError(t, err)
+	})
+
+	t.Run("missing timestamp should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		r := signedRequest(t, "s3cret", time.Now(), body)
+		r.Header.Del(types.WebhookTimestampHeader)
+
+		_, err := types.VerifyWebhookCallback(r, "s3cret")
+		require.ErrorIs(t, err, types.ErrWebhookTimestampInvalid)
+		require.ErrorContains(t, err, "'' is not a Unix timestamp")
+	})
+
+	t.Run("invalid bodies should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.VerifyWebhookCallback(signedRequest(t, "s3cret", time.Now(), "not json"), "s3cret")
+		require.ErrorContains(t, err, "failed to decode webhook callback")
+
+		_, err = types.VerifyWebhookCallback(signedRequest(t, "s3cret", time.Now(), strings.Repeat(" ", types.MaxWebhookCallbackBodySize+1)), "s3cret")
+		require.ErrorContains(t, err, "webhook request body exceeds 1048576 bytes")
+	})
+}
+
+func TestWebhookVerifier(t *testing.T) {
+	t.Parallel()
+
+	body := `{"id":"restart"}`
+	signed := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("timestamps should be checked against the clock", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			now   time.Time
+			valid bool
+		}{
+			{signed, true},
+			{signed.Add(5 * time.Minute), true},
+			{signed.Add(-5 * time.Minute), true},
+			{signed.Add(5*time.Minute + time.Second), false},
+			{signed.Add(-5*time.Minute - time.Second), false},
+		}
+
+		for _, tt := range tests {
+			v, err := types.NewWebhookVerifier(&types.WebhookVerifierOptions{Clock: types.FixedClock(tt.now)}, "s3cret")
+			require.NoError(t, err)
+
+			_, err = v.Verify(signedRequest(t, "s3cret", signed, body))
+			if tt.valid {
+				require.NoError(t, err, tt.now)
+			} else {
+				require.ErrorIs(t, err, types.ErrWebhookTimestampInvalid, tt.now)
+			}
+		}
+	})
+
+	t.Run("custom tolerance should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := types.DefaultWebhookVerifierOptions()
+		opts.Tolerance = time.Minute
+		opts.Clock = types.FixedClock(signed.Add(2 * time.Minute))
+
+		v, err := types.NewWebhookVerifier(opts, "s3cret")
+		require.NoError(t, err)
+
+		_, err = v.Verify(signedRequest(t, "s3cret", signed, body))
+		require.ErrorIs(t, err, types.ErrWebhookTimestampInvalid)
+		require.ErrorContains(t, err, "is more than 1m0s from the current time")
+	})
+
+	t.Run("middleware should use the verifier options", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := types.NewWebhookVerifier(&types.WebhookVerifierOptions{Clock: types.FixedClock(signed)}, "s3cret")
+		require.NoError(t, err)
+
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, types.WebhookCallbackFromContext(r.Context()).ID)
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedRequest(t, "s3cret", signed, body))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "restart", w.Body.String())
+	})
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	body := `{"id":"restart","userId":"U1"}`
+
+	handler := types.VerifyWebhookSignature("s3cret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callback := types.WebhookCallbackFromContext(r.Context())
+		_ = json.NewEncoder(w).Encode(callback.UserID)
+	}))
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"valid request", signedRequest(t, "s3cret", time.Now(), body), http.StatusOK},
+		{"invalid signature", signedRequest(t, "other", time.Now(), body), http.StatusUnauthorized},
+		{"stale timestamp", signedRequest(t, "s3cret", time.Now().Add(-time.Hour), body), http.StatusUnauthorized},
+		{"invalid body", signedRequest(t, "s3cret", time.Now(), "not json"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.req)
+
+			assert.Equal(t, tt.status, w.Code)
+
+			if tt.status == http.StatusOK {
+				assert.JSONEq(t, `"U1"`, w.Body.String())
+			}
+		})
+	}
+
+	assert.Nil(t, types.WebhookCallbackFromContext(context.Background()))
+
+	assert.PanicsWithValue(t, "webhook verifier requires at least one non-empty secret", func() { types.VerifyWebhookSignature() })
+	assert.PanicsWithValue(t, "webhook verifier requires at least one non-empty secret", func() { types.VerifyWebhookSignature("") })
+}